@@ -225,7 +225,7 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "Become a validator",
+                "description": "Apply for the validator role, it is granted after the verification of the uploaded documents",
                 "consumes": [
                     "application/json"
                 ],
@@ -263,32 +263,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/bookmarks": {
+        "/blobs/{blob_id}": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get bookmarks",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Download the uploaded document, available to its owner, admins and the readers of the work's content",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "Bookmarks"
+                    "Docs"
+                ],
+                "summary": "Download document",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "document id",
+                        "name": "blob_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get bookmarks",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/storage.WorkResponse"
-                            }
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -297,46 +300,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/rest.ErrorMsg"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorMsg"
                         }
-                    }
-                }
-            }
-        },
-        "/faucet": {
-            "get": {
-                "description": "Mints 50 SOW tokens to web3_address",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Faucet"
-                ],
-                "summary": "Faucet SOW tokens",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "participant web3 address",
-                        "name": "web3_address",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "string"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorMsg"
                         }
@@ -344,14 +315,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/get_basic_info": {
-            "post": {
+        "/bookmarks": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get basic info",
+                "description": "Get bookmarks",
                 "consumes": [
                     "application/json"
                 ],
@@ -359,14 +330,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Participants"
+                    "Bookmarks"
                 ],
-                "summary": "Get info",
+                "summary": "Get bookmarks",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.BasicInfo"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/storage.WorkResponse"
+                            }
                         }
                     },
                     "400": {
@@ -374,37 +348,44 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorMsg"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
                     }
                 }
             }
         },
-        "/if_participant_exists/{web3_address}": {
+        "/bookmarks/cite": {
             "get": {
-                "description": "Check participant availability",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "Bearer": []
+                    }
                 ],
+                "description": "Export the citations of the bookmarked works in BibTeX, RIS or CSL-JSON, or format them\nin the APA or GOST R 7.0.5-2008 style as the numbered list",
                 "produces": [
-                    "application/json"
+                    "text/plain"
                 ],
                 "tags": [
-                    "Participants"
+                    "Citations"
                 ],
-                "summary": "Check if participant exists",
+                "summary": "Cite bookmarks",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "participant web3 address",
-                        "name": "web3_address",
-                        "in": "path",
-                        "required": true
+                        "description": "bibtex(default), ris, csl, apa or gost",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.IfParticipantExistsResp"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -412,18 +393,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorMsg"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
                     }
                 }
             }
         },
-        "/invite_co_author": {
+        "/decide_validator/{web3_address}": {
             "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Invite co-author",
+                "description": "Approve the application under the verification and grant the validator role, or reject it with the reason(admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -431,17 +418,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authors"
+                    "Validator applications"
                 ],
-                "summary": "Invite co-author",
+                "summary": "Decide validator application",
                 "parameters": [
                     {
-                        "description": "update author info",
-                        "name": "account",
+                        "type": "string",
+                        "description": "applicant web3 address",
+                        "name": "web3_address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "decision",
+                        "name": "decision",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.BecomeAuthorRequest"
+                            "$ref": "#/definitions/rest.DecideValidatorApplicationRequest"
                         }
                     }
                 ],
@@ -449,7 +443,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.SuccessMsg"
+                            "$ref": "#/definitions/storage.Validator"
                         }
                     },
                     "400": {
@@ -457,58 +451,76 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorMsg"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
                     }
                 }
             }
         },
-        "/new_participant": {
-            "post": {
-                "description": "Become a new participant",
-                "consumes": [
-                    "application/json"
+        "/deposits": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
                 ],
+                "description": "Deposits of the works to Crossref and their statuses, the latest first(admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Participants"
+                    "Deposits"
                 ],
-                "summary": "Become a participant",
+                "summary": "Deposits",
                 "parameters": [
                     {
-                        "description": "become participant",
-                        "name": "account",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/rest.NewParticipantRequest"
-                        }
+                        "type": "string",
+                        "description": "work id",
+                        "name": "work_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "DEPOSIT_PENDING, DEPOSIT_SUBMITTED, DEPOSIT_SUCCEEDED or DEPOSIT_FAILED",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.AuthResp"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/storage.WorkDeposit"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorMsg"
                         }
                     }
                 }
-            }
-        },
-        "/publish_work": {
+            },
             "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Publish a new work",
+                "description": "Submit the deposit of the open works identified by the DOIs to Crossref(admin only). The works\ndeposited before are deposited again, e.g. after their metadata has changed. The works whose metadata\ndoesn't match the schema fail at once, the rest are processed by Crossref and checked by the cron.",
                 "consumes": [
                     "application/json"
                 ],
@@ -516,24 +528,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Publish work"
+                    "Deposits"
                 ],
-                "summary": "Publish a new work",
+                "summary": "Deposit works",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer {JWT token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "work and author info",
-                        "name": "Work",
+                        "description": "works",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/storage.WorkResponse"
+                            "$ref": "#/definitions/rest.DepositWorksRequest"
                         }
                     }
                 ],
@@ -541,7 +546,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/storage.WorkResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/storage.WorkDeposit"
+                            }
                         }
                     },
                     "400": {
@@ -550,8 +558,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/rest.ErrorMsg"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorMsg"
                         }
@@ -559,30 +573,31 @@ const docTemplate = `{
                 }
             }
         },
-        "/purchase_work/{work_id}": {
-            "post": {
+        "/deposits/xml": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Purchase particular work",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Generate the Crossref deposit(schema 5.3.1) of the open works identified by the DOIs(admin only).\nOne work or the batch of the works is deposited, the deposit is validated and isn't submitted.",
                 "produces": [
-                    "application/json"
+                    "text/xml"
                 ],
                 "tags": [
-                    "Purchasing works"
+                    "Deposits"
                 ],
-                "summary": "Purchase work",
+                "summary": "Deposit XML",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "work id to purchase",
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "work id, repeated for the batch",
                         "name": "work_id",
-                        "in": "path",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -590,7 +605,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.SuccessMsg"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -599,8 +614,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/rest.ErrorMsg"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorMsg"
                         }
@@ -608,32 +623,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/purchased_works": {
+        "/extractions/{extraction_id}": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get purchased works",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the status of the paper recognition, the draft work id is set when it's done.\nThe PDF is processed page by page, the status of each page is listed with its failure reason.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Purchasing works"
+                    "Drafts"
+                ],
+                "summary": "Paper extraction status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "extraction id",
+                        "name": "extraction_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Purchased works",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/storage.WorkResponse"
-                            }
+                            "$ref": "#/definitions/storage.DocumentExtraction"
                         }
                     },
                     "400": {
@@ -642,8 +660,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/rest.ErrorMsg"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorMsg"
                         }
@@ -651,14 +675,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/remove_bookmark": {
-            "post": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "Remove bookmarks",
+        "/faucet": {
+            "get": {
+                "description": "Mints 50 SOW tokens to web3_address",
                 "consumes": [
                     "application/json"
                 ],
@@ -666,30 +685,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Bookmarks"
+                    "Faucet"
                 ],
-                "summary": "Remove bookmarks",
+                "summary": "Faucet SOW tokens",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "author web3 address",
+                        "description": "participant web3 address",
                         "name": "web3_address",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Bearer {JWT token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.SuccessMsg"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -697,62 +709,46 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorMsg"
                         }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/rest.ErrorMsg"
-                        }
                     }
                 }
             }
         },
-        "/submit_work_review": {
-            "post": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "Submit work review by validator",
-                "consumes": [
-                    "application/json"
-                ],
+        "/feeds/works.{format}": {
+            "get": {
+                "description": "Atom 1.0 or RSS 2.0 feed of the latest works opened in the library, the latest first. The feed\nlists all the opened works or the works of the tag and(or) the language.",
                 "produces": [
-                    "application/json"
+                    "text/xml"
                 ],
                 "tags": [
-                    "Work review"
+                    "Feeds"
                 ],
-                "summary": "Submit review",
+                "summary": "Feed of the opened works",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "work id",
-                        "name": "work_id",
+                        "description": "atom or rss",
+                        "name": "format",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "enum": [
-                            "WORK_REVIEW_SUBMITTED",
-                            "WORK_REVIEW_SKIPPED",
-                            "WORK_REVIEW_REJECTED",
-                            "WORK_REVIEW_DECLINED",
-                            "WORK_REVIEW_ACCEPTED"
-                        ],
                         "type": "string",
-                        "description": "review status",
-                        "name": "status",
-                        "in": "path",
-                        "required": true
+                        "description": "tag of the works",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "language of the works, e.g. en",
+                        "name": "language",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.SuccessMsg"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -760,18 +756,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorMsg"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
                     }
                 }
             }
         },
-        "/update_author_info": {
+        "/get_basic_info": {
             "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Update participant basic info",
+                "description": "Get basic info",
                 "consumes": [
                     "application/json"
                 ],
@@ -779,25 +781,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authors"
-                ],
-                "summary": "Update participant info",
-                "parameters": [
-                    {
-                        "description": "update author info",
-                        "name": "account",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/rest.UpdateAuthorRequest"
-                        }
-                    }
+                    "Participants"
                 ],
+                "summary": "Get info",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.AuthResp"
+                            "$ref": "#/definitions/rest.BasicInfo"
                         }
                     },
                     "400": {
@@ -809,59 +800,51 @@ const docTemplate = `{
                 }
             }
         },
-        "/update_basic_info": {
-            "post": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "Update basic participant info",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+        "/id/{prefix}/{suffix}": {
+            "get": {
+                "description": "Redirect to the page of the work identified by the persistent identifier\nminted under the configured prefix, e.g. /id/10.5555/sow.2026.00042",
                 "tags": [
-                    "Participants"
+                    "Works"
                 ],
-                "summary": "Update participant info",
+                "summary": "Resolve persistent identifier",
                 "parameters": [
                     {
-                        "description": "update basic participant info",
-                        "name": "account",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/rest.BasicInfoUpdateRequest"
-                        }
+                        "type": "string",
+                        "description": "prefix of the identifier",
+                        "name": "prefix",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "suffix of the identifier",
+                        "name": "suffix",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/rest.AuthResp"
-                        }
+                    "302": {
+                        "description": "Found"
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorMsg"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
                     }
                 }
             }
         },
-        "/update_review": {
-            "post": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "Evaluate work by validator",
+        "/if_participant_exists/{web3_address}": {
+            "get": {
+                "description": "Check participant availability",
                 "consumes": [
                     "application/json"
                 ],
@@ -869,25 +852,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Work review"
+                    "Participants"
                 ],
-                "summary": "Evaluate work",
+                "summary": "Check if participant exists",
                 "parameters": [
                     {
-                        "description": "work review",
-                        "name": "account",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/rest.WorkReviewRequest"
-                        }
+                        "type": "string",
+                        "description": "participant web3 address",
+                        "name": "web3_address",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/storage.WorkReview"
+                            "$ref": "#/definitions/rest.IfParticipantExistsResp"
                         }
                     },
                     "400": {
@@ -899,14 +880,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/update_validator_info": {
+        "/invite_co_author": {
             "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Update validator info",
+                "description": "Invite co-author",
                 "consumes": [
                     "application/json"
                 ],
@@ -914,17 +895,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Validators"
+                    "Authors"
                 ],
-                "summary": "Update validator",
+                "summary": "Invite co-author",
                 "parameters": [
                     {
-                        "description": "update validator info",
+                        "description": "update author info",
                         "name": "account",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.UpdateValidatorRequest"
+                            "$ref": "#/definitions/rest.BecomeAuthorRequest"
                         }
                     }
                 ],
@@ -932,7 +913,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.AuthResp"
+                            "$ref": "#/definitions/rest.SuccessMsg"
                         }
                     },
                     "400": {
@@ -944,29 +925,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/upload_doc": {
-            "put": {
+        "/link_nft/{work_id}": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Uploading documents confirming work",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Opt the work in to be minted as NFT. The tokens aren't minted yet, the open work is linked\nto the token of its on-chain paper right away, the work under review is linked once it's\napproved and published on-chain.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Docs"
+                    "NFT"
                 ],
-                "summary": "Upload doc of work",
+                "summary": "Link work to NFT",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "work id",
-                        "name": "doc_type",
+                        "name": "work_id",
                         "in": "path",
                         "required": true
                     }
@@ -975,7 +953,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.SuccessMsg"
+                            "$ref": "#/definitions/storage.ParticipantsWork"
                         }
                     },
                     "400": {
@@ -983,18 +961,42 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorMsg"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
                     }
                 }
             }
         },
-        "/validator_info/upload_docs": {
-            "post": {
+        "/my_validator_application": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Uploading documents confirming competencies of validator",
+                "description": "Get the status of the participant's validator application with the uploaded diplomas",
                 "consumes": [
                     "application/json"
                 ],
@@ -1002,14 +1004,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Validators"
+                    "Validator applications"
                 ],
-                "summary": "Upload validator documents",
+                "summary": "My validator application",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.SuccessMsg"
+                            "$ref": "#/definitions/storage.ValidatorApplication"
                         }
                     },
                     "400": {
@@ -1017,13 +1019,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorMsg"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
                     }
                 }
             }
         },
-        "/validator_info/{web3_address}": {
-            "get": {
-                "description": "Validator full info",
+        "/new_participant": {
+            "post": {
+                "description": "Become a new participant",
                 "consumes": [
                     "application/json"
                 ],
@@ -1031,23 +1039,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Validators"
+                    "Participants"
                 ],
-                "summary": "Validator info",
+                "summary": "Become a participant",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "validator web3 address",
-                        "name": "web3_address",
-                        "in": "path",
-                        "required": true
+                        "description": "become participant",
+                        "name": "account",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.NewParticipantRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/storage.ValidatorResponse"
+                            "$ref": "#/definitions/rest.AuthResp"
                         }
                     },
                     "400": {
@@ -1059,65 +1069,186 @@ const docTemplate = `{
                 }
             }
         },
-        "/work_data": {
+        "/nft/{token_id}": {
             "get": {
-                "description": "Mock work data",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "ERC-721 metadata JSON of the work linked to the token: the name, the annotation as the description,\nthe image and the attributes for the tags, the language, the science and the authors",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Publish work"
+                    "NFT"
+                ],
+                "summary": "NFT metadata",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token id",
+                        "name": "token_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Mock work data",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.PublishWorkDataResp"
+                            "$ref": "#/definitions/srv.NFTMetadata"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
                         }
                     }
                 }
             }
         },
-        "/work_review/{work_id}": {
+        "/oai": {
             "get": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "Get all work reviews by work id",
+                "description": "OAI-PMH 2.0 endpoint for the harvesters of the open works: Identify, ListMetadataFormats, ListSets,\nListIdentifiers, ListRecords and GetRecord. The records are disseminated in oai_dc and jats(the front\nmatter of the JATS article), the sets are the sciences and the tags, the datestamps are the times\nthe works were opened. The errors of the request are reported in the OAI-PMH response.",
                 "consumes": [
-                    "application/json"
+                    "application/x-www-form-urlencoded"
                 ],
                 "produces": [
-                    "application/json"
+                    "text/xml"
                 ],
                 "tags": [
-                    "Work review"
+                    "Harvesting"
                 ],
-                "summary": "Work reviews",
+                "summary": "OAI-PMH provider",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "work id",
-                        "name": "work_id",
-                        "in": "path",
+                        "description": "OAI-PMH verb",
+                        "name": "verb",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "oai:{host}:{work_id}",
+                        "name": "identifier",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "oai_dc or jats",
+                        "name": "metadataPrefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "YYYY-MM-DD or YYYY-MM-DDThh:mm:ssZ",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "YYYY-MM-DD or YYYY-MM-DDThh:mm:ssZ",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "set spec",
+                        "name": "set",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "resumption token",
+                        "name": "resumptionToken",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/storage.WorkReview"
+                            "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "OAI-PMH 2.0 endpoint for the harvesters of the open works: Identify, ListMetadataFormats, ListSets,\nListIdentifiers, ListRecords and GetRecord. The records are disseminated in oai_dc and jats(the front\nmatter of the JATS article), the sets are the sciences and the tags, the datestamps are the times\nthe works were opened. The errors of the request are reported in the OAI-PMH response.",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "Harvesting"
+                ],
+                "summary": "OAI-PMH provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "OAI-PMH verb",
+                        "name": "verb",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "oai:{host}:{work_id}",
+                        "name": "identifier",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "oai_dc or jats",
+                        "name": "metadataPrefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "YYYY-MM-DD or YYYY-MM-DDThh:mm:ssZ",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "YYYY-MM-DD or YYYY-MM-DDThh:mm:ssZ",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "set spec",
+                        "name": "set",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "resumption token",
+                        "name": "resumptionToken",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorMsg"
                         }
@@ -1125,14 +1256,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/work_reviews/{work_id}": {
-            "get": {
+        "/publish_work": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get work reviews by work_id",
+                "description": "Publish a new work. The license is CC-BY-4.0, CC-BY-SA-4.0, CC-BY-NC-4.0, all-rights-reserved(the default one)\nor custom with the text of its terms. The work under CC-BY-4.0 or CC-BY-SA-4.0 must be open access,\ni.e. free to read once it's open, unless the platform allows to sell such works.\nThe science picks the questionnaire of the reviews, it's taken from the author's profile if it's null.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1140,26 +1271,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Work review"
+                    "Publish work"
                 ],
-                "summary": "Get work reviews",
+                "summary": "Publish a new work",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "work id",
-                        "name": "work_id",
-                        "in": "path",
+                        "description": "Bearer {JWT token}",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "work and author info",
+                        "name": "Work",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/storage.WorkResponse"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/storage.WorkReview"
-                            }
+                            "$ref": "#/definitions/storage.WorkResponse"
                         }
                     },
                     "400": {
@@ -1167,13 +1304,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorMsg"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
                     }
                 }
             }
         },
-        "/works": {
-            "get": {
-                "description": "Get all works depends on role",
+        "/purchase_work/{work_id}": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Purchase particular work",
                 "consumes": [
                     "application/json"
                 ],
@@ -1181,17 +1329,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Works"
+                    "Purchasing works"
+                ],
+                "summary": "Purchase work",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "work id to purchase",
+                        "name": "work_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all works",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/storage.WorkResponse"
-                            }
+                            "$ref": "#/definitions/rest.SuccessMsg"
                         }
                     },
                     "400": {
@@ -1199,18 +1353,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorMsg"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
                     }
                 }
             }
         },
-        "/works/author/{web3_address}": {
+        "/purchased_works": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get list author` + "`" + `s works",
+                "description": "Get purchased works",
                 "consumes": [
                     "application/json"
                 ],
@@ -1218,18 +1378,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Works"
-                ],
-                "summary": "List author` + "`" + `s works",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "author web3 address",
-                        "name": "web3_address",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Purchasing works"
                 ],
+                "summary": "Purchased works",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1245,45 +1396,44 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorMsg"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
                     }
                 }
             }
         },
-        "/works/{work_id}": {
+        "/purchased_works/cite": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get work by id",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Export the citations of the purchased works in BibTeX, RIS or CSL-JSON, or format them\nin the APA or GOST R 7.0.5-2008 style as the numbered list",
                 "produces": [
-                    "application/json"
+                    "text/plain"
                 ],
                 "tags": [
-                    "Works"
+                    "Citations"
                 ],
-                "summary": "Work by id",
+                "summary": "Cite purchased works",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "work id",
-                        "name": "work_id",
-                        "in": "path",
-                        "required": true
+                        "description": "bibtex(default), ris, csl, apa or gost",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/storage.WorkResponse"
-                            }
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -1292,8 +1442,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/rest.ErrorMsg"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorMsg"
                         }
@@ -1301,14 +1451,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/works_by_key_words": {
-            "get": {
+        "/questionnaire_template": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Search works by particular words",
+                "description": "Create a new questionnaire template or the next version of the existing one(admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1316,16 +1466,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Works"
+                    "Questionnaires"
                 ],
-                "summary": "Get all works by words",
+                "summary": "Save questionnaire template",
+                "parameters": [
+                    {
+                        "description": "questionnaire template",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.QuestionnaireTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/storage.QuestionnaireTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/questionnaire_templates": {
+            "get": {
+                "description": "Get the latest version of all questionnaire templates",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Questionnaires"
+                ],
+                "summary": "Questionnaire templates",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "words to search for",
-                        "name": "key_words",
-                        "in": "path",
-                        "required": true
+                        "description": "science area",
+                        "name": "science",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1334,7 +1523,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/storage.WorkResponse"
+                                "$ref": "#/definitions/storage.QuestionnaireTemplate"
                             }
                         }
                     },
@@ -1346,59 +1535,3036 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "rest.AuthResp": {
-            "type": "object",
-            "properties": {
-                "jwt_token": {
-                    "type": "string"
-                },
-                "nickname": {
-                    "type": "string",
-                    "example": "phd ***** destroyer"
-                },
-                "role": {
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/storage.ParticipantRole"
-                        }
-                    ],
-                    "example": 1
-                }
-            }
         },
-        "rest.BasicInfo": {
-            "type": "object",
-            "properties": {
-                "nickname": {
-                    "type": "string",
-                    "example": "phd ***** destroyer"
-                },
-                "role": {
+        "/questionnaire_templates/{template_id}": {
+            "get": {
+                "description": "Get the certain version of the questionnaire template, the latest one by default",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Questionnaires"
+                ],
+                "summary": "Questionnaire template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "template id",
+                        "name": "template_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "template version",
+                        "name": "version",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/storage.QuestionnaireTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/rate_review/{review_id}/{rating}": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Rate the helpfulness of the finished review by the work's author or an admin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Work review"
+                ],
+                "summary": "Rate review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "review id",
+                        "name": "review_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 5,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "review rating",
+                        "name": "rating",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/read_review_notification/{notification_id}": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Mark the validator's notification read",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Validators"
+                ],
+                "summary": "Read review notification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "notification id",
+                        "name": "notification_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.SuccessMsg"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/remove_bookmark": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Remove bookmarks",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookmarks"
+                ],
+                "summary": "Remove bookmarks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "author web3 address",
+                        "name": "web3_address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/review_notifications": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the validator's reminders of the review deadlines, the overdue and the reassigned reviews, the latest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Validators"
+                ],
+                "summary": "Review notifications",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "only the unread ones",
+                        "name": "unread",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/storage.ReviewNotification"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/rewards_history": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the validator's review rewards with the paid and pending totals(in wei)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Validators"
+                ],
+                "summary": "Rewards history",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/storage.RewardsHistory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/sitemap.xml": {
+            "get": {
+                "description": "XML sitemap of the library for the search engines: the library page and the pages of the open works\nwith the dates they were opened",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "Feeds"
+                ],
+                "summary": "Sitemap",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/submit_draft/{work_id}": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Send the draft work to the review, the draft must have the name, the annotation and the content",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Drafts"
+                ],
+                "summary": "Submit draft",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "work id",
+                        "name": "work_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/storage.WorkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/submit_work_review": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Submit work review by validator",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Work review"
+                ],
+                "summary": "Submit review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "work id",
+                        "name": "work_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "WORK_REVIEW_SUBMITTED",
+                            "WORK_REVIEW_SKIPPED",
+                            "WORK_REVIEW_REJECTED",
+                            "WORK_REVIEW_DECLINED",
+                            "WORK_REVIEW_ACCEPTED"
+                        ],
+                        "type": "string",
+                        "description": "review status",
+                        "name": "status",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/trace_watermark": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Extract the reader watermarks from the leaked copy of the work and identify the accounts\nit was served to. The body is the text of the work or the PDF(Content-Type: application/pdf).",
+                "consumes": [
+                    "text/plain",
+                    "application/pdf"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Trace leaked copy",
+                "parameters": [
+                    {
+                        "description": "leaked copy",
+                        "name": "Copy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/srv.WatermarkTrace"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/update_author_info": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Update participant basic info",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authors"
+                ],
+                "summary": "Update participant info",
+                "parameters": [
+                    {
+                        "description": "update author info",
+                        "name": "account",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.UpdateAuthorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.AuthResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/update_basic_info": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Update basic participant info",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Participants"
+                ],
+                "summary": "Update participant info",
+                "parameters": [
+                    {
+                        "description": "update basic participant info",
+                        "name": "account",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.BasicInfoUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.AuthResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/update_draft/{work_id}": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Save the author's corrections of the draft work recognized from the paper\nThe license rules are the same as for the new work.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Drafts"
+                ],
+                "summary": "Update draft",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "work id",
+                        "name": "work_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "draft work",
+                        "name": "Work",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.DraftReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/storage.WorkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/update_review": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Evaluate work by validator",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Work review"
+                ],
+                "summary": "Evaluate work",
+                "parameters": [
+                    {
+                        "description": "work review",
+                        "name": "account",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.WorkReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/storage.WorkReview"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/update_validator_info": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Update validator info",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Validators"
+                ],
+                "summary": "Update validator",
+                "parameters": [
+                    {
+                        "description": "update validator info",
+                        "name": "account",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.UpdateValidatorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.AuthResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/upload_doc/{doc_type}": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Uploading documents confirming work, the document is saved in the blob store.\nThe \"paper\" document is recognized into the draft work, the returned extraction tracks the recognition.\nThe \"jats\" document is the JATS XML article imported into the draft work right away.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Docs"
+                ],
+                "summary": "Upload doc of work",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "document type",
+                        "name": "doc_type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "work id the document belongs to",
+                        "name": "work_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "file",
+                        "description": "document",
+                        "name": "doc",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/storage.BlobRecord"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/storage.WorkResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/storage.DocumentExtraction"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/validator_applications": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the validator applications with the status, the ones waiting for the verification by default(admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Validator applications"
+                ],
+                "summary": "Validator applications",
+                "parameters": [
+                    {
+                        "enum": [
+                            "VALIDATOR_APPLIED",
+                            "VALIDATOR_DOCUMENTS_SUBMITTED",
+                            "VALIDATOR_UNDER_VERIFICATION",
+                            "VALIDATOR_APPROVED",
+                            "VALIDATOR_REJECTED"
+                        ],
+                        "type": "string",
+                        "description": "application status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/storage.ValidatorApplication"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/validator_applications/{web3_address}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the validator application with the OCR fields of the uploaded diplomas(admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Validator applications"
+                ],
+                "summary": "Validator application",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "applicant web3 address",
+                        "name": "web3_address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/storage.ValidatorApplication"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/validator_applications/{web3_address}/diplomas/{diploma_id}/{side}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the uploaded scan of the applicant's diploma(admin only)",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Validator applications"
+                ],
+                "summary": "Diploma scan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "applicant web3 address",
+                        "name": "web3_address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "diploma id",
+                        "name": "diploma_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "forward",
+                            "backward"
+                        ],
+                        "type": "string",
+                        "description": "diploma side",
+                        "name": "side",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/validator_info/upload_docs": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Uploading documents confirming competencies of validator, the recognized diploma is saved for the verification",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Validators"
+                ],
+                "summary": "Upload validator documents",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "forward side of the diploma",
+                        "name": "forward_doc",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "backward side of the diploma",
+                        "name": "backward_doc",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/storage.Diploma"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/validator_info/{web3_address}": {
+            "get": {
+                "description": "Validator full info",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Validators"
+                ],
+                "summary": "Validator info",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "validator web3 address",
+                        "name": "web3_address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/storage.ValidatorResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/verify_validator/{web3_address}": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Take the application with the submitted documents for the verification(admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Validator applications"
+                ],
+                "summary": "Start validator verification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "applicant web3 address",
+                        "name": "web3_address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/storage.Validator"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/work_data": {
+            "get": {
+                "description": "Mock work data",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Publish work"
+                ],
+                "summary": "Mock work data",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PublishWorkDataResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/work_key/{work_id}": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Deliver the data key of the encrypted work content to the reader who has access to it.\nThe key is encrypted by ECIES to the public key of the reader's wallet, so the reader\ncan fetch the pinned work from IPFS and decrypt its AES-256-GCM content(nonce || ciphertext).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Works"
+                ],
+                "summary": "Work content key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "work id",
+                        "name": "work_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "public key of the reader's wallet",
+                        "name": "Key",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.WorkKeyReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/srv.WorkKey"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/work_references/{work_id}": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Replace the reference list of the author's work. A reference refers either to another\nwork of the library by its id or to the external work by its DOI, URL or the free text.\nThe references to the works of the library make the citation graph.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Citations"
+                ],
+                "summary": "Set work references",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "work id",
+                        "name": "work_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "reference list",
+                        "name": "References",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.WorkReferencesReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/storage.WorkReference"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/work_references/{work_id}/proposed": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Parse the bibliography of the author's work content and propose the references: the authors,\nthe title, the year, the DOI and the URL of each entry and the work of the library it's matched to.\nThe proposals aren't saved, the author confirms them by setting the work references.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Citations"
+                ],
+                "summary": "Proposed work references",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "work id",
+                        "name": "work_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/srv.ProposedReference"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/work_review/{work_id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get all work reviews by work id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Work review"
+                ],
+                "summary": "Work reviews",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "work id",
+                        "name": "work_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/storage.WorkReview"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/work_review_diff/{review_id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Compare two versions of the review(admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Work review"
+                ],
+                "summary": "Review versions diff",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "review id",
+                        "name": "review_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "version to compare from",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "version to compare to",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/storage.WorkReviewDiff"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/work_review_history/{review_id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get all versions of the review and the version the work was decided with(the work's author, the validator or an admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Work review"
+                ],
+                "summary": "Review history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "review id",
+                        "name": "review_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/storage.WorkReviewHistory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/work_reviews/{work_id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get work reviews by work_id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Work review"
+                ],
+                "summary": "Get work reviews",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "work id",
+                        "name": "work_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/storage.WorkReview"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/work_score/{work_id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Aggregated questionnaire score of the work reviews",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Work review"
+                ],
+                "summary": "Work score",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "work id",
+                        "name": "work_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/storage.WorkScore"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/works": {
+            "get": {
+                "description": "Get all works depends on role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Works"
+                ],
+                "summary": "Get all works",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/storage.WorkResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/works/author/{web3_address}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get list author` + "`" + `s works",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Works"
+                ],
+                "summary": "List author` + "`" + `s works",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "author web3 address",
+                        "name": "web3_address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/storage.WorkResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/works/author/{web3_address}/feed.{format}": {
+            "get": {
+                "description": "Atom 1.0 or RSS 2.0 feed of the latest opened works of the author, the latest first",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "Feeds"
+                ],
+                "summary": "Feed of the author's opened works",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "web3 address of the author",
+                        "name": "web3_address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "atom or rss",
+                        "name": "format",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/works/author/{web3_address}/metrics": {
+            "get": {
+                "description": "Get the citation metrics of the author's open works: the number of the works,\nthe total citations and the h-index",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Citations"
+                ],
+                "summary": "Author metrics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "web3 address of the author",
+                        "name": "web3_address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/srv.AuthorMetrics"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/works/{work_id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get work by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Works"
+                ],
+                "summary": "Work by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "work id",
+                        "name": "work_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/storage.WorkResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/works/{work_id}/cite": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Export the citation of the work in BibTeX, RIS or CSL-JSON, or format it in the APA\nor GOST R 7.0.5-2008 style. The author is cited by the name from the profile, followed by the co-authors.\nThe exports carry the license of the work: the BibTeX copyright, the RIS note and the CSL license.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Citations"
+                ],
+                "summary": "Cite work",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "work id",
+                        "name": "work_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "bibtex(default), ris, csl, apa or gost",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/works/{work_id}/cited_by": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the open works citing the work",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Citations"
+                ],
+                "summary": "Cited by",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "work id",
+                        "name": "work_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/storage.WorkResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/works/{work_id}/jats": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Export the open work as the JATS XML article(Journal Archiving and Interchange 1.3). The body\nis included if the reader has access to the content, the finished reviews are included as\nthe reviewer reports if the review of the work is open.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "Works"
+                ],
+                "summary": "Work JATS",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "work id",
+                        "name": "work_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/works/{work_id}/jsonld": {
+            "get": {
+                "description": "Schema.org ScholarlyArticle of the open work in JSON-LD, the page of the work embeds it as\nthe application/ld+json script for the search engines",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Works"
+                ],
+                "summary": "Work JSON-LD",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "work id",
+                        "name": "work_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/works/{work_id}/pdf": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Download the PDF of the work: the cover page with its title, authors, annotation, license,\ncontent hash and the \"cite as\" block followed by the rendered content. The access rules are\nthe same as for the work by id, the copy is stamped with the reader's watermark. The PDF which\ncan't be stamped isn't served.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Works"
+                ],
+                "summary": "Work PDF",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "work id",
+                        "name": "work_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/works/{work_id}/references": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the reference list of the work with the cited works of the library",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Citations"
+                ],
+                "summary": "Work references",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "work id",
+                        "name": "work_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/srv.WorkReferenceResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/works/{work_id}/render": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Render the content of the work from its source format(plain text, Markdown or LaTeX)\nto the sanitized HTML fragment or the plain text. The math is kept in the \\( \\) and \\[ \\]\ndelimiters to be typeset by the client. The access rules are the same as for the work by id.",
+                "produces": [
+                    "text/html",
+                    "text/plain"
+                ],
+                "tags": [
+                    "Works"
+                ],
+                "summary": "Render work",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "work id",
+                        "name": "work_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "output format: html(default) or txt",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/works/{work_id}/verify": {
+            "get": {
+                "description": "Recompute the fingerprint of the published work(its metadata, content and attached files)\nand compare it with the fingerprints of the last approved revision saved to the library,\npinned to IPFS and published on-chain. The work is verified if all of them match.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Works"
+                ],
+                "summary": "Verify work",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "work id",
+                        "name": "work_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/srv.WorkVerification"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/works_by_key_words": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Search works by particular words",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Works"
+                ],
+                "summary": "Get all works by words",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "words to search for",
+                        "name": "key_words",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/storage.WorkResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorMsg"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "rest.AuthResp": {
+            "type": "object",
+            "properties": {
+                "jwt_token": {
+                    "type": "string"
+                },
+                "nickname": {
+                    "type": "string",
+                    "example": "phd ***** destroyer"
+                },
+                "role": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/storage.ParticipantRole"
+                        }
+                    ],
+                    "example": 1
+                }
+            }
+        },
+        "rest.BasicInfo": {
+            "type": "object",
+            "properties": {
+                "nickname": {
+                    "type": "string",
+                    "example": "phd ***** destroyer"
+                },
+                "role": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/storage.ParticipantRole"
+                        }
+                    ],
+                    "example": 1
+                }
+            }
+        },
+        "rest.BasicInfoUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "nickname": {
+                    "type": "string",
+                    "example": "phd ***** destroyer"
+                }
+            }
+        },
+        "rest.BecomeAuthorDataResp": {
+            "type": "object",
+            "properties": {
+                "sciences": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "rest.BecomeAuthorRequest": {
+            "type": "object",
+            "properties": {
+                "email_address": {
+                    "type": "string",
+                    "example": "mr_math_phd@science.com"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "middlename": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "orcid": {
+                    "type": "string"
+                },
+                "scholar_ship_profile": {
+                    "type": "string"
+                },
+                "sciences": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "surname": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.BecomeValidatorRequest": {
+            "type": "object",
+            "properties": {
+                "email_address": {
+                    "description": "mandatory",
+                    "type": "string"
+                },
+                "language": {
+                    "description": "mandatory",
+                    "type": "string"
+                },
+                "middlename": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "mandatory",
+                    "type": "string"
+                },
+                "orcid": {
+                    "type": "string"
+                },
+                "sciences": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "surname": {
+                    "description": "mandatory",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.DecideValidatorApplicationRequest": {
+            "type": "object",
+            "properties": {
+                "approved": {
+                    "type": "boolean"
+                },
+                "reason": {
+                    "description": "mandatory for the rejection",
+                    "type": "string"
+                }
+            }
+        },
+        "rest.DepositWorksRequest": {
+            "type": "object",
+            "properties": {
+                "work_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "rest.DraftReq": {
+            "type": "object",
+            "properties": {
+                "work": {
+                    "$ref": "#/definitions/storage.Work"
+                }
+            }
+        },
+        "rest.ErrorMsg": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "null request param"
+                }
+            }
+        },
+        "rest.IfParticipantExistsResp": {
+            "type": "object",
+            "properties": {
+                "result": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "rest.NewParticipantRequest": {
+            "type": "object",
+            "properties": {
+                "nickname": {
+                    "type": "string",
+                    "example": "phd ***** destroyer"
+                },
+                "web3_address": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.PublishWorkDataResp": {
+            "type": "object",
+            "properties": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "rest.QuestionnaireTemplateRequest": {
+            "type": "object",
+            "properties": {
+                "template": {
+                    "$ref": "#/definitions/storage.QuestionnaireTemplate"
+                }
+            }
+        },
+        "rest.ReferenceReq": {
+            "type": "object",
+            "properties": {
+                "doi": {
+                    "type": "string",
+                    "example": "10.1000/xyz123"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "work_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.SuccessMsg": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "OK"
+                }
+            }
+        },
+        "rest.UpdateAuthorRequest": {
+            "type": "object",
+            "properties": {
+                "email_address": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "middlename": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "orcid": {
+                    "type": "string"
+                },
+                "scholar_ship_profile": {
+                    "type": "string"
+                },
+                "sciences": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "surname": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.UpdateValidatorRequest": {
+            "type": "object",
+            "properties": {
+                "email_address": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "middlename": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "orcid": {
+                    "type": "string"
+                },
+                "sciences": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "surname": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.WorkKeyReq": {
+            "type": "object",
+            "properties": {
+                "public_key": {
+                    "description": "hex encoded secp256k1 key",
+                    "type": "string",
+                    "example": "0x04..."
+                }
+            }
+        },
+        "rest.WorkReferencesReq": {
+            "type": "object",
+            "properties": {
+                "references": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.ReferenceReq"
+                    }
+                }
+            }
+        },
+        "rest.WorkReviewRequest": {
+            "type": "object",
+            "properties": {
+                "review": {
+                    "$ref": "#/definitions/storage.WorkReview"
+                }
+            }
+        },
+        "srv.AuthorMetrics": {
+            "type": "object",
+            "properties": {
+                "citations": {
+                    "type": "integer"
+                },
+                "h_index": {
+                    "description": "HIndex is the max h such that h of the works are cited at least h times each",
+                    "type": "integer"
+                },
+                "works": {
+                    "type": "integer"
+                }
+            }
+        },
+        "srv.NFTAttribute": {
+            "type": "object",
+            "properties": {
+                "trait_type": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "srv.NFTMetadata": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/srv.NFTAttribute"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "external_url": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "srv.ProposedReference": {
+            "type": "object",
+            "properties": {
+                "authors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "doi": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "text": {
+                    "description": "the reference as it's written",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "work_id": {
+                    "description": "WorkID is the work of the library the reference is matched to",
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "srv.VerificationCheck": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "fingerprint": {
+                    "type": "string"
+                },
+                "match": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "srv.WatermarkTrace": {
+            "type": "object",
+            "properties": {
+                "participant": {
+                    "$ref": "#/definitions/storage.Participant"
+                },
+                "participant_id": {
+                    "type": "string"
+                },
+                "served_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "srv.WorkKey": {
+            "type": "object",
+            "properties": {
+                "cid": {
+                    "type": "string"
+                },
+                "encrypted_key": {
+                    "description": "hex encoded",
+                    "type": "string"
+                },
+                "encryption": {
+                    "type": "string"
+                },
+                "key_encryption": {
+                    "type": "string"
+                },
+                "work_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "srv.WorkReferenceResponse": {
+            "type": "object",
+            "properties": {
+                "cited_work": {
+                    "$ref": "#/definitions/storage.WorkResponse"
+                },
+                "cited_work_id": {
+                    "type": "string"
+                },
+                "doi": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "srv.WorkVerification": {
+            "type": "object",
+            "properties": {
+                "cid": {
+                    "type": "string"
+                },
+                "fingerprint": {
+                    "type": "string"
+                },
+                "ipfs": {
+                    "description": "the fingerprint of the document pinned to IPFS",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/storage.ParticipantRole"
+                            "$ref": "#/definitions/srv.VerificationCheck"
                         }
-                    ],
-                    "example": 1
+                    ]
+                },
+                "on_chain": {
+                    "description": "the fingerprint in the URI of the on-chain publication",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/srv.VerificationCheck"
+                        }
+                    ]
+                },
+                "revision": {
+                    "type": "integer"
+                },
+                "stored": {
+                    "description": "the fingerprint saved when the revision was approved",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/srv.VerificationCheck"
+                        }
+                    ]
+                },
+                "tx_hash": {
+                    "type": "string"
+                },
+                "verified": {
+                    "type": "boolean"
+                },
+                "work_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "storage.Author": {
+            "type": "object",
+            "properties": {
+                "email_address": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "middlename": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "orcid": {
+                    "type": "string"
+                },
+                "scholar_ship_profile": {
+                    "type": "string"
+                },
+                "sciences": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "surname": {
+                    "type": "string"
+                }
+            }
+        },
+        "storage.AuthorResponse": {
+            "type": "object",
+            "properties": {
+                "author_info": {
+                    "$ref": "#/definitions/storage.Author"
+                },
+                "basic_info": {
+                    "$ref": "#/definitions/storage.Participant"
+                }
+            }
+        },
+        "storage.BlobKind": {
+            "type": "string",
+            "enum": [
+                "BLOB_WORK_DOCUMENT",
+                "BLOB_DIPLOMA"
+            ],
+            "x-enum-varnames": [
+                "WorkDocumentBlob",
+                "DiplomaBlob"
+            ]
+        },
+        "storage.BlobRecord": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "created_date": {
+                    "type": "string"
+                },
+                "diploma_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "kind": {
+                    "$ref": "#/definitions/storage.BlobKind"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "work_id": {
+                    "type": "string"
                 }
             }
         },
-        "rest.BasicInfoUpdateRequest": {
+        "storage.DepositStatus": {
+            "type": "string",
+            "enum": [
+                "DEPOSIT_PENDING",
+                "DEPOSIT_SUBMITTED",
+                "DEPOSIT_SUCCEEDED",
+                "DEPOSIT_FAILED"
+            ],
+            "x-enum-varnames": [
+                "DepositPending",
+                "DepositSubmitted",
+                "DepositSucceeded",
+                "DepositFailed"
+            ]
+        },
+        "storage.DiffLine": {
             "type": "object",
             "properties": {
-                "nickname": {
-                    "type": "string",
-                    "example": "phd ***** destroyer"
+                "op": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
                 }
             }
         },
-        "rest.BecomeAuthorDataResp": {
+        "storage.Diploma": {
             "type": "object",
             "properties": {
-                "sciences": {
+                "backward_image": {
+                    "$ref": "#/definitions/storage.DocumentImage"
+                },
+                "created_date": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "degree": {
+                    "type": "string"
+                },
+                "diploma_date": {
+                    "type": "string"
+                },
+                "diploma_number": {
+                    "description": "the backward side",
+                    "type": "string"
+                },
+                "diploma_serial_number": {
+                    "type": "string"
+                },
+                "forward_image": {
+                    "$ref": "#/definitions/storage.DocumentImage"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "number": {
+                    "description": "the forward side",
+                    "type": "integer"
+                },
+                "ocr_error": {
+                    "description": "OCR error if the text wasn't recognized",
+                    "type": "string"
+                },
+                "topics": {
                     "type": "array",
                     "items": {
                         "type": "string"
@@ -1406,194 +4572,374 @@ const docTemplate = `{
                 }
             }
         },
-        "rest.BecomeAuthorRequest": {
+        "storage.DocumentExtraction": {
             "type": "object",
             "properties": {
-                "email_address": {
-                    "type": "string",
-                    "example": "mr_math_phd@science.com"
-                },
-                "language": {
+                "blob_id": {
                     "type": "string"
                 },
-                "middlename": {
+                "created_date": {
                     "type": "string"
                 },
-                "name": {
+                "error": {
                     "type": "string"
                 },
-                "orcid": {
-                    "type": "string"
+                "failed_pages": {
+                    "type": "integer"
                 },
-                "scholar_ship_profile": {
+                "id": {
                     "type": "string"
                 },
-                "sciences": {
+                "pages": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/storage.ExtractionPage"
                     }
                 },
-                "surname": {
+                "processed_pages": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/storage.ExtractionStatus"
+                },
+                "total_pages": {
+                    "description": "the progress of the PDF processed page by page",
+                    "type": "integer"
+                },
+                "updated_date": {
+                    "type": "string"
+                },
+                "work_id": {
                     "type": "string"
                 }
             }
         },
-        "rest.BecomeValidatorRequest": {
+        "storage.DocumentImage": {
             "type": "object",
             "properties": {
-                "email_address": {
-                    "description": "mandatory",
+                "blob_id": {
                     "type": "string"
                 },
-                "language": {
-                    "description": "mandatory",
-                    "type": "string"
-                },
-                "middlename": {
+                "content_type": {
                     "type": "string"
                 },
                 "name": {
-                    "description": "mandatory",
                     "type": "string"
                 },
-                "orcid": {
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "storage.ExtractionPage": {
+            "type": "object",
+            "properties": {
+                "error": {
                     "type": "string"
                 },
-                "sciences": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "number": {
+                    "type": "integer"
                 },
-                "surname": {
-                    "description": "mandatory",
+                "source": {
+                    "description": "PAGE_TEXT if the text is embedded into the PDF, PAGE_OCR if it's recognized from the scan",
                     "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/storage.ExtractionStatus"
                 }
             }
         },
-        "rest.ErrorMsg": {
+        "storage.ExtractionStatus": {
+            "type": "string",
+            "enum": [
+                "EXTRACTION_PENDING",
+                "EXTRACTION_RUNNING",
+                "EXTRACTION_DONE",
+                "EXTRACTION_FAILED"
+            ],
+            "x-enum-varnames": [
+                "ExtractionPending",
+                "ExtractionRunning",
+                "ExtractionDone",
+                "ExtractionFailed"
+            ]
+        },
+        "storage.LicenseID": {
+            "type": "string",
+            "enum": [
+                "CC-BY-4.0",
+                "CC-BY-SA-4.0",
+                "CC-BY-NC-4.0",
+                "all-rights-reserved",
+                "custom"
+            ],
+            "x-enum-varnames": [
+                "CCBYLicense",
+                "CCBYSALicense",
+                "CCBYNCLicense",
+                "AllRightsReservedLicense",
+                "CustomLicense"
+            ]
+        },
+        "storage.Participant": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "null request param"
+                "language": {
+                    "description": "'ru', 'en'",
+                    "type": "string"
+                },
+                "nickname": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/storage.ParticipantRole"
+                },
+                "web3Address": {
+                    "type": "string"
                 }
             }
         },
-        "rest.IfParticipantExistsResp": {
+        "storage.ParticipantRole": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2,
+                3,
+                4,
+                5
+            ],
+            "x-enum-comments": {
+                "AdminRole": "5 Admin",
+                "AdvisorRole": "3 Advisor",
+                "AuthorRole": "2 Author",
+                "GuestRole": "0 Guest",
+                "ReaderRole": "1 Reader",
+                "ValidatorRole": "4 Validator"
+            },
+            "x-enum-varnames": [
+                "GuestRole",
+                "ReaderRole",
+                "AuthorRole",
+                "AdvisorRole",
+                "ValidatorRole",
+                "AdminRole"
+            ]
+        },
+        "storage.ParticipantsWork": {
             "type": "object",
             "properties": {
-                "result": {
-                    "type": "boolean",
-                    "example": true
+                "created_date": {
+                    "type": "string"
+                },
+                "decided_date": {
+                    "type": "string"
+                },
+                "mint_nft": {
+                    "description": "the author has opted in to mint the work as NFT",
+                    "type": "boolean"
+                },
+                "nft_address": {
+                    "type": "string"
+                },
+                "nft_token_id": {
+                    "type": "string"
+                },
+                "open_access": {
+                    "description": "the content is free to read once the work is open",
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "string"
                 }
             }
         },
-        "rest.NewParticipantRequest": {
+        "storage.QuestionAnswerDiff": {
             "type": "object",
             "properties": {
-                "nickname": {
-                    "type": "string",
-                    "example": "phd ***** destroyer"
+                "from": {
+                    "type": "integer"
                 },
-                "web3_address": {
+                "question_id": {
                     "type": "string"
+                },
+                "to": {
+                    "type": "integer"
                 }
             }
         },
-        "rest.PublishWorkDataResp": {
+        "storage.QuestionnaireQuestion": {
             "type": "object",
             "properties": {
-                "tags": {
-                    "type": "array",
-                    "items": {
+                "id": {
+                    "type": "string"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "text": {
+                    "description": "'ru', 'en'",
+                    "type": "object",
+                    "additionalProperties": {
                         "type": "string"
                     }
+                },
+                "weight": {
+                    "type": "number"
                 }
             }
         },
-        "rest.SuccessMsg": {
+        "storage.QuestionnaireTemplate": {
             "type": "object",
             "properties": {
-                "status": {
-                    "type": "string",
-                    "example": "OK"
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "questions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.QuestionnaireQuestion"
+                    }
+                },
+                "scale_max": {
+                    "description": "4 - согласен",
+                    "type": "integer"
+                },
+                "scale_min": {
+                    "description": "0 - не согласен",
+                    "type": "integer"
+                },
+                "science": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "rest.UpdateAuthorRequest": {
+        "storage.ReviewMode": {
+            "type": "string",
+            "enum": [
+                "blind",
+                "open"
+            ],
+            "x-enum-varnames": [
+                "BlindReviewMode",
+                "OpenReviewMode"
+            ]
+        },
+        "storage.ReviewNotification": {
             "type": "object",
             "properties": {
-                "email_address": {
-                    "type": "string"
-                },
-                "language": {
+                "created_date": {
                     "type": "string"
                 },
-                "middlename": {
+                "due_date": {
                     "type": "string"
                 },
-                "name": {
+                "id": {
                     "type": "string"
                 },
-                "orcid": {
+                "read_date": {
                     "type": "string"
                 },
-                "scholar_ship_profile": {
+                "review_id": {
                     "type": "string"
                 },
-                "sciences": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "topic": {
+                    "type": "string",
+                    "example": "review:reminder"
                 },
-                "surname": {
+                "work_id": {
                     "type": "string"
                 }
             }
         },
-        "rest.UpdateValidatorRequest": {
+        "storage.RewardLedger": {
             "type": "object",
             "properties": {
-                "email_address": {
+                "amount": {
                     "type": "string"
                 },
-                "language": {
+                "created_date": {
                     "type": "string"
                 },
-                "middlename": {
+                "id": {
                     "type": "string"
                 },
-                "name": {
+                "paid_date": {
                     "type": "string"
                 },
-                "orcid": {
+                "policy": {
                     "type": "string"
                 },
-                "sciences": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "review_id": {
+                    "type": "string"
                 },
-                "surname": {
+                "status": {
+                    "$ref": "#/definitions/storage.RewardStatus"
+                },
+                "tx_hash": {
+                    "type": "string"
+                },
+                "work_id": {
                     "type": "string"
                 }
             }
         },
-        "rest.WorkReviewRequest": {
+        "storage.RewardStatus": {
+            "type": "string",
+            "enum": [
+                "REWARD_PENDING",
+                "REWARD_PAID",
+                "REWARD_FAILED",
+                "REWARD_PAYING"
+            ],
+            "x-enum-varnames": [
+                "RewardPending",
+                "RewardPaid",
+                "RewardFailed",
+                "RewardPaying"
+            ]
+        },
+        "storage.RewardsHistory": {
             "type": "object",
             "properties": {
-                "review": {
-                    "$ref": "#/definitions/storage.WorkReview"
+                "paid": {
+                    "type": "string"
+                },
+                "pending": {
+                    "type": "string"
+                },
+                "rewards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.RewardLedger"
+                    }
                 }
             }
         },
-        "storage.Author": {
+        "storage.Validator": {
             "type": "object",
             "properties": {
+                "application_status": {
+                    "description": "the application, empty status means the validator was approved before the verification existed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/storage.ValidatorApplicationStatus"
+                        }
+                    ]
+                },
+                "diploma_id": {
+                    "description": "referrenceKey",
+                    "type": "string"
+                },
                 "email_address": {
                     "type": "string"
                 },
@@ -1609,7 +4955,7 @@ const docTemplate = `{
                 "orcid": {
                     "type": "string"
                 },
-                "scholar_ship_profile": {
+                "rejection_reason": {
                     "type": "string"
                 },
                 "sciences": {
@@ -1620,94 +4966,71 @@ const docTemplate = `{
                 },
                 "surname": {
                     "type": "string"
+                },
+                "verified_date": {
+                    "type": "string"
                 }
             }
         },
-        "storage.AuthorResponse": {
+        "storage.ValidatorApplication": {
             "type": "object",
             "properties": {
-                "author_info": {
-                    "$ref": "#/definitions/storage.Author"
-                },
                 "basic_info": {
                     "$ref": "#/definitions/storage.Participant"
-                }
-            }
-        },
-        "storage.Participant": {
-            "type": "object",
-            "properties": {
-                "language": {
-                    "description": "'ru', 'en'",
-                    "type": "string"
-                },
-                "nickname": {
-                    "type": "string"
                 },
-                "role": {
-                    "$ref": "#/definitions/storage.ParticipantRole"
+                "diplomas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.Diploma"
+                    }
                 },
-                "web3Address": {
-                    "type": "string"
+                "validator_info": {
+                    "$ref": "#/definitions/storage.Validator"
                 }
             }
         },
-        "storage.ParticipantRole": {
-            "type": "integer",
+        "storage.ValidatorApplicationStatus": {
+            "type": "string",
             "enum": [
-                0,
-                1,
-                2,
-                3,
-                4,
-                5
+                "VALIDATOR_APPLIED",
+                "VALIDATOR_DOCUMENTS_SUBMITTED",
+                "VALIDATOR_UNDER_VERIFICATION",
+                "VALIDATOR_APPROVED",
+                "VALIDATOR_REJECTED"
             ],
-            "x-enum-comments": {
-                "AdminRole": "5 Admin",
-                "AdvisorRole": "3 Advisor",
-                "AuthorRole": "2 Author",
-                "GuestRole": "0 Guest",
-                "ReaderRole": "1 Reader",
-                "ValidatorRole": "4 Validator"
-            },
             "x-enum-varnames": [
-                "GuestRole",
-                "ReaderRole",
-                "AuthorRole",
-                "AdvisorRole",
-                "ValidatorRole",
-                "AdminRole"
+                "ValidatorApplied",
+                "ValidatorDocumentsSubmitted",
+                "ValidatorUnderVerification",
+                "ValidatorApproved",
+                "ValidatorRejected"
             ]
         },
-        "storage.Validator": {
+        "storage.ValidatorReputation": {
             "type": "object",
             "properties": {
-                "diploma_id": {
-                    "description": "referrenceKey",
-                    "type": "string"
+                "agreement": {
+                    "type": "number"
                 },
-                "email_address": {
-                    "type": "string"
+                "depth": {
+                    "type": "number"
                 },
-                "language": {
-                    "type": "string"
+                "helpfulness": {
+                    "type": "number"
                 },
-                "middlename": {
-                    "type": "string"
+                "history": {
+                    "type": "number"
                 },
-                "name": {
-                    "type": "string"
+                "reviews": {
+                    "type": "integer"
                 },
-                "orcid": {
-                    "type": "string"
+                "score": {
+                    "type": "number"
                 },
-                "sciences": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "timeliness": {
+                    "type": "number"
                 },
-                "surname": {
+                "updated_date": {
                     "type": "string"
                 }
             }
@@ -1718,19 +5041,61 @@ const docTemplate = `{
                 "basic_info": {
                     "$ref": "#/definitions/storage.Participant"
                 },
+                "reputation": {
+                    "$ref": "#/definitions/storage.ValidatorReputation"
+                },
+                "review_stats": {
+                    "$ref": "#/definitions/storage.ValidatorReviewStats"
+                },
                 "validator_info": {
                     "$ref": "#/definitions/storage.Validator"
                 }
             }
         },
+        "storage.ValidatorReviewStats": {
+            "type": "object",
+            "properties": {
+                "finished": {
+                    "type": "integer"
+                },
+                "in_progress": {
+                    "type": "integer"
+                },
+                "late": {
+                    "description": "have ever been overdue",
+                    "type": "integer"
+                },
+                "overdue": {
+                    "description": "currently overdue",
+                    "type": "integer"
+                },
+                "reassigned": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
         "storage.Work": {
             "type": "object",
             "properties": {
                 "annotation": {
                     "type": "string"
                 },
+                "cid": {
+                    "description": "PUBLICATION of the approved work: the IPFS CID of its canonical JSON,\nthe content fingerprint and the tx of the on-chain publication, the work updated\nafter the publication is published again if its content has changed",
+                    "type": "string"
+                },
+                "co_authors": {
+                    "description": "CoAuthors are the authors of the work besides the participant who has submitted it",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.Author"
+                    }
+                },
                 "content": {
-                    "description": "BODY INFORMATION",
+                    "description": "BODY INFORMATION, the content is encrypted by the data key of the work\nwrapped by the master key",
                     "allOf": [
                         {
                             "$ref": "#/definitions/storage.WorkContent"
@@ -1740,6 +5105,9 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
+                "fingerprint": {
+                    "type": "string"
+                },
                 "id": {
                     "description": "BASE INFORMATION",
                     "type": "string"
@@ -1747,12 +5115,41 @@ const docTemplate = `{
                 "language": {
                     "type": "string"
                 },
+                "license": {
+                    "description": "License is chosen by the author, the open access work is free to read",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/storage.WorkLicense"
+                        }
+                    ]
+                },
                 "name": {
                     "type": "string"
                 },
+                "open_access": {
+                    "type": "boolean"
+                },
+                "pid": {
+                    "description": "PID is the persistent identifier of the open work",
+                    "type": "string"
+                },
                 "price": {
                     "type": "string"
                 },
+                "publish_tx_hash": {
+                    "type": "string"
+                },
+                "review_mode": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/storage.ReviewMode"
+                        }
+                    ],
+                    "example": "blind"
+                },
+                "science": {
+                    "type": "string"
+                },
                 "sources": {
                     "type": "string"
                 },
@@ -1770,11 +5167,87 @@ const docTemplate = `{
         "storage.WorkContent": {
             "type": "object",
             "properties": {
+                "format": {
+                    "description": "the source format of the work data: plain, markdown or latex, the empty one is plain",
+                    "type": "string",
+                    "example": "markdown"
+                },
                 "work_data": {
                     "type": "string"
                 }
             }
         },
+        "storage.WorkDeposit": {
+            "type": "object",
+            "properties": {
+                "batch_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pid": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/storage.DepositStatus"
+                },
+                "submitted_at": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "work_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "storage.WorkLicense": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/storage.LicenseID"
+                        }
+                    ],
+                    "example": "CC-BY-4.0"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "storage.WorkReference": {
+            "type": "object",
+            "properties": {
+                "cited_work_id": {
+                    "type": "string"
+                },
+                "doi": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
         "storage.WorkResponse": {
             "type": "object",
             "properties": {
@@ -1784,6 +5257,10 @@ const docTemplate = `{
                 "bookmarked": {
                     "type": "boolean"
                 },
+                "citations": {
+                    "description": "Citations is the number of the open works citing the work",
+                    "type": "integer"
+                },
                 "work": {
                     "$ref": "#/definitions/storage.Work"
                 }
@@ -1803,6 +5280,9 @@ const docTemplate = `{
                 "created_date": {
                     "type": "string"
                 },
+                "due_date": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
@@ -1831,15 +5311,124 @@ const docTemplate = `{
                 }
             }
         },
+        "storage.WorkReviewDiff": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "$ref": "#/definitions/storage.WorkReviewVersion"
+                },
+                "questionnaire": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.QuestionAnswerDiff"
+                    }
+                },
+                "review": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.DiffLine"
+                    }
+                },
+                "review_id": {
+                    "type": "string"
+                },
+                "status_changed": {
+                    "type": "boolean"
+                },
+                "to": {
+                    "$ref": "#/definitions/storage.WorkReviewVersion"
+                }
+            }
+        },
+        "storage.WorkReviewHistory": {
+            "type": "object",
+            "properties": {
+                "decided_date": {
+                    "type": "string"
+                },
+                "decision_version": {
+                    "$ref": "#/definitions/storage.WorkReviewVersion"
+                },
+                "review_id": {
+                    "type": "string"
+                },
+                "versions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.WorkReviewVersion"
+                    }
+                },
+                "work_id": {
+                    "type": "string"
+                }
+            }
+        },
         "storage.WorkReviewQuestionnaire": {
             "type": "object",
             "properties": {
                 "questions": {
-                    "description": "0 - не согласен, 4 - согласен",
+                    "description": "question id -\u003e score within the template scale",
                     "type": "object",
                     "additionalProperties": {
                         "type": "integer"
                     }
+                },
+                "template_id": {
+                    "type": "string"
+                },
+                "template_version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "storage.WorkReviewVersion": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "$ref": "#/definitions/storage.WorkReviewBody"
+                },
+                "body_hash": {
+                    "type": "string"
+                },
+                "created_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "review_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "work_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "storage.WorkScore": {
+            "type": "object",
+            "properties": {
+                "questions": {
+                    "description": "question id -\u003e average raw score",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "reviews": {
+                    "type": "integer"
+                },
+                "score": {
+                    "description": "weighted, normalized to [0, 1]",
+                    "type": "number"
+                },
+                "work_id": {
+                    "type": "string"
                 }
             }
         }
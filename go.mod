@@ -4,6 +4,9 @@ go 1.19
 
 require (
 	github.com/SeaOfWisdom/sow_proto v0.0.0-20230721115747-1eb47e5f5681
+	github.com/go-pdf/fpdf v0.8.0
+	github.com/ledongthuc/pdf v0.0.0-20220302134840-0c2507a12d80
+	github.com/robfig/cron/v3 v3.0.1
 	github.com/sirupsen/logrus v1.9.3
 	github.com/swaggo/swag v1.16.1
 	go.mongodb.org/mongo-driver v1.12.0
@@ -60,6 +63,7 @@ require (
 )
 
 require (
+	github.com/holiman/uint256 v1.2.2-0.20230321075855-87b91420868c // indirect
 	github.com/klauspost/compress v1.15.15 // indirect
 	github.com/montanaflynn/stats v0.0.0-20171201202039-1bf9dbcd8cbe // indirect
 	github.com/xdg-go/pbkdf2 v1.0.0 // indirect
@@ -36,7 +36,7 @@ func main() {
 
 		/* wait for application termination */
 		common.WaitForSignal()
-		service.Start()
+		service.Stop()
 		restService.Stop()
 		grpcServer.Stop()
 	})
@@ -29,11 +29,70 @@ type Config struct {
 	PostgresUser     string
 	PostgresPassword string
 	/* Cron */
-	AddRewardsCron   string
-	UpdateRewadsCron string
-	/* Pinata */
-	PinataURL string
-	PinataJWT string
+	AddRewardsCron     string
+	UpdateRewadsCron   string
+	ReviewDeadlineCron string
+	ReputationCron     string
+	PublishWorksCron   string
+	/* Review rewards */
+	RewardsPolicy      string
+	RewardAmount       string
+	RewardsBatchSize   int64
+	RewardsMaxAttempts int64
+	/* Review deadlines */
+	ReviewDueDays       int64
+	ReviewReminderHours int64
+	ReviewGraceDays     int64
+	ReviewReassign      bool
+	/* Blob store */
+	BlobStoreType    string
+	BlobStoreDir     string
+	S3Endpoint       string
+	S3Region         string
+	S3Bucket         string
+	S3AccessKey      string
+	S3SecretKey      string
+	BlobMaxSize      int64
+	BlobAllowedTypes string
+	/* Ingestion of the uploaded papers */
+	IngestWorkers int
+	/* IPFS pinning of the approved works */
+	ContentPublisher string
+	PinataURL        string
+	PinataJWT        string
+	W3SURL           string
+	W3SToken         string
+	IPFSGatewayURL   string
+	/* Ethereum RPC to verify the on-chain publications */
+	EthRPCURL string
+	/* Encryption of the works content */
+	ContentMasterKey string
+	/* Watermarks of the served content */
+	WatermarkSecret string
+	/* NFT metadata of the works */
+	LibraryURL  string
+	NFTImageURL string
+	/* PDF of the works */
+	PDFFont string
+	/* Persistent identifiers of the works */
+	PIDPrefix    string
+	PIDRegistrar string
+	/* OAI-PMH harvesting of the open works */
+	OAIBaseURL    string
+	OAIAdminEmail string
+	OAIPageSize   int
+	/* Syndication of the newly opened works */
+	FeedSize int
+	/* Crossref deposits of the identified works */
+	CrossrefURL            string
+	CrossrefLogin          string
+	CrossrefPassword       string
+	CrossrefDepositorName  string
+	CrossrefDepositorEmail string
+	CrossrefBatchSize      int
+	CrossrefISSN           string
+	/* Licensing of the works */
+	PaywallOpenLicenses bool
 	/* Metric */
 	MetricService     string
 	MetricServiceGrpc string
@@ -66,9 +125,68 @@ func NewConfig() *Config {
 	/* Cron */
 	flag.StringVar(&config.AddRewardsCron, "add-rewards-cron", "*/1 * * * *", "")
 	flag.StringVar(&config.UpdateRewadsCron, "update-rewards-cron", "*/3 * * * *", "")
-	/* Pinata */
-	flag.StringVar(&config.PinataURL, "pinata-url", "*/3 * * * *", "")
-	flag.StringVar(&config.PinataJWT, "pinata-jwt", "*/3 * * * *", "")
+	flag.StringVar(&config.ReviewDeadlineCron, "review-deadline-cron", "*/10 * * * *", "schedule of the review deadlines check")
+	flag.StringVar(&config.ReputationCron, "reputation-cron", "0 * * * *", "schedule of the validators reputation recalculation")
+	flag.StringVar(&config.PublishWorksCron, "publish-works-cron", "*/5 * * * *", "schedule of the retries to publish the approved works")
+	/* Review rewards */
+	flag.StringVar(&config.RewardsPolicy, "rewards-policy", "flat", "policy of the review rewards: flat, per_score or per_reputation")
+	flag.StringVar(&config.RewardAmount, "reward-amount", "10000000000000000000", "base reward for a review in wei")
+	flag.Int64Var(&config.RewardsBatchSize, "rewards-batch-size", 20, "max number of the rewards paid out per run")
+	flag.Int64Var(&config.RewardsMaxAttempts, "rewards-max-attempts", 5, "max number of the payout attempts of a reward")
+	/* Review deadlines */
+	flag.Int64Var(&config.ReviewDueDays, "review-due-days", 14, "days given to a validator to submit the review")
+	flag.Int64Var(&config.ReviewReminderHours, "review-reminder-hours", 48, "hours before the deadline to remind the validator")
+	flag.Int64Var(&config.ReviewGraceDays, "review-grace-days", 3, "days after the deadline before the review is reassigned")
+	flag.BoolVar(&config.ReviewReassign, "review-reassign", false, "reassign overdue reviews to another validator after the grace period")
+	/* Blob store */
+	flag.StringVar(&config.BlobStoreType, "blob-store-type", "local", "storage of the uploaded documents: local or s3")
+	flag.StringVar(&config.BlobStoreDir, "blob-store-dir", "./blobs", "directory of the local blob store")
+	flag.StringVar(&config.S3Endpoint, "s3-endpoint", "http://localhost:9000", "endpoint of the S3 compatible storage")
+	flag.StringVar(&config.S3Region, "s3-region", "us-east-1", "")
+	flag.StringVar(&config.S3Bucket, "s3-bucket", "sow-library", "")
+	flag.StringVar(&config.S3AccessKey, "s3-access-key", "", "")
+	flag.StringVar(&config.S3SecretKey, "s3-secret-key", "", "")
+	flag.Int64Var(&config.BlobMaxSize, "blob-max-size", 20<<20, "max size of an uploaded document in bytes")
+	flag.StringVar(&config.BlobAllowedTypes, "blob-allowed-types", "image/jpeg,image/png,application/pdf", "comma separated MIME types of the uploaded documents")
+	/* Ingestion of the uploaded papers */
+	flag.IntVar(&config.IngestWorkers, "ingest-workers", 4, "number of the PDF pages processed concurrently")
+	/* IPFS pinning of the approved works */
+	flag.StringVar(&config.ContentPublisher, "content-publisher", "", "pinning service of the approved works: pinata or w3s, the approved works are published on-chain without pinning if it's null")
+	flag.StringVar(&config.PinataURL, "pinata-url", "https://api.pinata.cloud", "")
+	flag.StringVar(&config.PinataJWT, "pinata-jwt", "", "")
+	flag.StringVar(&config.W3SURL, "w3s-url", "https://api.web3.storage", "")
+	flag.StringVar(&config.W3SToken, "w3s-token", "", "")
+	flag.StringVar(&config.IPFSGatewayURL, "ipfs-gateway-url", "https://ipfs.io", "gateway to read the pinned works")
+	/* Ethereum RPC to verify the on-chain publications */
+	flag.StringVar(&config.EthRPCURL, "eth-rpc-url", "", "JSON-RPC endpoint of the chain, the on-chain verification is skipped if it's null")
+	/* Encryption of the works content */
+	flag.StringVar(&config.ContentMasterKey, "content-master-key", "", "hex encoded 256 bit key wrapping the data keys of the works content, the content of the works can't be stored if it's null")
+	/* Watermarks of the served content */
+	flag.StringVar(&config.WatermarkSecret, "watermark-secret", "", "secret authenticating the watermarks, at least 16 bytes, it's derived from the content master key if it's null")
+	/* NFT metadata of the works */
+	flag.StringVar(&config.LibraryURL, "library-url", "https://seaofwisdom.io", "public URL of the library, the works are linked from the NFT metadata")
+	flag.StringVar(&config.NFTImageURL, "nft-image-url", "https://seaofwisdom.io/images/work-nft.png", "image of the works NFT")
+	/* PDF of the works */
+	flag.StringVar(&config.PDFFont, "pdf-font", "", "TrueType font file of the works PDF, the bundled DejaVu Sans Condensed is used if it's null")
+	/* Persistent identifiers of the works */
+	flag.StringVar(&config.PIDPrefix, "pid-prefix", "10.5555", "prefix of the persistent identifiers of the works, e.g. the DOI prefix")
+	flag.StringVar(&config.PIDRegistrar, "pid-registrar", "library", "registrar of the persistent identifiers: library, the identifiers are registered in the library database")
+	/* OAI-PMH harvesting of the open works */
+	flag.StringVar(&config.OAIBaseURL, "oai-base-url", "https://seaofwisdom.io/api/oai", "public URL of the OAI-PMH provider, its host is the repository identifier")
+	flag.StringVar(&config.OAIAdminEmail, "oai-admin-email", "admin@seaofwisdom.io", "email of the repository administrator shown to the harvesters")
+	flag.IntVar(&config.OAIPageSize, "oai-page-size", 100, "max number of the records in the part of the harvested list")
+	/* Syndication of the newly opened works */
+	flag.IntVar(&config.FeedSize, "feed-size", 50, "max number of the latest opened works in the Atom and RSS feeds")
+	/* Crossref deposits of the identified works */
+	flag.StringVar(&config.CrossrefURL, "crossref-url", "", "URL of the Crossref deposit API, e.g. https://doi.crossref.org, the deposits aren't submitted if it's null, their XML is only generated on request")
+	flag.StringVar(&config.CrossrefLogin, "crossref-login", "", "login of the Crossref depositor")
+	flag.StringVar(&config.CrossrefPassword, "crossref-password", "", "password of the Crossref depositor")
+	flag.StringVar(&config.CrossrefDepositorName, "crossref-depositor-name", "Sea of Wisdom", "name of the depositor shown in the deposits")
+	flag.StringVar(&config.CrossrefDepositorEmail, "crossref-depositor-email", "admin@seaofwisdom.io", "email the results of the deposits are sent to")
+	flag.IntVar(&config.CrossrefBatchSize, "crossref-batch-size", 50, "max number of the works deposited in one batch")
+	flag.StringVar(&config.CrossrefISSN, "crossref-issn", "", "electronic ISSN of the library journal, the works are deposited as the journal articles if it's given and as the posted content otherwise")
+	/* Licensing of the works */
+	flag.BoolVar(&config.PaywallOpenLicenses, "paywall-open-licenses", false, "allow the works under the CC BY and CC BY-SA licenses to be sold, otherwise they must be open access")
 	/* Internal communication services */
 	flag.StringVar(&config.JWTServiceGRpcAddress, "jwt-service-address", "0.0.0.0:5304", "")
 	flag.StringVar(&config.OCRServiceGRpcAddress, "ocr-service-address", "0.0.0.0:50051", "")
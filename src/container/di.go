@@ -20,6 +20,9 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/SeaOfWisdom/sow_library/src/rest-service"
+	"github.com/SeaOfWisdom/sow_library/src/service/blobstore"
+	"github.com/SeaOfWisdom/sow_library/src/service/pid"
+	"github.com/SeaOfWisdom/sow_library/src/service/publisher"
 	"github.com/SeaOfWisdom/sow_library/src/service/storage"
 
 	_ "github.com/jinzhu/gorm/dialects/postgres"
@@ -98,8 +101,32 @@ func CreateContainer() *dig.Container {
 		}
 		return contractorProto.NewContractorServiceClient(conn)
 	}))
+	/* Blob store of the uploaded documents */
+	must(container.Provide(func(config *config.Config) blobstore.BlobStore {
+		store, err := blobstore.NewBlobStore(config)
+		if err != nil {
+			panic(fmt.Errorf("unable to create the blob store: %v", err))
+		}
+		return store
+	}))
+	/* IPFS pinning service of the approved works */
+	must(container.Provide(func(config *config.Config) publisher.ContentPublisher {
+		contentPublisher, err := publisher.NewContentPublisher(config)
+		if err != nil {
+			panic(fmt.Errorf("unable to create the content publisher: %v", err))
+		}
+		return contentPublisher
+	}))
 	/* initialize the storage consists of mongoDB and postgreDB */
 	must(container.Provide(storage.NewStorageSrv))
+	/* registrar of the persistent identifiers of the works */
+	must(container.Provide(func(config *config.Config, storageSrv *storage.StorageSrv) pid.Registrar {
+		registrar, err := pid.NewRegistrar(config, storageSrv)
+		if err != nil {
+			panic(fmt.Errorf("unable to create the PID registrar: %v", err))
+		}
+		return registrar
+	}))
 	/* initialize internal services */
 	must(container.Provide(lib.NewLibrarySrv))
 	must(container.Provide(rest.NewRestSrv))
@@ -0,0 +1,140 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/citation"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+
+	"github.com/gorilla/mux"
+)
+
+// citationFormat returns the requested citation format, BibTeX by default
+func citationFormat(r *http.Request) string {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return strings.ToLower(format)
+	}
+	return citation.BibTeXFormat
+}
+
+func responCitation(w http.ResponseWriter, name, format, citations string) {
+	responFile(w, name+citation.Extension(format), citation.ContentType(format),
+		int64(len(citations)), strings.NewReader(citations))
+}
+
+func responCitationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, citation.ErrUnknownFormat):
+		responError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, storage.ErrWorkNotExists):
+		responError(w, http.StatusNotFound, err.Error())
+	default:
+		responError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// HandleCiteWork CiteWork godoc
+// @Summary      Cite work
+// @Description  Export the citation of the work in BibTeX, RIS or CSL-JSON, or format it in the APA
+// @Description  or GOST R 7.0.5-2008 style. The author is cited by the name from the profile, followed by the co-authors.
+// @Description  The exports carry the license of the work: the BibTeX copyright, the RIS note and the CSL license.
+// @Tags         Citations
+// @Produce      plain
+// @Param        work_id   path      string  true   "work id"
+// @Param        format    query     string  false  "bibtex(default), ris, csl, apa or gost"
+// @Success      200  {string}  string
+// @Failure      400  {object}  ErrorMsg
+// @Failure      401  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /works/{work_id}/cite [get]
+func (rs *RestSrv) HandleCiteWork(w http.ResponseWriter, r *http.Request) {
+	web3Address, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	vars := mux.Vars(r)
+	workID, ok := vars["work_id"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	format := citationFormat(r)
+	citations, err := rs.libSrv.CiteWork(r.Context(), web3Address, workID, format)
+	if err != nil {
+		responCitationError(w, err)
+
+		return
+	}
+
+	responCitation(w, workID, format, citations)
+}
+
+// HandleCiteBookmarks CiteBookmarks godoc
+// @Summary      Cite bookmarks
+// @Description  Export the citations of the bookmarked works in BibTeX, RIS or CSL-JSON, or format them
+// @Description  in the APA or GOST R 7.0.5-2008 style as the numbered list
+// @Tags         Citations
+// @Produce      plain
+// @Param        format    query     string  false  "bibtex(default), ris, csl, apa or gost"
+// @Success      200  {string}  string
+// @Failure      400  {object}  ErrorMsg
+// @Failure      401  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /bookmarks/cite [get]
+func (rs *RestSrv) HandleCiteBookmarks(w http.ResponseWriter, r *http.Request) {
+	web3Address, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	format := citationFormat(r)
+	citations, err := rs.libSrv.CiteBookmarks(r.Context(), web3Address, format)
+	if err != nil {
+		responCitationError(w, err)
+
+		return
+	}
+
+	responCitation(w, "bookmarks", format, citations)
+}
+
+// HandleCitePurchasedWorks CitePurchasedWorks godoc
+// @Summary      Cite purchased works
+// @Description  Export the citations of the purchased works in BibTeX, RIS or CSL-JSON, or format them
+// @Description  in the APA or GOST R 7.0.5-2008 style as the numbered list
+// @Tags         Citations
+// @Produce      plain
+// @Param        format    query     string  false  "bibtex(default), ris, csl, apa or gost"
+// @Success      200  {string}  string
+// @Failure      400  {object}  ErrorMsg
+// @Failure      401  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /purchased_works/cite [get]
+func (rs *RestSrv) HandleCitePurchasedWorks(w http.ResponseWriter, r *http.Request) {
+	web3Address, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	format := citationFormat(r)
+	citations, err := rs.libSrv.CitePurchasedWorks(r.Context(), web3Address, format)
+	if err != nil {
+		responCitationError(w, err)
+
+		return
+	}
+
+	responCitation(w, "purchased_works", format, citations)
+}
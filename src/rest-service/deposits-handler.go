@@ -0,0 +1,114 @@
+package rest
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	srv "github.com/SeaOfWisdom/sow_library/src/service"
+	"github.com/SeaOfWisdom/sow_library/src/service/crossref"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+)
+
+func depositErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, storage.ErrWorkNotExists), errors.Is(err, storage.ErrPIDNotExists):
+		return http.StatusNotFound
+	case errors.Is(err, srv.ErrNoDOI), errors.Is(err, crossref.ErrInvalid):
+		return http.StatusBadRequest
+	case errors.Is(err, srv.ErrDepositsDisabled):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// HandleDepositXML DepositXML godoc
+// @Summary      Deposit XML
+// @Description  Generate the Crossref deposit(schema 5.3.1) of the open works identified by the DOIs(admin only).
+// @Description  One work or the batch of the works is deposited, the deposit is validated and isn't submitted.
+// @Tags         Deposits
+// @Produce      xml
+// @Param        work_id   query     []string  true  "work id, repeated for the batch"  collectionFormat(multi)
+// @Success      200  {file}  file
+// @Failure      400  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /deposits/xml [get]
+func (rs *RestSrv) HandleDepositXML(w http.ResponseWriter, r *http.Request) {
+	workIDs := r.URL.Query()["work_id"]
+	if len(workIDs) == 0 {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	data, err := rs.libSrv.DepositXML(r.Context(), workIDs)
+	if err != nil {
+		responError(w, depositErrorStatus(err), err.Error())
+
+		return
+	}
+
+	name := "deposit.xml"
+	if len(workIDs) == 1 {
+		name = workIDs[0] + ".xml"
+	}
+	responFile(w, name, "application/xml; charset=utf-8", int64(len(data)), bytes.NewReader(data))
+}
+
+// HandleDepositWorks DepositWorks godoc
+// @Summary      Deposit works
+// @Description  Submit the deposit of the open works identified by the DOIs to Crossref(admin only). The works
+// @Description  deposited before are deposited again, e.g. after their metadata has changed. The works whose metadata
+// @Description  doesn't match the schema fail at once, the rest are processed by Crossref and checked by the cron.
+// @Tags         Deposits
+// @Accept       json
+// @Produce      json
+// @Param        request  body  DepositWorksRequest  true  "works"
+// @Success      200  {array}  storage.WorkDeposit
+// @Failure      400  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Failure      503  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /deposits [post]
+func (rs *RestSrv) HandleDepositWorks(w http.ResponseWriter, r *http.Request) {
+	request := new(DepositWorksRequest)
+	if err := rs.getRequest(r.Body, request); err != nil {
+		responError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	deposits, err := rs.libSrv.DepositWorks(r.Context(), request.WorkIDs)
+	if err != nil {
+		responError(w, depositErrorStatus(err), err.Error())
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, deposits)
+}
+
+// HandleWorkDeposits WorkDeposits godoc
+// @Summary      Deposits
+// @Description  Deposits of the works to Crossref and their statuses, the latest first(admin only)
+// @Tags         Deposits
+// @Produce      json
+// @Param        work_id  query  string  false  "work id"
+// @Param        status   query  string  false  "DEPOSIT_PENDING, DEPOSIT_SUBMITTED, DEPOSIT_SUCCEEDED or DEPOSIT_FAILED"
+// @Success      200  {array}  storage.WorkDeposit
+// @Failure      500  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /deposits [get]
+func (rs *RestSrv) HandleWorkDeposits(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	deposits, err := rs.libSrv.GetWorkDeposits(query.Get("work_id"), storage.DepositStatus(query.Get("status")))
+	if err != nil {
+		responError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, deposits)
+}
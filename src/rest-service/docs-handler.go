@@ -1,27 +1,47 @@
 package rest
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 
-	ocr "github.com/SeaOfWisdom/sow_proto/ocr-srv"
+	srv "github.com/SeaOfWisdom/sow_library/src/service"
+	"github.com/SeaOfWisdom/sow_library/src/service/blobstore"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
 
 	"github.com/gorilla/mux"
 )
 
 // HandlerUploadDoc UploadDoc godoc
 // @Summary      Upload doc of work
-// @Description  Uploading documents confirming work
+// @Description  Uploading documents confirming work, the document is saved in the blob store.
+// @Description  The "paper" document is recognized into the draft work, the returned extraction tracks the recognition.
+// @Description  The "jats" document is the JATS XML article imported into the draft work right away.
 // @Tags         Docs
-// @Accept       json
+// @Accept       mpfd
 // @Produce      json
-// @Param        doc_type   path      string  true  "work id"
-// @Success      200  {object}  SuccessMsg
+// @Param        doc_type   path      string  true  "document type"
+// @Param        work_id   query      string  false  "work id the document belongs to"
+// @Param        doc   formData      file  true  "document"
+// @Success      200  {object}  storage.BlobRecord
+// @Success      201  {object}  storage.WorkResponse
+// @Success      202  {object}  storage.DocumentExtraction
 // @Failure      400  {object}  ErrorMsg
+// @Failure      403  {object}  ErrorMsg
+// @Failure      413  {object}  ErrorMsg
+// @Failure      415  {object}  ErrorMsg
+// @Failure      503  {object}  ErrorMsg
 // @Security Bearer
-// @Router       /upload_doc [put]
+// @Router       /upload_doc/{doc_type} [put]
 func (rs *RestSrv) HandlerUploadDoc(w http.ResponseWriter, r *http.Request) {
+	web3Address, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
 	vars := mux.Vars(r)
 	docType, ok := vars["doc_type"]
 	if !ok {
@@ -47,47 +67,102 @@ func (rs *RestSrv) HandlerUploadDoc(w http.ResponseWriter, r *http.Request) {
 
 	// read all of the contents of our uploaded file into a
 	// byte array
-
-	/// !!!! TODO !!!!
-	imageBytes, err := io.ReadAll(file)
+	docBytes, err := io.ReadAll(file)
 	if err != nil {
 		rs.logger.Errorf("HandlerUploadDoc: %v", err)
-		responJSON(w, http.StatusOK, SuccessMsg{Msg: "OK"})
+		responError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	// the paper is recognized into the draft work in the background
+	if docType == "paper" {
+		extraction, err := rs.libSrv.CreateDraftFromPaper(r.Context(), web3Address, handler.Filename, docBytes)
+		if err != nil {
+			responDraftError(w, err)
+
+			return
+		}
+
+		responJSON(w, http.StatusAccepted, extraction)
 
 		return
 	}
 
-	imageResp, err := rs.ocrSrv.ExtractText(r.Context(), &ocr.ExtractTextRequest{
-		Image: imageBytes,
-	})
+	if docType == "jats" {
+		work, err := rs.libSrv.ImportJATS(r.Context(), web3Address, docBytes)
+		if err != nil {
+			responDraftError(w, err)
+
+			return
+		}
+
+		responJSON(w, http.StatusCreated, work)
+
+		return
+	}
+
+	record, err := rs.libSrv.UploadDocument(r.Context(), web3Address, r.URL.Query().Get("work_id"), handler.Filename, docBytes)
 	if err != nil {
-		rs.logger.Errorf("HandlerUploadDoc: while extract text via ocr service, err: %v", err)
-		responJSON(w, http.StatusOK, SuccessMsg{Msg: "OK"})
+		responBlobError(w, err)
 
 		return
 	}
 
-	rs.logger.Errorf("HandlerUploadDoc: abstract: %v", imageResp.Abstract)
-	rs.logger.Errorf("HandlerUploadDoc: main: %s", imageResp.Main)
+	responJSON(w, http.StatusOK, record)
+}
 
-	// TODO
-	if docType == "paper" {
-		// if _, _, err := rs.libSrv.PublishWork(web3Address, request.Work); err != nil {
-		// 	responError(w, http.StatusBadRequest, err.Error())
-		// 	return
-		// }
+// HandleGetBlob GetBlob godoc
+// @Summary      Download document
+// @Description  Download the uploaded document, available to its owner, admins and the readers of the work's content
+// @Tags         Docs
+// @Produce      octet-stream
+// @Param        blob_id   path      string  true  "document id"
+// @Success      200  {file}   file
+// @Failure      400  {object}  ErrorMsg
+// @Failure      403  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /blobs/{blob_id} [get]
+func (rs *RestSrv) HandleGetBlob(w http.ResponseWriter, r *http.Request) {
+	web3Address, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
 	}
 
-	// // Create a temporary file within our temp-images directory that follows
-	// // a particular naming pattern
-	// tempFile, err := ioutil.TempFile("temp-images", "upload-*.png")
-	// if err != nil {
-	// 	fmt.Println(err)
-	// }
-	// defer tempFile.Close()
-
-	// // write this byte array to our temporary file
-	// tempFile.Write(fileBytes)
-	// return that we have successfully uploaded our file!
-	responJSON(w, http.StatusOK, SuccessMsg{Msg: "OK"})
+	vars := mux.Vars(r)
+	blobID, ok := vars["blob_id"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	record, data, err := rs.libSrv.GetBlob(r.Context(), web3Address, blobID)
+	if err != nil {
+		responBlobError(w, err)
+
+		return
+	}
+	defer data.Close()
+
+	responFile(w, record.Name, record.ContentType, record.Size, data)
+}
+
+func responBlobError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, storage.ErrBlobNotExists), errors.Is(err, blobstore.ErrNotFound),
+		errors.Is(err, storage.ErrWorkNotExists):
+		responError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, srv.ErrBlobAccessDenied):
+		responError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, blobstore.ErrTooLarge):
+		responError(w, http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, blobstore.ErrTypeNotAllowed):
+		responError(w, http.StatusUnsupportedMediaType, err.Error())
+	default:
+		responError(w, http.StatusInternalServerError, err.Error())
+	}
 }
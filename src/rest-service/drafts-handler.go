@@ -0,0 +1,161 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	srv "github.com/SeaOfWisdom/sow_library/src/service"
+	"github.com/SeaOfWisdom/sow_library/src/service/blobstore"
+	"github.com/SeaOfWisdom/sow_library/src/service/jats"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+
+	"github.com/gorilla/mux"
+)
+
+// HandleGetExtraction GetExtraction godoc
+// @Summary      Paper extraction status
+// @Description  Get the status of the paper recognition, the draft work id is set when it's done.
+// @Description  The PDF is processed page by page, the status of each page is listed with its failure reason.
+// @Tags         Drafts
+// @Produce      json
+// @Param        extraction_id   path      string  true  "extraction id"
+// @Success      200  {object}  storage.DocumentExtraction
+// @Failure      400  {object}  ErrorMsg
+// @Failure      403  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /extractions/{extraction_id} [get]
+func (rs *RestSrv) HandleGetExtraction(w http.ResponseWriter, r *http.Request) {
+	web3Address, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	vars := mux.Vars(r)
+	extractionID, ok := vars["extraction_id"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	extraction, err := rs.libSrv.GetExtraction(r.Context(), web3Address, extractionID)
+	if err != nil {
+		responDraftError(w, err)
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, extraction)
+}
+
+// HandleUpdateDraft UpdateDraft godoc
+// @Summary      Update draft
+// @Description  Save the author's corrections of the draft work recognized from the paper
+// @Description  The license rules are the same as for the new work.
+// @Tags         Drafts
+// @Accept       json
+// @Produce      json
+// @Param        work_id   path      string  true  "work id"
+// @Param        Work body DraftReq true "draft work"
+// @Success      200  {object}  storage.WorkResponse
+// @Failure      400  {object}  ErrorMsg
+// @Failure      403  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Failure      409  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /update_draft/{work_id} [post]
+func (rs *RestSrv) HandleUpdateDraft(w http.ResponseWriter, r *http.Request) {
+	web3Address, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	vars := mux.Vars(r)
+	workID, ok := vars["work_id"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	request := new(DraftReq)
+	if err := rs.getRequest(r.Body, request); err != nil {
+		responError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	workResp, err := rs.libSrv.UpdateDraft(r.Context(), web3Address, workID, request.Work)
+	if err != nil {
+		responDraftError(w, err)
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, workResp)
+}
+
+// HandleSubmitDraft SubmitDraft godoc
+// @Summary      Submit draft
+// @Description  Send the draft work to the review, the draft must have the name, the annotation and the content
+// @Tags         Drafts
+// @Produce      json
+// @Param        work_id   path      string  true  "work id"
+// @Success      200  {object}  storage.WorkResponse
+// @Failure      400  {object}  ErrorMsg
+// @Failure      403  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Failure      409  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /submit_draft/{work_id} [post]
+func (rs *RestSrv) HandleSubmitDraft(w http.ResponseWriter, r *http.Request) {
+	web3Address, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	vars := mux.Vars(r)
+	workID, ok := vars["work_id"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	workResp, err := rs.libSrv.SubmitDraft(r.Context(), web3Address, workID)
+	if err != nil {
+		responDraftError(w, err)
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, workResp)
+}
+
+func responDraftError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, storage.ErrExtractionNotExists), errors.Is(err, storage.ErrWorkNotExists):
+		responError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, srv.ErrNotAuthor), errors.Is(err, srv.ErrDraftAccessDenied),
+		errors.Is(err, srv.ErrBlobAccessDenied):
+		responError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, srv.ErrNotDraft):
+		responError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, srv.ErrIncompleteDraft), errors.Is(err, jats.ErrMalformed),
+		errors.Is(err, srv.ErrWrongLicense), errors.Is(err, srv.ErrLicensePaywalled):
+		responError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, blobstore.ErrTooLarge), errors.Is(err, blobstore.ErrTypeNotAllowed):
+		responBlobError(w, err)
+	case errors.Is(err, srv.ErrServiceStopped):
+		responError(w, http.StatusServiceUnavailable, err.Error())
+	default:
+		responError(w, http.StatusInternalServerError, err.Error())
+	}
+}
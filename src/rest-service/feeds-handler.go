@@ -0,0 +1,156 @@
+package rest
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	srv "github.com/SeaOfWisdom/sow_library/src/service"
+	"github.com/SeaOfWisdom/sow_library/src/service/feed"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+
+	"github.com/gorilla/mux"
+)
+
+// requestURL returns the public URL the request was made to, the scheme is taken from the proxy
+func requestURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
+
+func (rs *RestSrv) respondFeed(w http.ResponseWriter, r *http.Request, format string, filter *srv.FeedFilter) {
+	data, err := rs.libSrv.WorksFeed(r.Context(), requestURL(r), format, filter)
+	if err != nil {
+		switch {
+		case errors.Is(err, feed.ErrUnknownFormat):
+			responError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, storage.ErrParticipantNotExists):
+			responError(w, http.StatusNotFound, err.Error())
+		default:
+			responError(w, http.StatusInternalServerError, err.Error())
+		}
+
+		return
+	}
+
+	responFile(w, "", feed.ContentType(format), int64(len(data)), bytes.NewReader(data))
+}
+
+// HandleWorksFeed WorksFeed godoc
+// @Summary      Feed of the opened works
+// @Description  Atom 1.0 or RSS 2.0 feed of the latest works opened in the library, the latest first. The feed
+// @Description  lists all the opened works or the works of the tag and(or) the language.
+// @Tags         Feeds
+// @Produce      xml
+// @Param        format    path      string  true   "atom or rss"
+// @Param        tag       query     string  false  "tag of the works"
+// @Param        language  query     string  false  "language of the works, e.g. en"
+// @Success      200  {string}  string
+// @Failure      400  {object}  ErrorMsg
+// @Failure      500  {object}  ErrorMsg
+// @Router       /feeds/works.{format} [get]
+func (rs *RestSrv) HandleWorksFeed(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	format, ok := vars["format"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	query := r.URL.Query()
+	rs.respondFeed(w, r, format, &srv.FeedFilter{Tag: query.Get("tag"), Language: query.Get("language")})
+}
+
+// HandleAuthorWorksFeed AuthorWorksFeed godoc
+// @Summary      Feed of the author's opened works
+// @Description  Atom 1.0 or RSS 2.0 feed of the latest opened works of the author, the latest first
+// @Tags         Feeds
+// @Produce      xml
+// @Param        web3_address  path      string  true  "web3 address of the author"
+// @Param        format        path      string  true  "atom or rss"
+// @Success      200  {string}  string
+// @Failure      400  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Failure      500  {object}  ErrorMsg
+// @Router       /works/author/{web3_address}/feed.{format} [get]
+func (rs *RestSrv) HandleAuthorWorksFeed(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	web3Address, ok := vars["web3_address"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	format, ok := vars["format"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	rs.respondFeed(w, r, format, &srv.FeedFilter{AuthorAddress: web3Address})
+}
+
+// HandleSitemap Sitemap godoc
+// @Summary      Sitemap
+// @Description  XML sitemap of the library for the search engines: the library page and the pages of the open works
+// @Description  with the dates they were opened
+// @Tags         Feeds
+// @Produce      xml
+// @Success      200  {string}  string
+// @Failure      500  {object}  ErrorMsg
+// @Router       /sitemap.xml [get]
+func (rs *RestSrv) HandleSitemap(w http.ResponseWriter, r *http.Request) {
+	data, err := rs.libSrv.Sitemap(r.Context())
+	if err != nil {
+		responError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	responFile(w, "", feed.SitemapContentType, int64(len(data)), bytes.NewReader(data))
+}
+
+// HandleWorkJSONLD WorkJSONLD godoc
+// @Summary      Work JSON-LD
+// @Description  Schema.org ScholarlyArticle of the open work in JSON-LD, the page of the work embeds it as
+// @Description  the application/ld+json script for the search engines
+// @Tags         Works
+// @Produce      json
+// @Param        work_id   path      string  true  "work id"
+// @Success      200  {string}  string
+// @Failure      400  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Failure      500  {object}  ErrorMsg
+// @Router       /works/{work_id}/jsonld [get]
+func (rs *RestSrv) HandleWorkJSONLD(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	workID, ok := vars["work_id"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	data, err := rs.libSrv.WorkJSONLD(r.Context(), workID)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrWorkNotExists):
+			responError(w, http.StatusNotFound, err.Error())
+		default:
+			responError(w, http.StatusInternalServerError, err.Error())
+		}
+
+		return
+	}
+
+	responFile(w, "", feed.JSONLDContentType, int64(len(data)), bytes.NewReader(data))
+}
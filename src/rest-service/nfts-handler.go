@@ -0,0 +1,96 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	srv "github.com/SeaOfWisdom/sow_library/src/service"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+
+	"github.com/gorilla/mux"
+)
+
+// HandleLinkWorkNFT LinkWorkNFT godoc
+// @Summary      Link work to NFT
+// @Description  Opt the work in to be minted as NFT. The tokens aren't minted yet, the open work is linked
+// @Description  to the token of its on-chain paper right away, the work under review is linked once it's
+// @Description  approved and published on-chain.
+// @Tags         NFT
+// @Produce      json
+// @Param        work_id   path      string  true  "work id"
+// @Success      200  {object}  storage.ParticipantsWork
+// @Failure      400  {object}  ErrorMsg
+// @Failure      401  {object}  ErrorMsg
+// @Failure      403  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Failure      409  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /link_nft/{work_id} [post]
+func (rs *RestSrv) HandleLinkWorkNFT(w http.ResponseWriter, r *http.Request) {
+	web3Address, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	vars := mux.Vars(r)
+	workID, ok := vars["work_id"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	participantsWork, err := rs.libSrv.OptInWorkNFT(r.Context(), web3Address, workID)
+	if err != nil {
+		responNFTError(w, err)
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, participantsWork)
+}
+
+// HandleNFTMetadata NFTMetadata godoc
+// @Summary      NFT metadata
+// @Description  ERC-721 metadata JSON of the work linked to the token: the name, the annotation as the description,
+// @Description  the image and the attributes for the tags, the language, the science and the authors
+// @Tags         NFT
+// @Produce      json
+// @Param        token_id   path      string  true  "token id"
+// @Success      200  {object}  srv.NFTMetadata
+// @Failure      400  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Router       /nft/{token_id} [get]
+func (rs *RestSrv) HandleNFTMetadata(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tokenID, ok := vars["token_id"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	metadata, err := rs.libSrv.GetNFTMetadata(r.Context(), tokenID)
+	if err != nil {
+		responNFTError(w, err)
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, metadata)
+}
+
+func responNFTError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, storage.ErrNFTNotExists), errors.Is(err, storage.ErrWorkNotExists):
+		responError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, srv.ErrNFTAccessDenied):
+		responError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, srv.ErrNFTNotAllowed):
+		responError(w, http.StatusConflict, err.Error())
+	default:
+		responError(w, http.StatusInternalServerError, err.Error())
+	}
+}
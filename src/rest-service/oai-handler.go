@@ -0,0 +1,52 @@
+package rest
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/oaipmh"
+)
+
+// HandleOAI OAI godoc
+// @Summary      OAI-PMH provider
+// @Description  OAI-PMH 2.0 endpoint for the harvesters of the open works: Identify, ListMetadataFormats, ListSets,
+// @Description  ListIdentifiers, ListRecords and GetRecord. The records are disseminated in oai_dc and jats(the front
+// @Description  matter of the JATS article), the sets are the sciences and the tags, the datestamps are the times
+// @Description  the works were opened. The errors of the request are reported in the OAI-PMH response.
+// @Tags         Harvesting
+// @Accept       x-www-form-urlencoded
+// @Produce      xml
+// @Param        verb             query     string  true   "OAI-PMH verb"
+// @Param        identifier       query     string  false  "oai:{host}:{work_id}"
+// @Param        metadataPrefix   query     string  false  "oai_dc or jats"
+// @Param        from             query     string  false  "YYYY-MM-DD or YYYY-MM-DDThh:mm:ssZ"
+// @Param        until            query     string  false  "YYYY-MM-DD or YYYY-MM-DDThh:mm:ssZ"
+// @Param        set              query     string  false  "set spec"
+// @Param        resumptionToken  query     string  false  "resumption token"
+// @Success      200  {string}  string
+// @Failure      500  {object}  ErrorMsg
+// @Router       /oai [get]
+// @Router       /oai [post]
+func (rs *RestSrv) HandleOAI(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		responError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	resp, err := rs.libSrv.HarvestOAI(r.Context(), r.Form)
+	if err != nil {
+		responError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	data, err := oaipmh.Marshal(resp)
+	if err != nil {
+		responError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	responFile(w, "", oaipmh.ContentType, int64(len(data)), bytes.NewReader(data))
+}
@@ -59,7 +59,7 @@ func (rs *RestSrv) HandleAuth(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	rs.logger.Info("HandleAuth: request address: %s", web3AddrStr)
+	rs.logger.Infof("HandleAuth: request address: %s", web3AddrStr)
 
 	// try to find a participant by the web3 address
 	participant, err := rs.libSrv.GetParticipantByWeb3Address(web3AddrStr)
@@ -0,0 +1,54 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/pid"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+
+	"github.com/gorilla/mux"
+)
+
+// HandleResolvePID ResolvePID godoc
+// @Summary      Resolve persistent identifier
+// @Description  Redirect to the page of the work identified by the persistent identifier
+// @Description  minted under the configured prefix, e.g. /id/10.5555/sow.2026.00042
+// @Tags         Works
+// @Param        prefix   path      string  true  "prefix of the identifier"
+// @Param        suffix   path      string  true  "suffix of the identifier"
+// @Success      302
+// @Failure      400  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Router       /id/{prefix}/{suffix} [get]
+func (rs *RestSrv) HandleResolvePID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	prefix, ok := vars["prefix"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+	suffix, ok := vars["suffix"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	url, err := rs.libSrv.ResolvePID(prefix + "/" + suffix)
+	if err != nil {
+		switch {
+		case errors.Is(err, pid.ErrMalformed):
+			responError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, storage.ErrPIDNotExists):
+			responError(w, http.StatusNotFound, err.Error())
+		default:
+			responError(w, http.StatusInternalServerError, err.Error())
+		}
+
+		return
+	}
+
+	http.Redirect(w, r, url, http.StatusFound)
+}
@@ -0,0 +1,277 @@
+package rest
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+
+	srv "github.com/SeaOfWisdom/sow_library/src/service"
+	"github.com/SeaOfWisdom/sow_library/src/service/ingest"
+	"github.com/SeaOfWisdom/sow_library/src/service/jats"
+	"github.com/SeaOfWisdom/sow_library/src/service/render"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+
+	"github.com/gorilla/mux"
+)
+
+// HandleVerifyWork VerifyWork godoc
+// @Summary      Verify work
+// @Description  Recompute the fingerprint of the published work(its metadata, content and attached files)
+// @Description  and compare it with the fingerprints of the last approved revision saved to the library,
+// @Description  pinned to IPFS and published on-chain. The work is verified if all of them match.
+// @Tags         Works
+// @Produce      json
+// @Param        work_id   path      string  true  "work id"
+// @Success      200  {object}  srv.WorkVerification
+// @Failure      400  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Failure      409  {object}  ErrorMsg
+// @Router       /works/{work_id}/verify [get]
+func (rs *RestSrv) HandleVerifyWork(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	workID, ok := vars["work_id"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	verification, err := rs.libSrv.VerifyWork(r.Context(), workID)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrWorkNotExists):
+			responError(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, srv.ErrWorkNotPublished):
+			responError(w, http.StatusConflict, err.Error())
+		default:
+			responError(w, http.StatusInternalServerError, err.Error())
+		}
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, verification)
+}
+
+// HandleWorkKey WorkKey godoc
+// @Summary      Work content key
+// @Description  Deliver the data key of the encrypted work content to the reader who has access to it.
+// @Description  The key is encrypted by ECIES to the public key of the reader's wallet, so the reader
+// @Description  can fetch the pinned work from IPFS and decrypt its AES-256-GCM content(nonce || ciphertext).
+// @Tags         Works
+// @Accept       json
+// @Produce      json
+// @Param        work_id   path      string  true  "work id"
+// @Param        Key body WorkKeyReq true "public key of the reader's wallet"
+// @Success      200  {object}  srv.WorkKey
+// @Failure      400  {object}  ErrorMsg
+// @Failure      401  {object}  ErrorMsg
+// @Failure      403  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Failure      409  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /work_key/{work_id} [post]
+func (rs *RestSrv) HandleWorkKey(w http.ResponseWriter, r *http.Request) {
+	web3Address, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	vars := mux.Vars(r)
+	workID, ok := vars["work_id"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	request := new(WorkKeyReq)
+	if err := rs.getRequest(r.Body, request); err != nil {
+		responError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	key, err := rs.libSrv.GetWorkKey(r.Context(), web3Address, workID, request.PublicKey)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrWorkNotExists):
+			responError(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, srv.ErrWrongPublicKey):
+			responError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, srv.ErrContentAccessDenied):
+			responError(w, http.StatusForbidden, err.Error())
+		case errors.Is(err, storage.ErrContentNotEncrypted):
+			responError(w, http.StatusConflict, err.Error())
+		default:
+			responError(w, http.StatusInternalServerError, err.Error())
+		}
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, key)
+}
+
+// HandleRenderWork RenderWork godoc
+// @Summary      Render work
+// @Description  Render the content of the work from its source format(plain text, Markdown or LaTeX)
+// @Description  to the sanitized HTML fragment or the plain text. The math is kept in the \( \) and \[ \]
+// @Description  delimiters to be typeset by the client. The access rules are the same as for the work by id.
+// @Tags         Works
+// @Produce      html
+// @Produce      plain
+// @Param        work_id   path      string  true   "work id"
+// @Param        format    query     string  false  "output format: html(default) or txt"
+// @Success      200  {string}  string
+// @Failure      400  {object}  ErrorMsg
+// @Failure      401  {object}  ErrorMsg
+// @Failure      403  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /works/{work_id}/render [get]
+func (rs *RestSrv) HandleRenderWork(w http.ResponseWriter, r *http.Request) {
+	web3Address, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	vars := mux.Vars(r)
+	workID, ok := vars["work_id"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	output := r.URL.Query().Get("format")
+	if output == "" {
+		output = render.HTMLOutput
+	}
+
+	body, err := rs.libSrv.RenderWork(r.Context(), web3Address, workID, output)
+	if err != nil {
+		switch {
+		case errors.Is(err, render.ErrUnknownOutput), errors.Is(err, render.ErrUnknownFormat):
+			responError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, storage.ErrWorkNotExists), errors.Is(err, srv.ErrNoContent):
+			responError(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, srv.ErrContentAccessDenied):
+			responError(w, http.StatusForbidden, err.Error())
+		default:
+			responError(w, http.StatusInternalServerError, err.Error())
+		}
+
+		return
+	}
+
+	contentType := "text/html; charset=utf-8"
+	if output == render.TextOutput {
+		contentType = "text/plain; charset=utf-8"
+	}
+	responFile(w, "", contentType, int64(len(body)), strings.NewReader(body))
+}
+
+// HandleWorkPDF WorkPDF godoc
+// @Summary      Work PDF
+// @Description  Download the PDF of the work: the cover page with its title, authors, annotation, license,
+// @Description  content hash and the "cite as" block followed by the rendered content. The access rules are
+// @Description  the same as for the work by id, the copy is stamped with the reader's watermark. The PDF which
+// @Description  can't be stamped isn't served.
+// @Tags         Works
+// @Produce      application/pdf
+// @Param        work_id   path      string  true  "work id"
+// @Success      200  {file}  file
+// @Failure      400  {object}  ErrorMsg
+// @Failure      401  {object}  ErrorMsg
+// @Failure      403  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Failure      500  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /works/{work_id}/pdf [get]
+func (rs *RestSrv) HandleWorkPDF(w http.ResponseWriter, r *http.Request) {
+	web3Address, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	vars := mux.Vars(r)
+	workID, ok := vars["work_id"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	data, err := rs.libSrv.WorkPDF(r.Context(), web3Address, workID)
+	if err != nil {
+		switch {
+		case errors.Is(err, render.ErrUnknownFormat):
+			responError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, storage.ErrWorkNotExists), errors.Is(err, srv.ErrNoContent):
+			responError(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, srv.ErrContentAccessDenied):
+			responError(w, http.StatusForbidden, err.Error())
+		default:
+			responError(w, http.StatusInternalServerError, err.Error())
+		}
+
+		return
+	}
+
+	responFile(w, workID+".pdf", ingest.PDFContentType, int64(len(data)), bytes.NewReader(data))
+}
+
+// HandleWorkJATS WorkJATS godoc
+// @Summary      Work JATS
+// @Description  Export the open work as the JATS XML article(Journal Archiving and Interchange 1.3). The body
+// @Description  is included if the reader has access to the content, the finished reviews are included as
+// @Description  the reviewer reports if the review of the work is open.
+// @Tags         Works
+// @Produce      xml
+// @Param        work_id   path      string  true  "work id"
+// @Success      200  {file}  file
+// @Failure      400  {object}  ErrorMsg
+// @Failure      401  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /works/{work_id}/jats [get]
+func (rs *RestSrv) HandleWorkJATS(w http.ResponseWriter, r *http.Request) {
+	web3Address, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	vars := mux.Vars(r)
+	workID, ok := vars["work_id"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	data, err := rs.libSrv.ExportJATS(r.Context(), web3Address, workID)
+	if err != nil {
+		switch {
+		case errors.Is(err, render.ErrUnknownFormat):
+			responError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, storage.ErrWorkNotExists):
+			responError(w, http.StatusNotFound, err.Error())
+		default:
+			responError(w, http.StatusInternalServerError, err.Error())
+		}
+
+		return
+	}
+
+	responFile(w, workID+".xml", jats.ContentType, int64(len(data)), bytes.NewReader(data))
+}
@@ -0,0 +1,160 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+
+	"github.com/gorilla/mux"
+)
+
+// HandleQuestionnaireTemplates QuestionnaireTemplates godoc
+// @Summary      Questionnaire templates
+// @Description  Get the latest version of all questionnaire templates
+// @Tags         Questionnaires
+// @Accept       json
+// @Produce      json
+// @Param        science   query      string  false  "science area"
+// @Success      200  {object}   []storage.QuestionnaireTemplate
+// @Failure      400  {object}  ErrorMsg
+// @Router       /questionnaire_templates [get]
+func (rs *RestSrv) HandleQuestionnaireTemplates(w http.ResponseWriter, r *http.Request) {
+	science := r.URL.Query().Get("science")
+
+	templates, err := rs.libSrv.GetQuestionnaireTemplates(r.Context(), science)
+	if err != nil {
+		responError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, templates)
+}
+
+// HandleQuestionnaireTemplate QuestionnaireTemplate godoc
+// @Summary      Questionnaire template
+// @Description  Get the certain version of the questionnaire template, the latest one by default
+// @Tags         Questionnaires
+// @Accept       json
+// @Produce      json
+// @Param        template_id   path      string  true  "template id"
+// @Param        version   query      int  false  "template version"
+// @Success      200  {object}   storage.QuestionnaireTemplate
+// @Failure      400  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Router       /questionnaire_templates/{template_id} [get]
+func (rs *RestSrv) HandleQuestionnaireTemplate(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	templateID, ok := vars["template_id"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	var version int64
+	if versionStr := r.URL.Query().Get("version"); versionStr != "" {
+		var err error
+		if version, err = strconv.ParseInt(versionStr, 10, 64); err != nil {
+			responError(w, http.StatusBadRequest, "wrong template version")
+
+			return
+		}
+	}
+
+	template, err := rs.libSrv.GetQuestionnaireTemplate(r.Context(), templateID, version)
+	if err != nil {
+		if errors.Is(err, storage.ErrTemplateNotExists) {
+			responError(w, http.StatusNotFound, err.Error())
+
+			return
+		}
+
+		responError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, template)
+}
+
+// HandleSaveQuestionnaireTemplate SaveQuestionnaireTemplate godoc
+// @Summary      Save questionnaire template
+// @Description  Create a new questionnaire template or the next version of the existing one(admin only)
+// @Tags         Questionnaires
+// @Accept       json
+// @Produce      json
+// @Param        template body QuestionnaireTemplateRequest true "questionnaire template"
+// @Success      200  {object}   storage.QuestionnaireTemplate
+// @Failure      400  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /questionnaire_template [post]
+func (rs *RestSrv) HandleSaveQuestionnaireTemplate(w http.ResponseWriter, r *http.Request) {
+	request := new(QuestionnaireTemplateRequest)
+	if err := rs.getRequest(r.Body, request); err != nil {
+		responError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	template, err := rs.libSrv.SaveQuestionnaireTemplate(r.Context(), request.Template)
+	if err != nil {
+		if errors.Is(err, storage.ErrTemplateNotExists) {
+			responError(w, http.StatusNotFound, err.Error())
+
+			return
+		}
+
+		responError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, template)
+}
+
+// HandleWorkScore WorkScore godoc
+// @Summary      Work score
+// @Description  Aggregated questionnaire score of the work reviews
+// @Tags         Work review
+// @Accept       json
+// @Produce      json
+// @Param        work_id   path      string  true  "work id"
+// @Success      200  {object}   storage.WorkScore
+// @Failure      400  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /work_score/{work_id} [get]
+func (rs *RestSrv) HandleWorkScore(w http.ResponseWriter, r *http.Request) {
+	web3Address, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	vars := mux.Vars(r)
+	workID, ok := vars["work_id"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+	rs.logger.Infof("HandleWorkScore: request work id: %s", workID)
+
+	score, err := rs.libSrv.GetWorkScore(r.Context(), web3Address, workID)
+	if err != nil {
+		if errors.Is(err, storage.ErrWorkNotExists) {
+			responError(w, http.StatusNotFound, err.Error())
+
+			return
+		}
+
+		responError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, score)
+}
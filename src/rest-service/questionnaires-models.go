@@ -0,0 +1,19 @@
+package rest
+
+import (
+	"fmt"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+)
+
+type QuestionnaireTemplateRequest struct {
+	Template *storage.QuestionnaireTemplate `json:"template"`
+}
+
+func (r *QuestionnaireTemplateRequest) Validate() error {
+	if r.Template == nil {
+		return fmt.Errorf("template is null")
+	}
+
+	return r.Template.Validate()
+}
@@ -0,0 +1,222 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	srv "github.com/SeaOfWisdom/sow_library/src/service"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+
+	"github.com/gorilla/mux"
+)
+
+func responReferenceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, srv.ErrWrongReference):
+		responError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, srv.ErrNotAuthor):
+		responError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, storage.ErrWorkNotExists), errors.Is(err, storage.ErrParticipantNotExists),
+		errors.Is(err, srv.ErrNoContent):
+		responError(w, http.StatusNotFound, err.Error())
+	default:
+		responError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// HandleSetWorkReferences SetWorkReferences godoc
+// @Summary      Set work references
+// @Description  Replace the reference list of the author's work. A reference refers either to another
+// @Description  work of the library by its id or to the external work by its DOI, URL or the free text.
+// @Description  The references to the works of the library make the citation graph.
+// @Tags         Citations
+// @Accept       json
+// @Produce      json
+// @Param        work_id   path      string  true  "work id"
+// @Param        References body WorkReferencesReq true "reference list"
+// @Success      200  {object}  []storage.WorkReference
+// @Failure      400  {object}  ErrorMsg
+// @Failure      401  {object}  ErrorMsg
+// @Failure      403  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /work_references/{work_id} [post]
+func (rs *RestSrv) HandleSetWorkReferences(w http.ResponseWriter, r *http.Request) {
+	web3Address, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	vars := mux.Vars(r)
+	workID, ok := vars["work_id"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	var req WorkReferencesReq
+	if err := rs.getRequest(r.Body, &req); err != nil {
+		responError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	references, err := rs.libSrv.SetWorkReferences(r.Context(), web3Address, workID, req.workReferences())
+	if err != nil {
+		responReferenceError(w, err)
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, references)
+}
+
+// HandleProposedReferences ProposedReferences godoc
+// @Summary      Proposed work references
+// @Description  Parse the bibliography of the author's work content and propose the references: the authors,
+// @Description  the title, the year, the DOI and the URL of each entry and the work of the library it's matched to.
+// @Description  The proposals aren't saved, the author confirms them by setting the work references.
+// @Tags         Citations
+// @Produce      json
+// @Param        work_id   path      string  true  "work id"
+// @Success      200  {object}  []srv.ProposedReference
+// @Failure      400  {object}  ErrorMsg
+// @Failure      401  {object}  ErrorMsg
+// @Failure      403  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /work_references/{work_id}/proposed [get]
+func (rs *RestSrv) HandleProposedReferences(w http.ResponseWriter, r *http.Request) {
+	web3Address, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	vars := mux.Vars(r)
+	workID, ok := vars["work_id"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	proposals, err := rs.libSrv.ProposeWorkReferences(r.Context(), web3Address, workID)
+	if err != nil {
+		responReferenceError(w, err)
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, proposals)
+}
+
+// HandleWorkReferences WorkReferences godoc
+// @Summary      Work references
+// @Description  Get the reference list of the work with the cited works of the library
+// @Tags         Citations
+// @Produce      json
+// @Param        work_id   path      string  true  "work id"
+// @Success      200  {object}  []srv.WorkReferenceResponse
+// @Failure      400  {object}  ErrorMsg
+// @Failure      401  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /works/{work_id}/references [get]
+func (rs *RestSrv) HandleWorkReferences(w http.ResponseWriter, r *http.Request) {
+	web3Address, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	vars := mux.Vars(r)
+	workID, ok := vars["work_id"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	references, err := rs.libSrv.GetWorkReferences(r.Context(), web3Address, workID)
+	if err != nil {
+		responReferenceError(w, err)
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, references)
+}
+
+// HandleCitedBy CitedBy godoc
+// @Summary      Cited by
+// @Description  Get the open works citing the work
+// @Tags         Citations
+// @Produce      json
+// @Param        work_id   path      string  true  "work id"
+// @Success      200  {object}  []storage.WorkResponse
+// @Failure      400  {object}  ErrorMsg
+// @Failure      401  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /works/{work_id}/cited_by [get]
+func (rs *RestSrv) HandleCitedBy(w http.ResponseWriter, r *http.Request) {
+	web3Address, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	vars := mux.Vars(r)
+	workID, ok := vars["work_id"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	works, err := rs.libSrv.GetCitingWorks(r.Context(), web3Address, workID)
+	if err != nil {
+		responReferenceError(w, err)
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, works)
+}
+
+// HandleAuthorMetrics AuthorMetrics godoc
+// @Summary      Author metrics
+// @Description  Get the citation metrics of the author's open works: the number of the works,
+// @Description  the total citations and the h-index
+// @Tags         Citations
+// @Produce      json
+// @Param        web3_address   path      string  true  "web3 address of the author"
+// @Success      200  {object}  srv.AuthorMetrics
+// @Failure      400  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Router       /works/author/{web3_address}/metrics [get]
+func (rs *RestSrv) HandleAuthorMetrics(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	authorAddress, ok := vars["web3_address"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	metrics, err := rs.libSrv.GetAuthorMetrics(r.Context(), authorAddress)
+	if err != nil {
+		responReferenceError(w, err)
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, metrics)
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/SeaOfWisdom/sow_library/src/log"
 	"io"
 	"io/ioutil"
+	"mime"
 	"net/http"
+	"strconv"
 	"time"
 
 	_ "github.com/SeaOfWisdom/sow_library/docs" // docs is generated by Swag CLI, you have to import it.
@@ -111,35 +113,92 @@ func (rs *RestSrv) setRouters() map[string]storage.ParticipantRole {
 	// TODO
 	rs.Get("/validator_info/{web3_address}", rs.HandleValidatorInfo)
 	rs.Post("/validator_info/upload_docs", rs.HandleUploadValidatorDocs)
+	rs.Get("/rewards_history", rs.HandleRewardsHistory)
+	rs.Get("/review_notifications", rs.HandleReviewNotifications)
+	rs.Post("/read_review_notification/{notification_id}", rs.HandleReadReviewNotification)
+
+	// Validator applications
+	rs.Get("/my_validator_application", rs.HandleMyValidatorApplication)
+	rs.Get("/validator_applications", rs.HandleValidatorApplications)
+	rs.Get("/validator_applications/{web3_address}", rs.HandleValidatorApplication)
+	rs.Get("/validator_applications/{web3_address}/diplomas/{diploma_id}/{side}", rs.HandleDiplomaImage)
+	rs.Post("/verify_validator/{web3_address}", rs.HandleStartValidatorVerification)
+	rs.Post("/decide_validator/{web3_address}", rs.HandleDecideValidatorApplication)
 
 	// Validator work review
 	rs.Get("/work_review/{work_id}", rs.HandleGetWorkReviewByWorkID)
 	rs.Get("/work_reviews/{work_id}", rs.HandleGetWorkReviews)
 	rs.Post("/update_review", rs.HandleEvaluateWork)
 	rs.Post("/submit_work_review/{work_id}/{status}", rs.HandleSubmitWorkReview)
+	rs.Get("/work_score/{work_id}", rs.HandleWorkScore)
+	rs.Post("/rate_review/{review_id}/{rating}", rs.HandleRateWorkReview)
+	rs.Get("/work_review_history/{review_id}", rs.HandleWorkReviewHistory)
+	rs.Get("/work_review_diff/{review_id}", rs.HandleWorkReviewDiff)
+
+	// Review questionnaires
+	rs.Get("/questionnaire_templates", rs.HandleQuestionnaireTemplates)
+	rs.Get("/questionnaire_templates/{template_id}", rs.HandleQuestionnaireTemplate)
+	rs.Post("/questionnaire_template", rs.HandleSaveQuestionnaireTemplate)
 
 	// DOCs
 	rs.Put("/upload_doc/{doc_type}", rs.HandlerUploadDoc)
+	rs.Get("/blobs/{blob_id}", rs.HandleGetBlob)
+
+	// Drafts recognized from the uploaded papers
+	rs.Get("/extractions/{extraction_id}", rs.HandleGetExtraction)
+	rs.Post("/update_draft/{work_id}", rs.HandleUpdateDraft)
+	rs.Post("/submit_draft/{work_id}", rs.HandleSubmitDraft)
 
 	// Works
 	rs.Get("/works", rs.HandleAllWorks)
 	// TODO
 	rs.Get("/works/{work_id}", rs.HandleWorkByID)
 	rs.Get("/works/author/{web3_address}", rs.HandleAuthorWorks)
+	rs.Get("/works/{work_id}/verify", rs.HandleVerifyWork)
+	rs.Get("/works/{work_id}/render", rs.HandleRenderWork)
+	rs.Get("/works/{work_id}/pdf", rs.HandleWorkPDF)
+	rs.Get("/works/{work_id}/jats", rs.HandleWorkJATS)
+	rs.Get("/works/{work_id}/jsonld", rs.HandleWorkJSONLD)
+	rs.Get("/works/{work_id}/cite", rs.HandleCiteWork)
+	rs.Get("/id/{prefix}/{suffix}", rs.HandleResolvePID)
+	rs.Get("/deposits/xml", rs.HandleDepositXML)
+	rs.Get("/deposits", rs.HandleWorkDeposits)
+	rs.Post("/deposits", rs.HandleDepositWorks)
+	rs.Get("/works/{work_id}/references", rs.HandleWorkReferences)
+	rs.Get("/works/{work_id}/cited_by", rs.HandleCitedBy)
+	rs.Get("/works/author/{web3_address}/metrics", rs.HandleAuthorMetrics)
+	rs.Get("/works/author/{web3_address}/feed.{format}", rs.HandleAuthorWorksFeed)
+	rs.Post("/work_references/{work_id}", rs.HandleSetWorkReferences)
+	rs.Get("/work_references/{work_id}/proposed", rs.HandleProposedReferences)
+	rs.Post("/work_key/{work_id}", rs.HandleWorkKey)
 
 	rs.Get("/works_by_key_words/{key_words}", rs.HandleWorkByKeyWords)
 
+	// OAI-PMH harvesting of the open works
+	rs.Get("/oai", rs.HandleOAI)
+	rs.Post("/oai", rs.HandleOAI)
+
+	// Feeds of the newly opened works
+	rs.Get("/feeds/works.{format}", rs.HandleWorksFeed)
+	rs.Get("/sitemap.xml", rs.HandleSitemap)
+
 	rs.Get("/purchase_work/{work_id}", rs.HandlePurchaseWork)
 	rs.Get("/purchased_works", rs.HandlePurchasedWorks)
+	rs.Get("/purchased_works/cite", rs.HandleCitePurchasedWorks)
 
 	// Bookmarks
 	rs.Post("/add_bookmark/{work_id}", rs.HandleAddInBookmarks)
 	rs.Post("/remove_bookmark/{work_id}", rs.HandleRemoveFromBookmarks)
 	rs.Get("/bookmarks", rs.HandleGetBookmarks)
+	rs.Get("/bookmarks/cite", rs.HandleCiteBookmarks)
 
 	rs.Get("/work_data", rs.HandlePublishWorkData)
 	rs.Post("/publish_work", rs.HandlePublishWork)
 
+	// NFT
+	rs.Post("/link_nft/{work_id}", rs.HandleLinkWorkNFT)
+	rs.Get("/nft/{token_id}", rs.HandleNFTMetadata)
+
 	// faucet
 	rs.Get("/faucet/{web3_address}", rs.HandleFaucet)
 
@@ -147,6 +206,7 @@ func (rs *RestSrv) setRouters() map[string]storage.ParticipantRole {
 	rs.Get("/pending_works", rs.HandlePendingWorks)
 	rs.Post("/approve_work/{work_id}", rs.HandleApproveWork)
 	rs.Post("/remove_work/{work_id}", rs.HandleRemoveWork)
+	rs.Post("/trace_watermark", rs.HandleTraceWatermark)
 
 	rs.router.PathPrefix("/swagger/").Handler(httpSwagger.Handler(
 		// httpSwagger.URL("http://0.0.0.0:8005/swagger/doc.json"), //The url pointing to API definition
@@ -166,10 +226,34 @@ func (rs *RestSrv) setRouters() map[string]storage.ParticipantRole {
 
 		"update_author_info": storage.AuthorRole,
 
-		"become_validator":   storage.ReaderRole,
-		"update_review":      storage.ValidatorRole,
-		"work_review":        storage.ValidatorRole,
-		"submit_work_review": storage.ValidatorRole,
+		"become_validator": storage.ReaderRole,
+
+		"my_validator_application": storage.ReaderRole,
+		"validator_applications":   storage.AdminRole,
+		"verify_validator":         storage.AdminRole,
+		"decide_validator":         storage.AdminRole,
+
+		"update_review":            storage.ValidatorRole,
+		"work_review":              storage.ValidatorRole,
+		"submit_work_review":       storage.ValidatorRole,
+		"work_score":               storage.AuthorRole,
+		"rate_review":              storage.AuthorRole,
+		"rewards_history":          storage.ValidatorRole,
+		"review_notifications":     storage.ValidatorRole,
+		"read_review_notification": storage.ValidatorRole,
+		"work_review_history":      storage.AuthorRole,
+		"work_review_diff":         storage.AdminRole,
+
+		"questionnaire_template": storage.AdminRole,
+
+		// Docs
+		"upload_doc": storage.ReaderRole,
+		"blobs":      storage.ReaderRole,
+
+		// Drafts
+		"extractions":  storage.ReaderRole,
+		"update_draft": storage.AuthorRole,
+		"submit_draft": storage.AuthorRole,
 
 		// Works
 		"publish_work":  storage.AuthorRole,
@@ -177,9 +261,18 @@ func (rs *RestSrv) setRouters() map[string]storage.ParticipantRole {
 		"approve_work":  storage.AdminRole,
 		"remove_work":   storage.AdminRole,
 
+		"trace_watermark": storage.AdminRole,
+		"deposits":        storage.AdminRole,
+
+		"work_key":        storage.ReaderRole,
 		"purchase_work":   storage.ReaderRole,
 		"purchased_works": storage.ReaderRole,
 
+		"work_references": storage.AuthorRole,
+
+		// NFT
+		"link_nft": storage.AuthorRole,
+
 		// Bookmarks
 		"add_bookmark":    storage.ReaderRole,
 		"remove_bookmark": storage.ReaderRole,
@@ -225,6 +318,18 @@ func responError(w http.ResponseWriter, code int, message string) {
 	responJSON(w, code, ErrorMsg{Msg: message})
 }
 
+func responFile(w http.ResponseWriter, name, contentType string, size int64, data io.Reader) {
+	w.Header().Set("Content-Type", contentType)
+	if size > 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+	}
+	if name != "" {
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": name}))
+	}
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, data)
+}
+
 func (rs *RestSrv) getRequest(reader io.Reader, request interface{}) error {
 	reqBody, err := ioutil.ReadAll(reader)
 	if err != nil {
@@ -0,0 +1,230 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	srv "github.com/SeaOfWisdom/sow_library/src/service"
+	"github.com/SeaOfWisdom/sow_library/src/service/blobstore"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+
+	"github.com/gorilla/mux"
+)
+
+func responApplicationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, srv.ErrNoValidatorApplication),
+		errors.Is(err, storage.ErrParticipantNotExists),
+		errors.Is(err, storage.ErrDiplomaNotExists),
+		errors.Is(err, storage.ErrBlobNotExists),
+		errors.Is(err, blobstore.ErrNotFound):
+		responError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, srv.ErrWrongApplicationStatus):
+		responError(w, http.StatusConflict, err.Error())
+	default:
+		responError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// HandleMyValidatorApplication MyValidatorApplication godoc
+// @Summary      My validator application
+// @Description  Get the status of the participant's validator application with the uploaded diplomas
+// @Tags         Validator applications
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}   storage.ValidatorApplication
+// @Failure      400  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /my_validator_application [get]
+func (rs *RestSrv) HandleMyValidatorApplication(w http.ResponseWriter, r *http.Request) {
+	web3Address, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	application, err := rs.libSrv.GetValidatorApplication(r.Context(), web3Address)
+	if err != nil {
+		responApplicationError(w, err)
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, application)
+}
+
+// HandleValidatorApplications ValidatorApplications godoc
+// @Summary      Validator applications
+// @Description  Get the validator applications with the status, the ones waiting for the verification by default(admin only)
+// @Tags         Validator applications
+// @Accept       json
+// @Produce      json
+// @Param        status   query      string  false  "application status" Enums(VALIDATOR_APPLIED, VALIDATOR_DOCUMENTS_SUBMITTED, VALIDATOR_UNDER_VERIFICATION, VALIDATOR_APPROVED, VALIDATOR_REJECTED)
+// @Success      200  {object}   []storage.ValidatorApplication
+// @Failure      400  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /validator_applications [get]
+func (rs *RestSrv) HandleValidatorApplications(w http.ResponseWriter, r *http.Request) {
+	status := storage.ValidatorApplicationStatus(r.URL.Query().Get("status"))
+
+	applications, err := rs.libSrv.GetValidatorApplications(r.Context(), status)
+	if err != nil {
+		responError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, applications)
+}
+
+// HandleValidatorApplication ValidatorApplication godoc
+// @Summary      Validator application
+// @Description  Get the validator application with the OCR fields of the uploaded diplomas(admin only)
+// @Tags         Validator applications
+// @Accept       json
+// @Produce      json
+// @Param        web3_address   path      string  true  "applicant web3 address"
+// @Success      200  {object}   storage.ValidatorApplication
+// @Failure      400  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /validator_applications/{web3_address} [get]
+func (rs *RestSrv) HandleValidatorApplication(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	web3Address, ok := vars["web3_address"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	application, err := rs.libSrv.GetValidatorApplication(r.Context(), web3Address)
+	if err != nil {
+		responApplicationError(w, err)
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, application)
+}
+
+// HandleDiplomaImage DiplomaImage godoc
+// @Summary      Diploma scan
+// @Description  Get the uploaded scan of the applicant's diploma(admin only)
+// @Tags         Validator applications
+// @Produce      octet-stream
+// @Param        web3_address   path      string  true  "applicant web3 address"
+// @Param        diploma_id   path      string  true  "diploma id"
+// @Param        side   path      string  true  "diploma side" Enums(forward, backward)
+// @Success      200  {file}   file
+// @Failure      400  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /validator_applications/{web3_address}/diplomas/{diploma_id}/{side} [get]
+func (rs *RestSrv) HandleDiplomaImage(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	web3Address, diplomaID, side := vars["web3_address"], vars["diploma_id"], vars["side"]
+	if side != srv.ForwardDiplomaSide && side != srv.BackwardDiplomaSide {
+		responError(w, http.StatusBadRequest, "wrong diploma side")
+
+		return
+	}
+
+	image, data, err := rs.libSrv.GetDiplomaImage(r.Context(), web3Address, diplomaID, side)
+	if err != nil {
+		responApplicationError(w, err)
+
+		return
+	}
+	defer data.Close()
+
+	responFile(w, image.Name, image.ContentType, image.Size, data)
+}
+
+// HandleStartValidatorVerification StartValidatorVerification godoc
+// @Summary      Start validator verification
+// @Description  Take the application with the submitted documents for the verification(admin only)
+// @Tags         Validator applications
+// @Accept       json
+// @Produce      json
+// @Param        web3_address   path      string  true  "applicant web3 address"
+// @Success      200  {object}   storage.Validator
+// @Failure      400  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Failure      409  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /verify_validator/{web3_address} [post]
+func (rs *RestSrv) HandleStartValidatorVerification(w http.ResponseWriter, r *http.Request) {
+	adminAddress, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	vars := mux.Vars(r)
+	web3Address, ok := vars["web3_address"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	validator, err := rs.libSrv.StartValidatorVerification(r.Context(), adminAddress, web3Address)
+	if err != nil {
+		responApplicationError(w, err)
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, validator)
+}
+
+// HandleDecideValidatorApplication DecideValidatorApplication godoc
+// @Summary      Decide validator application
+// @Description  Approve the application under the verification and grant the validator role, or reject it with the reason(admin only)
+// @Tags         Validator applications
+// @Accept       json
+// @Produce      json
+// @Param        web3_address   path      string  true  "applicant web3 address"
+// @Param        decision body DecideValidatorApplicationRequest true "decision"
+// @Success      200  {object}   storage.Validator
+// @Failure      400  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Failure      409  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /decide_validator/{web3_address} [post]
+func (rs *RestSrv) HandleDecideValidatorApplication(w http.ResponseWriter, r *http.Request) {
+	adminAddress, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	vars := mux.Vars(r)
+	web3Address, ok := vars["web3_address"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	request := new(DecideValidatorApplicationRequest)
+	if err := rs.getRequest(r.Body, request); err != nil {
+		responError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	validator, err := rs.libSrv.DecideValidatorApplication(r.Context(), adminAddress, web3Address, request.Approved, request.Reason)
+	if err != nil {
+		responApplicationError(w, err)
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, validator)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"strconv"
 
 	srv "github.com/SeaOfWisdom/sow_library/src/service"
 	"github.com/SeaOfWisdom/sow_library/src/service/storage"
@@ -20,7 +21,7 @@ import (
 
 // HandleBecomeValidator BecomeValidator godoc
 // @Summary      Become a validator
-// @Description  Become a validator
+// @Description  Apply for the validator role, it is granted after the verification of the uploaded documents
 // @Tags         Validators
 // @Accept       json
 // @Produce      json
@@ -46,9 +47,15 @@ func (rs *RestSrv) HandleBecomeValidator(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// request author
+	// request the validator application
 	participant, err := rs.libSrv.BecomeValidator(ctx, web3Address, request.EmailAddress, request.Name, request.Surname)
 	if err != nil {
+		if errors.Is(err, srv.ErrValidatorApplicationExists) {
+			responError(w, http.StatusConflict, err.Error())
+
+			return
+		}
+
 		responError(w, http.StatusInternalServerError, err.Error())
 
 		return
@@ -62,7 +69,8 @@ func (rs *RestSrv) HandleBecomeValidator(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	responJSON(w, http.StatusOK, AuthResp{Token: jwt.Token, Role: storage.ValidatorRole})
+	// the role is granted after the verification of the documents
+	responJSON(w, http.StatusOK, AuthResp{Token: jwt.Token, Role: participant.Role})
 }
 
 // HandleValidatorInfo ValidatorInfo godoc
@@ -155,18 +163,28 @@ func (rs *RestSrv) HandleUpdateValidator(w http.ResponseWriter, r *http.Request)
 
 // HandleUploadValidatorDocs UploadValidatorDocs godoc
 // @Summary      Upload validator documents
-// @Description  Uploading documents confirming competencies of validator
+// @Description  Uploading documents confirming competencies of validator, the recognized diploma is saved for the verification
 // @Tags         Validators
-// @Accept       json
+// @Accept       mpfd
 // @Produce      json
-// @Success      200  {object}  SuccessMsg
+// @Param        forward_doc   formData      file  true  "forward side of the diploma"
+// @Param        backward_doc   formData      file  true  "backward side of the diploma"
+// @Success      200  {object}  storage.Diploma
 // @Failure      400  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
 // @Security Bearer
 // @Router       /validator_info/upload_docs [post]
 func (rs *RestSrv) HandleUploadValidatorDocs(w http.ResponseWriter, r *http.Request) {
+	web3Address, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
 	// Parse our multipart form, 10 << 20 specifies a maximum
 	// upload of 20 MB files.
-	err := r.ParseMultipartForm(10 << 20)
+	err = r.ParseMultipartForm(10 << 20)
 	if err != nil {
 		responError(w, http.StatusBadRequest, err.Error())
 
@@ -197,60 +215,65 @@ func (rs *RestSrv) HandleUploadValidatorDocs(w http.ResponseWriter, r *http.Requ
 
 	// read all of the contents of our uploaded file into a
 	// byte array
-
-	/// !!!! TODO !!!!
-
 	fImageBytes, err := io.ReadAll(forwardFile)
 	if err != nil {
 		rs.logger.Errorf("HandleUploadValidatorDocs: while read forward file to bytes, err: %v", err)
-		responJSON(w, http.StatusOK, SuccessMsg{Msg: "OK"})
+		responError(w, http.StatusBadRequest, err.Error())
 
 		return
 	}
 
 	bImageBytes, err := io.ReadAll(backwardFile)
 	if err != nil {
-		rs.logger.Error("HandleUploadValidatorDocs: while read backward file to bytes, err: %v", err)
-		responJSON(w, http.StatusOK, SuccessMsg{Msg: "OK"})
+		rs.logger.Errorf("HandleUploadValidatorDocs: while read backward file to bytes, err: %v", err)
+		responError(w, http.StatusBadRequest, err.Error())
 
 		return
 	}
 
+	diploma := &storage.Diploma{
+		ForwardImage:  &storage.DocumentImage{Name: fHandler.Filename, Data: fImageBytes},
+		BackwardImage: &storage.DocumentImage{Name: bHandler.Filename, Data: bImageBytes},
+	}
+
+	// the scans are saved even if the text isn't recognized, an admin verifies them anyway
 	resp, err := rs.ocrSrv.ExtractValidatorText(r.Context(), &ocr.ExtractValidatorRequest{ForwardImage: fImageBytes, BackwardImage: bImageBytes})
 	if err != nil {
 		rs.logger.Errorf("HandleUploadValidatorDocs: while extract text via ocr service, err: %v", err)
-		responJSON(w, http.StatusOK, SuccessMsg{Msg: "OK"})
-
-		return
-	}
-
-	out := &ValidatorDocReview{
-		ValidatorForwardDoc: &ValidatorForwardDoc{
-			Number:  resp.ForwardInfo.Number,
-			Science: resp.ForwardInfo.Sciences,
-			Date:    resp.ForwardInfo.Date.AsTime(),
-		},
-		ValidatorBackwardDoc: &ValidatorBackwardDoc{
-			DiplomaNumber:       resp.BackwardInfo.Number,
-			DiplomaSerialNumber: resp.BackwardInfo.SerialNumber,
-			Date:                resp.BackwardInfo.Date.AsTime(),
-		},
+		diploma.OCRError = err.Error()
+	} else {
+		if info := resp.ForwardInfo; info != nil {
+			diploma.Number = info.Number
+			if info.Date != nil {
+				diploma.Date = info.Date.AsTime()
+			}
+			if info.Sciences != "" {
+				diploma.Topics = []string{info.Sciences}
+			}
+		}
+		if info := resp.BackwardInfo; info != nil {
+			diploma.DiplomaNumber = info.Number
+			diploma.DiplomaSerialNumber = info.SerialNumber
+			if info.Date != nil {
+				diploma.DiplomaDate = info.Date.AsTime()
+			}
+		}
 	}
 
-	// TODO
-	// Save documents to binary storage
+	out, err := rs.libSrv.SubmitValidatorDiploma(r.Context(), web3Address, diploma)
+	if err != nil {
+		switch {
+		case errors.Is(err, srv.ErrNoValidatorApplication), errors.Is(err, storage.ErrParticipantNotExists):
+			responError(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, srv.ErrWrongApplicationStatus):
+			responError(w, http.StatusConflict, err.Error())
+		default:
+			responBlobError(w, err)
+		}
 
-	// // Create a temporary file within our temp-images directory that follows
-	// // a particular naming pattern
-	// tempFile, err := ioutil.TempFile("temp-images", "upload-*.png")
-	// if err != nil {
-	// 	fmt.Println(err)
-	// }
-	// defer tempFile.Close()
+		return
+	}
 
-	// // write this byte array to our temporary file
-	// tempFile.Write(fileBytes)
-	// return that we have successfully uploaded our file!
 	responJSON(w, http.StatusOK, out)
 }
 
@@ -338,6 +361,12 @@ func (rs *RestSrv) HandleEvaluateWork(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if errors.Is(err, srv.ErrInvalidQuestionnaire) {
+			responError(w, http.StatusBadRequest, err.Error())
+
+			return
+		}
+
 		responError(w, http.StatusInternalServerError, err.Error())
 
 		return
@@ -386,6 +415,12 @@ func (rs *RestSrv) HandleSubmitWorkReview(w http.ResponseWriter, r *http.Request
 			return
 		}
 
+		if errors.Is(err, srv.ErrInvalidQuestionnaire) {
+			responError(w, http.StatusBadRequest, err.Error())
+
+			return
+		}
+
 		responError(w, http.StatusInternalServerError, err.Error())
 
 		return
@@ -393,3 +428,255 @@ func (rs *RestSrv) HandleSubmitWorkReview(w http.ResponseWriter, r *http.Request
 
 	responJSON(w, http.StatusOK, SuccessMsg{Msg: "OK"})
 }
+
+// HandleRateWorkReview RateWorkReview godoc
+// @Summary      Rate review
+// @Description  Rate the helpfulness of the finished review by the work's author or an admin
+// @Tags         Work review
+// @Accept       json
+// @Produce      json
+// @Param        review_id   path      string  true  "review id"
+// @Param        rating   path      int  true "review rating" minimum(1) maximum(5)
+// @Success      200  {object}   SuccessMsg
+// @Failure      400  {object}  ErrorMsg
+// @Failure      403  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /rate_review/{review_id}/{rating} [post]
+func (rs *RestSrv) HandleRateWorkReview(w http.ResponseWriter, r *http.Request) {
+	web3Address, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	vars := mux.Vars(r)
+	reviewID, ok := vars["review_id"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null review_id path param")
+
+		return
+	}
+
+	rating, err := strconv.ParseInt(vars["rating"], 10, 64)
+	if err != nil {
+		responError(w, http.StatusBadRequest, "wrong review rating")
+
+		return
+	}
+
+	if err := rs.libSrv.RateWorkReview(r.Context(), web3Address, reviewID, rating); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrReviewNotExists):
+			responError(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, srv.ErrRatingNotAllowed):
+			responError(w, http.StatusForbidden, err.Error())
+		case errors.Is(err, srv.ErrInvalidRating), errors.Is(err, srv.ErrReviewNotFinished):
+			responError(w, http.StatusBadRequest, err.Error())
+		default:
+			responError(w, http.StatusInternalServerError, err.Error())
+		}
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, SuccessMsg{Msg: "OK"})
+}
+
+// HandleRewardsHistory RewardsHistory godoc
+// @Summary      Rewards history
+// @Description  Get the validator's review rewards with the paid and pending totals(in wei)
+// @Tags         Validators
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}   storage.RewardsHistory
+// @Failure      400  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /rewards_history [get]
+func (rs *RestSrv) HandleRewardsHistory(w http.ResponseWriter, r *http.Request) {
+	web3Address, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	history, err := rs.libSrv.GetRewardsHistory(web3Address)
+	if err != nil {
+		responError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, history)
+}
+
+// HandleReviewNotifications ReviewNotifications godoc
+// @Summary      Review notifications
+// @Description  Get the validator's reminders of the review deadlines, the overdue and the reassigned reviews, the latest first
+// @Tags         Validators
+// @Accept       json
+// @Produce      json
+// @Param        unread  query  bool  false  "only the unread ones"
+// @Success      200  {array}   storage.ReviewNotification
+// @Failure      401  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /review_notifications [get]
+func (rs *RestSrv) HandleReviewNotifications(w http.ResponseWriter, r *http.Request) {
+	web3Address, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	notifications, err := rs.libSrv.GetReviewNotifications(web3Address, r.URL.Query().Get("unread") == "true")
+	if err != nil {
+		responError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, notifications)
+}
+
+// HandleReadReviewNotification ReadReviewNotification godoc
+// @Summary      Read review notification
+// @Description  Mark the validator's notification read
+// @Tags         Validators
+// @Accept       json
+// @Produce      json
+// @Param        notification_id  path  string  true  "notification id"
+// @Success      200  {object}  SuccessMsg
+// @Failure      401  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /read_review_notification/{notification_id} [post]
+func (rs *RestSrv) HandleReadReviewNotification(w http.ResponseWriter, r *http.Request) {
+	web3Address, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	notificationID, ok := mux.Vars(r)["notification_id"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null request param")
+
+		return
+	}
+
+	if err := rs.libSrv.ReadReviewNotification(web3Address, notificationID); err != nil {
+		if errors.Is(err, storage.ErrNotificationNotExists) {
+			responError(w, http.StatusNotFound, err.Error())
+
+			return
+		}
+		responError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, SuccessMsg{Msg: "OK"})
+}
+
+// HandleWorkReviewHistory WorkReviewHistory godoc
+// @Summary      Review history
+// @Description  Get all versions of the review and the version the work was decided with(the work's author, the validator or an admin)
+// @Tags         Work review
+// @Accept       json
+// @Produce      json
+// @Param        review_id   path      string  true  "review id"
+// @Success      200  {object}   storage.WorkReviewHistory
+// @Failure      400  {object}  ErrorMsg
+// @Failure      403  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /work_review_history/{review_id} [get]
+func (rs *RestSrv) HandleWorkReviewHistory(w http.ResponseWriter, r *http.Request) {
+	web3Address, err := rs.getWeb3Address(r)
+	if err != nil {
+		responError(w, http.StatusUnauthorized, err.Error())
+
+		return
+	}
+
+	vars := mux.Vars(r)
+	reviewID, ok := vars["review_id"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null review_id path param")
+
+		return
+	}
+
+	history, err := rs.libSrv.GetWorkReviewHistory(r.Context(), web3Address, reviewID)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrReviewNotExists), errors.Is(err, storage.ErrWorkNotExists):
+			responError(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, srv.ErrReviewHistoryNotAllowed):
+			responError(w, http.StatusForbidden, err.Error())
+		default:
+			responError(w, http.StatusInternalServerError, err.Error())
+		}
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, history)
+}
+
+// HandleWorkReviewDiff WorkReviewDiff godoc
+// @Summary      Review versions diff
+// @Description  Compare two versions of the review(admin only)
+// @Tags         Work review
+// @Accept       json
+// @Produce      json
+// @Param        review_id   path      string  true  "review id"
+// @Param        from   query      int  true  "version to compare from"
+// @Param        to   query      int  true  "version to compare to"
+// @Success      200  {object}   storage.WorkReviewDiff
+// @Failure      400  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /work_review_diff/{review_id} [get]
+func (rs *RestSrv) HandleWorkReviewDiff(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	reviewID, ok := vars["review_id"]
+	if !ok {
+		responError(w, http.StatusBadRequest, "null review_id path param")
+
+		return
+	}
+
+	from, err := strconv.ParseInt(r.URL.Query().Get("from"), 10, 64)
+	if err != nil {
+		responError(w, http.StatusBadRequest, "wrong from version")
+
+		return
+	}
+
+	to, err := strconv.ParseInt(r.URL.Query().Get("to"), 10, 64)
+	if err != nil {
+		responError(w, http.StatusBadRequest, "wrong to version")
+
+		return
+	}
+
+	diff, err := rs.libSrv.DiffWorkReviewVersions(r.Context(), reviewID, from, to)
+	if err != nil {
+		if errors.Is(err, storage.ErrReviewVersionNotExists) {
+			responError(w, http.StatusNotFound, err.Error())
+
+			return
+		}
+
+		responError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, diff)
+}
@@ -2,7 +2,6 @@ package rest
 
 import (
 	"fmt"
-	"time"
 
 	"github.com/SeaOfWisdom/sow_library/src/service/storage"
 )
@@ -63,10 +62,9 @@ func (r *WorkReviewRequest) Validate() error {
 	// 	return fmt.Errorf("wrong validator id: %s", r.Review.ValidatorID)
 	// }
 
-	fmt.Println("REVIEW BODY: ", r.Review.Body.Questionnaire, r.Review.Body.Review)
-	// if r.Review.Body == nil {
-	// 	return fmt.Errorf("review body is null: %v", r.Review.Body)
-	// }
+	if r.Review.Body == nil {
+		return fmt.Errorf("review body is null: %v", r.Review.Body)
+	}
 
 	if r.Review.Body.Questionnaire == nil && r.Review.Body.Review == "" {
 		return fmt.Errorf("review body is null: %v", r.Review.Body)
@@ -74,21 +72,14 @@ func (r *WorkReviewRequest) Validate() error {
 	return nil
 }
 
-type (
-	ValidatorDocReview struct {
-		*ValidatorForwardDoc
-		*ValidatorBackwardDoc
-	}
-
-	ValidatorForwardDoc struct {
-		Number  uint64    `json:"number"`
-		Date    time.Time `json:"date"`
-		Science string    `json:"science"`
-	}
+type DecideValidatorApplicationRequest struct {
+	Approved bool   `json:"approved"`
+	Reason   string `json:"reason"` // mandatory for the rejection
+}
 
-	ValidatorBackwardDoc struct {
-		DiplomaNumber       string    `json:"diploma_number"`
-		DiplomaSerialNumber string    `json:"diploma_serial_number"`
-		Date                time.Time `json:"diploma_date"`
+func (r *DecideValidatorApplicationRequest) Validate() error {
+	if !r.Approved && r.Reason == "" {
+		return fmt.Errorf("the rejection reason is null")
 	}
-)
+	return nil
+}
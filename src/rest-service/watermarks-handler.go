@@ -0,0 +1,58 @@
+package rest
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/watermark"
+)
+
+// the leaked copy is either the text or the PDF of the work
+const maxLeakedCopySize = 50 << 20
+
+// HandleTraceWatermark TraceWatermark godoc
+// @Summary      Trace leaked copy
+// @Description  Extract the reader watermarks from the leaked copy of the work and identify the accounts
+// @Description  it was served to. The body is the text of the work or the PDF(Content-Type: application/pdf).
+// @Tags         Admin
+// @Accept       plain
+// @Accept       application/pdf
+// @Produce      json
+// @Param        Copy body string true "leaked copy"
+// @Success      200  {object}  []srv.WatermarkTrace
+// @Failure      400  {object}  ErrorMsg
+// @Failure      404  {object}  ErrorMsg
+// @Failure      413  {object}  ErrorMsg
+// @Security Bearer
+// @Router       /trace_watermark [post]
+func (rs *RestSrv) HandleTraceWatermark(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxLeakedCopySize+1))
+	if err != nil {
+		responError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	if len(data) > maxLeakedCopySize {
+		responError(w, http.StatusRequestEntityTooLarge, "the copy is too large")
+
+		return
+	}
+
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	traces, err := rs.libSrv.TraceWatermark(r.Context(), contentType, data)
+	if err != nil {
+		if errors.Is(err, watermark.ErrNoWatermark) {
+			responError(w, http.StatusNotFound, err.Error())
+		} else {
+			responError(w, http.StatusInternalServerError, err.Error())
+		}
+
+		return
+	}
+
+	responJSON(w, http.StatusOK, traces)
+}
@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"strings"
 
+	srv "github.com/SeaOfWisdom/sow_library/src/service"
+
 	"github.com/gorilla/mux"
 )
 
@@ -156,7 +158,10 @@ func (rs *RestSrv) HandlePublishWorkData(w http.ResponseWriter, r *http.Request)
 
 // HandlePublishWork PublishWork godoc
 // @Summary      Publish a new work
-// @Description  Publish a new work
+// @Description  Publish a new work. The license is CC-BY-4.0, CC-BY-SA-4.0, CC-BY-NC-4.0, all-rights-reserved(the default one)
+// @Description  or custom with the text of its terms. The work under CC-BY-4.0 or CC-BY-SA-4.0 must be open access,
+// @Description  i.e. free to read once it's open, unless the platform allows to sell such works.
+// @Description  The science picks the questionnaire of the reviews, it's taken from the author's profile if it's null.
 // @Tags         Publish work
 // @Accept       json
 // @Produce      json
@@ -184,7 +189,12 @@ func (rs *RestSrv) HandlePublishWork(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// TODO
-	workResp, _, err := rs.libSrv.PublishWork(r.Context(), web3Address, request.Work)
+	workResp, err := rs.libSrv.PublishWork(r.Context(), web3Address, request.Work)
+	if errors.Is(err, srv.ErrWrongLicense) || errors.Is(err, srv.ErrLicensePaywalled) {
+		responError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
 	if err != nil {
 		responError(w, http.StatusInternalServerError, err.Error())
 
@@ -424,7 +434,7 @@ func (rs *RestSrv) HandleRemoveFromBookmarks(w http.ResponseWriter, r *http.Requ
 
 		return
 	}
-	rs.logger.Info("HandleRemoveFromBookmarks: request work id: %s", workId)
+	rs.logger.Infof("HandleRemoveFromBookmarks: request work id: %s", workId)
 
 	if err := rs.libSrv.RemoveBookmark(web3Address, workId); err != nil {
 		rs.logger.Errorf("HandleRemoveFromBookmarks: %v", err)
@@ -3,6 +3,7 @@ package rest
 import (
 	"fmt"
 
+	"github.com/SeaOfWisdom/sow_library/src/service/render"
 	"github.com/SeaOfWisdom/sow_library/src/service/storage"
 )
 
@@ -26,6 +27,15 @@ func (r *WorkReq) Validate() error {
 	if r.Work.Content.WorkData == "" { // TODO
 		return fmt.Errorf("work content data is null")
 	}
+	if !render.ValidFormat(r.Work.Content.Format) {
+		return fmt.Errorf("wrong work content format: %s", r.Work.Content.Format)
+	}
+	if !storage.ValidReviewMode(r.Work.ReviewMode) {
+		return fmt.Errorf("wrong review mode: %s", r.Work.ReviewMode)
+	}
+	if !storage.ValidLicense(r.Work.License) {
+		return fmt.Errorf("wrong license: %s", r.Work.License.ID)
+	}
 	return nil
 }
 
@@ -36,3 +46,84 @@ type WorkResp struct {
 type PublishWorkDataResp struct {
 	Tags []string `json:"tags" eaxmple:"[подводный спорт, моноласт]"`
 }
+
+// DraftReq is the author's corrections of the recognized draft, the draft
+// may be incomplete until it's submitted
+type DraftReq struct {
+	Work *storage.Work `json:"work"`
+}
+
+func (r *DraftReq) Validate() error {
+	if r.Work == nil {
+		return fmt.Errorf("work is null")
+	}
+	if r.Work.Content != nil && !render.ValidFormat(r.Work.Content.Format) {
+		return fmt.Errorf("wrong work content format: %s", r.Work.Content.Format)
+	}
+	if !storage.ValidReviewMode(r.Work.ReviewMode) {
+		return fmt.Errorf("wrong review mode: %s", r.Work.ReviewMode)
+	}
+	if !storage.ValidLicense(r.Work.License) {
+		return fmt.Errorf("wrong license: %s", r.Work.License.ID)
+	}
+	return nil
+}
+
+// WorkKeyReq is the public key of the reader's wallet to encrypt the content key to
+type WorkKeyReq struct {
+	PublicKey string `json:"public_key" example:"0x04..."` // hex encoded secp256k1 key
+}
+
+func (r *WorkKeyReq) Validate() error {
+	if r.PublicKey == "" {
+		return fmt.Errorf("public key is null")
+	}
+	return nil
+}
+
+// ReferenceReq is the entry of the reference list, it refers either to the work
+// of the library by its id or to the external work by its DOI, URL or the free text
+type ReferenceReq struct {
+	WorkID string `json:"work_id,omitempty"`
+	DOI    string `json:"doi,omitempty" example:"10.1000/xyz123"`
+	URL    string `json:"url,omitempty"`
+	Text   string `json:"text,omitempty"`
+}
+
+// WorkReferencesReq is the reference list replacing the current one
+type WorkReferencesReq struct {
+	References []*ReferenceReq `json:"references"`
+}
+
+func (r *WorkReferencesReq) Validate() error {
+	for i, reference := range r.References {
+		if reference == nil || reference.WorkID == "" && reference.DOI == "" && reference.URL == "" && reference.Text == "" {
+			return fmt.Errorf("reference %d is empty", i+1)
+		}
+	}
+	return nil
+}
+
+func (r *WorkReferencesReq) workReferences() []*storage.WorkReference {
+	references := make([]*storage.WorkReference, 0, len(r.References))
+	for _, reference := range r.References {
+		references = append(references, &storage.WorkReference{
+			CitedWorkID: reference.WorkID,
+			DOI:         reference.DOI,
+			URL:         reference.URL,
+			Text:        reference.Text,
+		})
+	}
+	return references
+}
+
+type DepositWorksRequest struct {
+	WorkIDs []string `json:"work_ids"`
+}
+
+func (r *DepositWorksRequest) Validate() error {
+	if len(r.WorkIDs) == 0 {
+		return fmt.Errorf("work ids are null")
+	}
+	return nil
+}
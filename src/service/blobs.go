@@ -0,0 +1,144 @@
+package srv
+
+import (
+	"context"
+	"io"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/blobstore"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+
+	"github.com/google/uuid"
+)
+
+// saveBlob checks the limits, stores the data by its SHA-256 key and records the owner of the blob
+func (ls *LibrarySrv) saveBlob(ctx context.Context, participantID string, kind storage.BlobKind, name string, data []byte, workID string) (*storage.BlobRecord, error) {
+	contentType, err := ls.blobLimits.Check(data)
+	if err != nil {
+		return nil, err
+	}
+
+	key := blobstore.Key(data)
+	// the same content may be already stored for the other records
+	stored, err := ls.blobs.Exists(ctx, key)
+	if err != nil {
+		ls.log.Errorf("saveBlob: error check blob %s, err: %v", key, err)
+
+		return nil, err
+	}
+
+	if err := ls.blobs.Put(ctx, key, data, contentType); err != nil {
+		ls.log.Errorf("saveBlob: error put blob %s, err: %v", key, err)
+
+		return nil, err
+	}
+
+	record := &storage.BlobRecord{
+		ID:            uuid.New().String(),
+		Key:           key,
+		Kind:          kind,
+		Name:          name,
+		ContentType:   contentType,
+		Size:          int64(len(data)),
+		ParticipantID: participantID,
+		WorkID:        workID,
+	}
+	if err := ls.storage.CreateBlobRecord(record); err != nil {
+		ls.log.Errorf("saveBlob: error create blob record, err: %v", err)
+		if !stored {
+			ls.deleteOrphanBlob(ctx, key)
+		}
+
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// deleteOrphanBlob deletes the just stored blob no record refers to
+func (ls *LibrarySrv) deleteOrphanBlob(ctx context.Context, key string) {
+	recorded, err := ls.storage.BlobKeyRecorded(key)
+	if err != nil {
+		ls.log.Errorf("deleteOrphanBlob: error check records of blob %s, err: %v", key, err)
+
+		return
+	}
+
+	if recorded {
+		return
+	}
+
+	if err := ls.blobs.Delete(ctx, key); err != nil {
+		ls.log.Errorf("deleteOrphanBlob: error delete blob %s, err: %v", key, err)
+	}
+}
+
+// UploadDocument stores the participant's document, the work's document can be uploaded by its author only
+func (ls *LibrarySrv) UploadDocument(ctx context.Context, participantAddress, workID, name string, data []byte) (*storage.BlobRecord, error) {
+	participant, err := ls.storage.GetParticipantByAddress(participantAddress)
+	if err != nil {
+		ls.log.Errorf("UploadDocument: error get participant with address %s, err: %v", participantAddress, err)
+
+		return nil, err
+	}
+
+	if workID != "" {
+		work, err := ls.storage.GetParticipantWorkByID(workID)
+		if err != nil {
+			return nil, err
+		}
+
+		if work.ParticipantID != participant.ID && participant.Role < storage.AdminRole {
+			return nil, ErrBlobAccessDenied
+		}
+	}
+
+	return ls.saveBlob(ctx, participant.ID, storage.WorkDocumentBlob, name, data, workID)
+}
+
+// GetBlob returns the document to its owner, an admin or, for the work's documents,
+// to the participants allowed to see the work's content.
+func (ls *LibrarySrv) GetBlob(ctx context.Context, participantAddress, blobID string) (*storage.BlobRecord, io.ReadCloser, error) {
+	participant, err := ls.storage.GetParticipantByAddress(participantAddress)
+	if err != nil {
+		ls.log.Errorf("GetBlob: error get participant with address %s, err: %v", participantAddress, err)
+
+		return nil, nil, err
+	}
+
+	record, err := ls.storage.GetBlobRecord(blobID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !ls.canReadBlob(participant, record) {
+		return nil, nil, ErrBlobAccessDenied
+	}
+
+	data, err := ls.blobs.Get(ctx, record.Key)
+	if err != nil {
+		ls.log.Errorf("GetBlob: error get blob %s, err: %v", record.Key, err)
+
+		return nil, nil, err
+	}
+
+	return ls.watermarkBlob(participant, record, data)
+}
+
+func (ls *LibrarySrv) canReadBlob(participant *storage.Participant, record *storage.BlobRecord) bool {
+	if participant.Role >= storage.AdminRole || participant.ID == record.ParticipantID {
+		return true
+	}
+
+	if record.Kind != storage.WorkDocumentBlob || record.WorkID == "" {
+		return false
+	}
+
+	work, err := ls.storage.GetParticipantWorkByID(record.WorkID)
+	if err != nil {
+		return false
+	}
+
+	_, content := work.IsShow(participant, ls.storage.PurchasedWorkOrNot(participant.ID, record.WorkID))
+
+	return content
+}
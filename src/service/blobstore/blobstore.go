@@ -0,0 +1,108 @@
+package blobstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/SeaOfWisdom/sow_library/src/config"
+)
+
+// types of the blob stores
+const (
+	LocalStoreType = "local"
+	S3StoreType    = "s3"
+)
+
+var (
+	ErrNotFound       = errors.New("blob does not exist")
+	ErrTooLarge       = errors.New("blob is too large")
+	ErrTypeNotAllowed = errors.New("blob type is not allowed")
+)
+
+// BlobStore keeps the uploaded binary documents by their content addressed keys
+type BlobStore interface {
+	// Put stores the data with the key, storing the same key again is a no-op
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Get returns the data of the key or ErrNotFound
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Exists(ctx context.Context, key string) (bool, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// NewBlobStore creates the blob store of the configured type
+func NewBlobStore(cfg *config.Config) (BlobStore, error) {
+	switch cfg.BlobStoreType {
+	case LocalStoreType:
+		return NewLocalStore(cfg.BlobStoreDir)
+	case S3StoreType:
+		return NewS3Store(cfg.S3Endpoint, cfg.S3Region, cfg.S3Bucket, cfg.S3AccessKey, cfg.S3SecretKey)
+	default:
+		return nil, fmt.Errorf("unknown blob store type %q", cfg.BlobStoreType)
+	}
+}
+
+// Key returns the hex encoded SHA-256 of the data
+func Key(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// Limits restricts the size and the MIME types of the blobs
+type Limits struct {
+	MaxSize      int64
+	AllowedTypes []string
+}
+
+// NewLimits parses the comma separated list of the allowed MIME types, the empty list allows any type
+func NewLimits(maxSize int64, allowedTypes string) *Limits {
+	limits := &Limits{MaxSize: maxSize}
+	for _, contentType := range strings.Split(allowedTypes, ",") {
+		if contentType = strings.TrimSpace(contentType); contentType != "" {
+			limits.AllowedTypes = append(limits.AllowedTypes, contentType)
+		}
+	}
+
+	return limits
+}
+
+// Check verifies the data and returns its sniffed MIME type
+func (l *Limits) Check(data []byte) (string, error) {
+	if l.MaxSize > 0 && int64(len(data)) > l.MaxSize {
+		return "", fmt.Errorf("%w, %d bytes of %d allowed", ErrTooLarge, len(data), l.MaxSize)
+	}
+
+	// the parameters like charset aren't the part of the type
+	contentType := http.DetectContentType(data)
+	mediaType := strings.TrimSpace(strings.Split(contentType, ";")[0])
+	if len(l.AllowedTypes) == 0 {
+		return contentType, nil
+	}
+
+	for _, allowed := range l.AllowedTypes {
+		if allowed == mediaType || (strings.HasSuffix(allowed, "/*") &&
+			strings.HasPrefix(mediaType, strings.TrimSuffix(allowed, "*"))) {
+			return contentType, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrTypeNotAllowed, mediaType)
+}
+
+func checkKey(key string) error {
+	if len(key) != sha256.Size*2 {
+		return fmt.Errorf("wrong blob key %q", key)
+	}
+
+	if _, err := hex.DecodeString(key); err != nil {
+		return fmt.Errorf("wrong blob key %q", key)
+	}
+
+	return nil
+}
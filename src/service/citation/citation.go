@@ -0,0 +1,160 @@
+package citation
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// export formats of the citations
+const (
+	BibTeXFormat  = "bibtex"
+	RISFormat     = "ris"
+	CSLJSONFormat = "csl"
+	APAFormat     = "apa"
+	GOSTFormat    = "gost"
+)
+
+// Publisher is the container of the works in the citations
+const Publisher = "Sea of Wisdom"
+
+var ErrUnknownFormat = errors.New("unknown citation format")
+
+// Person is the author of the work
+type Person struct {
+	Given  string
+	Middle string
+	Family string
+	// Literal is the name which can't be split, e.g. the nickname
+	Literal string
+}
+
+// Item is the cited work
+type Item struct {
+	ID       string
+	Title    string
+	Authors  []*Person
+	Issued   time.Time
+	Abstract string
+	Keywords []string
+	Language string
+	URL      string
+	DOI      string
+	// License is the URL of the license the work is available under or its name or terms if it has no URL
+	License string
+	// Accessed is the date the electronic resource was accessed, it's required by GOST
+	Accessed time.Time
+}
+
+// Export formats the items, the styled citations are numbered if there are several of them
+func Export(format string, items ...*Item) (string, error) {
+	switch format {
+	case BibTeXFormat:
+		return joinItems(items, bibTeX, "\n"), nil
+	case RISFormat:
+		return joinItems(items, ris, ""), nil
+	case CSLJSONFormat:
+		return cslJSON(items)
+	case APAFormat:
+		return joinStyled(items, APA), nil
+	case GOSTFormat:
+		return joinStyled(items, GOST), nil
+	default:
+		return "", ErrUnknownFormat
+	}
+}
+
+// ContentType returns the MIME type of the export format
+func ContentType(format string) string {
+	switch format {
+	case BibTeXFormat:
+		return "application/x-bibtex; charset=utf-8"
+	case RISFormat:
+		return "application/x-research-info-systems; charset=utf-8"
+	case CSLJSONFormat:
+		return "application/vnd.citationstyles.csl+json; charset=utf-8"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
+
+// Extension returns the file extension of the export format
+func Extension(format string) string {
+	switch format {
+	case BibTeXFormat:
+		return ".bib"
+	case RISFormat:
+		return ".ris"
+	case CSLJSONFormat:
+		return ".json"
+	default:
+		return ".txt"
+	}
+}
+
+func joinItems(items []*Item, format func(*Item) string, sep string) string {
+	formatted := make([]string, 0, len(items))
+	for _, item := range items {
+		formatted = append(formatted, format(item))
+	}
+	return strings.Join(formatted, sep)
+}
+
+func joinStyled(items []*Item, style func(*Item) string) string {
+	if len(items) == 1 {
+		return style(items[0]) + "\n"
+	}
+
+	var sb strings.Builder
+	for i, item := range items {
+		sb.WriteString(strconv.Itoa(i+1) + ". " + style(item) + "\n")
+	}
+	return sb.String()
+}
+
+// initials returns the initials of the given names, the hyphenated names keep the hyphen: Jean-Paul is J.-P.
+func initials(names ...string) []string {
+	var result []string
+	for _, name := range names {
+		for _, word := range strings.Fields(name) {
+			parts := strings.Split(word, "-")
+			for i, part := range parts {
+				if r, _ := utf8.DecodeRuneInString(part); r != utf8.RuneError {
+					parts[i] = string(unicode.ToUpper(r)) + "."
+				}
+			}
+			result = append(result, strings.Join(parts, "-"))
+		}
+	}
+	return result
+}
+
+// year returns the year of the issue or "n.d." if it's unknown
+func (item *Item) year() string {
+	if item.Issued.IsZero() {
+		return "n.d."
+	}
+	return strconv.Itoa(item.Issued.Year())
+}
+
+// FullName returns "Given Middle Family" or the literal name
+func (p *Person) FullName() string {
+	if p.Family == "" {
+		return p.Literal
+	}
+	return strings.Join(strings.Fields(p.Given+" "+p.Middle+" "+p.Family), " ")
+}
+
+// SortName returns the family name followed by the given names
+func (p *Person) SortName() string {
+	if p.Family == "" {
+		return p.Literal
+	}
+	if given := strings.Join(strings.Fields(p.Given+" "+p.Middle), " "); given != "" {
+		return p.Family + ", " + given
+	}
+	return p.Family
+}
@@ -0,0 +1,193 @@
+package citation
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+var bibTeXEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	"{", `\{`, "}", `\}`,
+	"&", `\&`, "%", `\%`, "$", `\$`, "#", `\#`, "_", `\_`,
+	"~", `\textasciitilde{}`, "^", `\textasciicircum{}`,
+)
+
+// bibTeXName returns "Family, Given Middle", the literal name is braced to be kept as is
+func bibTeXName(person *Person) string {
+	if person.Family == "" {
+		return "{" + bibTeXEscaper.Replace(person.Literal) + "}"
+	}
+	given := strings.TrimSpace(person.Given + " " + person.Middle)
+	if given == "" {
+		return bibTeXEscaper.Replace(person.Family)
+	}
+	return bibTeXEscaper.Replace(person.Family + ", " + given)
+}
+
+// bibTeXKey is the ASCII key of the entry: the family name of the first author,
+// the year and the beginning of the id keeping the keys of the bulk export unique
+func bibTeXKey(item *Item) string {
+	var sb strings.Builder
+	if len(item.Authors) > 0 {
+		for _, r := range strings.ToLower(item.Authors[0].Family) {
+			if 'a' <= r && r <= 'z' {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	if sb.Len() == 0 {
+		sb.WriteString("sow")
+	}
+	if !item.Issued.IsZero() {
+		fmt.Fprintf(&sb, "%d", item.Issued.Year())
+	}
+	if id := strings.ReplaceAll(item.ID, "-", ""); id != "" {
+		if len(id) > 8 {
+			id = id[:8]
+		}
+		sb.WriteString("_" + id)
+	}
+	return sb.String()
+}
+
+func bibTeX(item *Item) string {
+	var sb strings.Builder
+	field := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(&sb, ",\n  %s = {%s}", name, value)
+		}
+	}
+
+	sb.WriteString("@article{" + bibTeXKey(item))
+	authors := make([]string, 0, len(item.Authors))
+	for _, author := range item.Authors {
+		authors = append(authors, bibTeXName(author))
+	}
+	field("author", strings.Join(authors, " and "))
+	// the title is double braced to keep its case
+	field("title", "{"+bibTeXEscaper.Replace(item.Title)+"}")
+	field("journal", Publisher)
+	if !item.Issued.IsZero() {
+		field("year", item.year())
+		field("month", strings.ToLower(item.Issued.Month().String()[:3]))
+	}
+	field("doi", item.DOI)
+	field("url", item.URL)
+	field("language", item.Language)
+	field("keywords", bibTeXEscaper.Replace(strings.Join(item.Keywords, ", ")))
+	field("abstract", bibTeXEscaper.Replace(item.Abstract))
+	field("copyright", bibTeXEscaper.Replace(item.License))
+	sb.WriteString("\n}\n")
+
+	return sb.String()
+}
+
+// ris formats the RIS record, the tags are followed by two spaces and the hyphen
+func ris(item *Item) string {
+	var sb strings.Builder
+	tag := func(name, value string) {
+		if value = strings.Join(strings.Fields(value), " "); value != "" {
+			sb.WriteString(name + "  - " + value + "\r\n")
+		}
+	}
+
+	tag("TY", "JOUR")
+	for _, author := range item.Authors {
+		if author.Family == "" {
+			tag("AU", author.Literal)
+			continue
+		}
+		tag("AU", strings.TrimSuffix(author.Family+", "+strings.TrimSpace(author.Given+" "+author.Middle), ", "))
+	}
+	tag("TI", item.Title)
+	tag("JO", Publisher)
+	if !item.Issued.IsZero() {
+		tag("PY", item.year())
+		tag("DA", item.Issued.Format("2006/01/02/"))
+	}
+	tag("AB", item.Abstract)
+	for _, keyword := range item.Keywords {
+		tag("KW", keyword)
+	}
+	tag("LA", item.Language)
+	tag("DO", item.DOI)
+	tag("UR", item.URL)
+	// RIS has no tag of the license, it's noted
+	if item.License != "" {
+		tag("N1", "License: "+item.License)
+	}
+	tag("ID", item.ID)
+	sb.WriteString("ER  - \r\n")
+
+	return sb.String()
+}
+
+type cslName struct {
+	Family  string `json:"family,omitempty"`
+	Given   string `json:"given,omitempty"`
+	Literal string `json:"literal,omitempty"`
+}
+
+type cslDate struct {
+	DateParts [][]int `json:"date-parts"`
+}
+
+type cslItem struct {
+	ID             string     `json:"id"`
+	Type           string     `json:"type"`
+	Title          string     `json:"title"`
+	Author         []*cslName `json:"author,omitempty"`
+	Issued         *cslDate   `json:"issued,omitempty"`
+	Accessed       *cslDate   `json:"accessed,omitempty"`
+	ContainerTitle string     `json:"container-title"`
+	Abstract       string     `json:"abstract,omitempty"`
+	Keyword        string     `json:"keyword,omitempty"`
+	Language       string     `json:"language,omitempty"`
+	DOI            string     `json:"DOI,omitempty"`
+	URL            string     `json:"URL,omitempty"`
+	License        string     `json:"license,omitempty"`
+}
+
+// cslJSON formats the items as the CSL-JSON array
+func cslJSON(items []*Item) (string, error) {
+	cslItems := make([]*cslItem, 0, len(items))
+	for _, item := range items {
+		csl := &cslItem{
+			ID:             item.ID,
+			Type:           "article-journal",
+			Title:          item.Title,
+			ContainerTitle: Publisher,
+			Abstract:       item.Abstract,
+			Keyword:        strings.Join(item.Keywords, ", "),
+			Language:       item.Language,
+			DOI:            item.DOI,
+			URL:            item.URL,
+			License:        item.License,
+		}
+		for _, author := range item.Authors {
+			if author.Family == "" {
+				csl.Author = append(csl.Author, &cslName{Literal: author.Literal})
+				continue
+			}
+			csl.Author = append(csl.Author, &cslName{
+				Family: author.Family,
+				Given:  strings.TrimSpace(author.Given + " " + author.Middle),
+			})
+		}
+		if !item.Issued.IsZero() {
+			csl.Issued = &cslDate{DateParts: [][]int{{item.Issued.Year(), int(item.Issued.Month()), item.Issued.Day()}}}
+		}
+		if !item.Accessed.IsZero() {
+			csl.Accessed = &cslDate{DateParts: [][]int{{item.Accessed.Year(), int(item.Accessed.Month()), item.Accessed.Day()}}}
+		}
+		cslItems = append(cslItems, csl)
+	}
+
+	data, err := json.MarshalIndent(cslItems, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(data) + "\n", nil
+}
@@ -0,0 +1,62 @@
+package citation
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func testItem() *Item {
+	return &Item{
+		ID:      "2b7c4d2e-0f4b-4a43-9d5e-6c1f3b0a9e11",
+		Title:   "On the Sea of Wisdom",
+		Authors: []*Person{{Given: "Josiah", Family: "Carberry"}, {Given: "Anna", Family: "Ivanova"}},
+		Issued:  time.Date(2026, time.March, 7, 0, 0, 0, 0, time.UTC),
+		URL:     "https://doi.org/10.5555/sow.1",
+		DOI:     "10.5555/sow.1",
+		License: "https://creativecommons.org/licenses/by/4.0/",
+	}
+}
+
+func TestExportLicense(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{BibTeXFormat, "copyright = {https://creativecommons.org/licenses/by/4.0/}"},
+		{RISFormat, "N1  - License: https://creativecommons.org/licenses/by/4.0/\r\n"},
+		{CSLJSONFormat, `"license": "https://creativecommons.org/licenses/by/4.0/"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			exported, err := Export(test.format, testItem())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(exported, test.want) {
+				t.Errorf("the export has no %q:\n%s", test.want, exported)
+			}
+
+			item := testItem()
+			item.License = ""
+			if exported, _ = Export(test.format, item); strings.Contains(strings.ToLower(exported), "license") ||
+				strings.Contains(exported, "copyright") {
+				t.Errorf("the export of the item without the license has it:\n%s", exported)
+			}
+		})
+	}
+}
+
+func TestExportEscapesLicense(t *testing.T) {
+	item := testItem()
+	item.License = "Read only & no reuse, 100% reserved"
+
+	exported, err := Export(BibTeXFormat, item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(exported, `copyright = {Read only \& no reuse, 100\% reserved}`) {
+		t.Errorf("the license isn't escaped:\n%s", exported)
+	}
+}
@@ -0,0 +1,126 @@
+package citation
+
+import (
+	"strings"
+)
+
+// the max number of the authors listed by APA, the last one is listed after the ellipsis
+const apaMaxAuthors = 20
+
+// apaName returns "Family, G. M."
+func apaName(person *Person) string {
+	if person.Family == "" {
+		return person.Literal
+	}
+	given := initials(person.Given, person.Middle)
+	if len(given) == 0 {
+		return person.Family
+	}
+	return person.Family + ", " + strings.Join(given, " ")
+}
+
+// endSentence adds the period unless the text already ends with the punctuation
+func endSentence(text string) string {
+	text = strings.TrimSpace(text)
+	if text == "" || strings.ContainsAny(text[len(text)-1:], ".?!") {
+		return text
+	}
+	return text + "."
+}
+
+// APA formats the citation in the APA 7th edition style:
+// Family, G. M., & Family, G. (2024). Title. Sea of Wisdom. https://doi.org/...
+func APA(item *Item) string {
+	names := make([]string, 0, len(item.Authors))
+	for _, author := range item.Authors {
+		names = append(names, apaName(author))
+	}
+
+	var authors string
+	switch n := len(names); {
+	case n == 0:
+	case n == 1:
+		authors = names[0]
+	case n <= apaMaxAuthors:
+		authors = strings.Join(names[:n-1], ", ") + ", & " + names[n-1]
+	default:
+		authors = strings.Join(names[:apaMaxAuthors-1], ", ") + ", . . . " + names[n-1]
+	}
+
+	parts := make([]string, 0, 4)
+	if authors != "" {
+		parts = append(parts, endSentence(authors)+" ("+item.year()+").", endSentence(item.Title))
+	} else {
+		parts = append(parts, endSentence(item.Title), "("+item.year()+").")
+	}
+	parts = append(parts, Publisher+".")
+
+	switch {
+	case item.DOI != "":
+		parts = append(parts, "https://doi.org/"+item.DOI)
+	case item.URL != "":
+		parts = append(parts, item.URL)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// the max number of the authors in the heading of the GOST record
+const gostMaxAuthors = 3
+
+// gostHeading returns "Family G. M."
+func gostHeading(person *Person) string {
+	if person.Family == "" {
+		return person.Literal
+	}
+	return strings.TrimSpace(person.Family + " " + strings.Join(initials(person.Given, person.Middle), " "))
+}
+
+// gostResponsibility returns "G. M. Family"
+func gostResponsibility(person *Person) string {
+	if person.Family == "" {
+		return person.Literal
+	}
+	return strings.TrimSpace(strings.Join(initials(person.Given, person.Middle), " ") + " " + person.Family)
+}
+
+// GOST formats the citation of the electronic resource by GOST R 7.0.5-2008:
+// Family G. M. Title [Электронный ресурс] / G. M. Family // Sea of Wisdom. — 2024. — URL: ... (дата обращения: 19.10.2026).
+// The works of more than three authors are described by the title with the first author followed by "[и др.]".
+func GOST(item *Item) string {
+	var sb strings.Builder
+
+	if n := len(item.Authors); n > 0 && n <= gostMaxAuthors {
+		sb.WriteString(endSentence(gostHeading(item.Authors[0])) + " ")
+	}
+	sb.WriteString(strings.TrimSuffix(strings.TrimSpace(item.Title), ".") + " [Электронный ресурс]")
+
+	switch n := len(item.Authors); {
+	case n == 0:
+	case n <= gostMaxAuthors:
+		names := make([]string, 0, n)
+		for _, author := range item.Authors {
+			names = append(names, gostResponsibility(author))
+		}
+		sb.WriteString(" / " + strings.Join(names, ", "))
+	default:
+		sb.WriteString(" / " + gostResponsibility(item.Authors[0]) + " [и др.]")
+	}
+
+	sb.WriteString(" // " + Publisher + ".")
+	if !item.Issued.IsZero() {
+		sb.WriteString(" — " + item.year() + ".")
+	}
+	if item.URL != "" {
+		sb.WriteString(" — URL: " + item.URL)
+		if !item.Accessed.IsZero() {
+			sb.WriteString(" (дата обращения: " + item.Accessed.Format("02.01.2006") + ")")
+		}
+		sb.WriteString(".")
+	}
+	if item.DOI != "" {
+		sb.WriteString(" — DOI: " + item.DOI + ".")
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,124 @@
+package srv
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/citation"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+)
+
+// citationAuthor returns the author's name from the profile, the nickname or
+// the address is cited as is if the name isn't filled in
+func citationAuthor(author *storage.AuthorResponse) *citation.Person {
+	if author == nil {
+		return nil
+	}
+	if info := author.AuthorInfo; info != nil && info.Surname != "" {
+		return &citation.Person{Given: info.Name, Middle: info.MiddleName, Family: info.Surname}
+	}
+	if basic := author.BasicInfo; basic != nil {
+		if basic.NickName != "" {
+			return &citation.Person{Literal: basic.NickName}
+		}
+		return &citation.Person{Literal: basic.Web3Address}
+	}
+	return nil
+}
+
+// workAuthors returns the author who has submitted the work and its co-authors
+func workAuthors(workResp *storage.WorkResponse) []*citation.Person {
+	var authors []*citation.Person
+	if author := citationAuthor(workResp.Author); author != nil {
+		authors = append(authors, author)
+	}
+	for _, coAuthor := range workResp.Work.CoAuthors {
+		if coAuthor.Name == "" && coAuthor.Surname == "" {
+			continue
+		}
+		authors = append(authors, &citation.Person{Given: coAuthor.Name, Middle: coAuthor.MiddleName, Family: coAuthor.Surname})
+	}
+	return authors
+}
+
+// citationItem collects the citation of the work
+func (ls *LibrarySrv) citationItem(workResp *storage.WorkResponse) *citation.Item {
+	work := workResp.Work
+	item := &citation.Item{
+		ID:       work.ID,
+		Title:    work.Name,
+		Issued:   work.CreatedAt.UTC(),
+		Abstract: work.Annotation,
+		Keywords: work.Tags,
+		Language: work.Language,
+		URL:      ls.workURL(work.ID),
+		Accessed: time.Now().UTC(),
+	}
+	if license := storage.LicenseOf(work); license.URL != "" {
+		item.License = license.URL
+	} else {
+		item.License = license.Notice()
+	}
+	// the identified work is cited by its persistent URL, the DOI is cited as such
+	if work.PID != "" {
+		item.URL = ls.pidURL(work.PID)
+	}
+	if strings.HasPrefix(work.PID, "10.") {
+		item.DOI = work.PID
+	}
+	// the co-authors are cited after the author who has submitted the work
+	item.Authors = workAuthors(workResp)
+
+	return item
+}
+
+// cite formats the citations of the works
+func (ls *LibrarySrv) cite(format string, works []*storage.WorkResponse) (string, error) {
+	items := make([]*citation.Item, 0, len(works))
+	for _, workResp := range works {
+		if workResp != nil && workResp.Work != nil {
+			items = append(items, ls.citationItem(workResp))
+		}
+	}
+
+	return citation.Export(format, items...)
+}
+
+// CiteWork returns the citation of the work in the format, the draft is cited by its author only
+func (ls *LibrarySrv) CiteWork(ctx context.Context, readerAddress, workID, format string) (string, error) {
+	workResp, err := ls.storage.GetWorkByID(ctx, workID)
+	if err != nil {
+		ls.log.Errorf("CiteWork: error get work by id %s, err: %v", workID, err)
+
+		return "", err
+	}
+
+	if workResp == nil || workResp.Work.Status == storage.DraftWorkStatus && !isWorkAuthor(workResp, readerAddress) {
+		return "", storage.ErrWorkNotExists
+	}
+
+	return ls.cite(format, []*storage.WorkResponse{workResp})
+}
+
+// CiteBookmarks returns the citations of the reader's bookmarks in the format
+func (ls *LibrarySrv) CiteBookmarks(ctx context.Context, readerAddress, format string) (string, error) {
+	works, err := ls.GetBookmarksOf(ctx, readerAddress)
+	if err != nil {
+		return "", err
+	}
+
+	return ls.cite(format, works)
+}
+
+// CitePurchasedWorks returns the citations of the works purchased by the reader in the format
+func (ls *LibrarySrv) CitePurchasedWorks(ctx context.Context, readerAddress, format string) (string, error) {
+	works, err := ls.storage.GetPurchasedWorks(ctx, readerAddress)
+	if err != nil {
+		ls.log.Errorf("CitePurchasedWorks: error get purchased works of %s, err: %v", readerAddress, err)
+
+		return "", err
+	}
+
+	return ls.cite(format, works)
+}
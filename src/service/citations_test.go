@@ -0,0 +1,67 @@
+package srv
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SeaOfWisdom/sow_library/src/config"
+	"github.com/SeaOfWisdom/sow_library/src/service/citation"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+)
+
+func citedWork() *storage.WorkResponse {
+	return &storage.WorkResponse{
+		Work: &storage.Work{
+			ID:      "work",
+			Name:    "On the Sea of Wisdom",
+			Content: &storage.WorkContent{Format: "markdown", WorkData: "# The work"},
+			CoAuthors: []*storage.Author{
+				{Name: "Anna", Surname: "Ivanova"},
+				{},
+			},
+		},
+		Author: &storage.AuthorResponse{
+			AuthorInfo: &storage.Author{Name: "Josiah", Surname: "Carberry"},
+			BasicInfo:  &storage.Participant{Web3Address: "0xauthor"},
+		},
+	}
+}
+
+func TestCitationItemAuthors(t *testing.T) {
+	ls := &LibrarySrv{cfg: &config.Config{LibraryURL: "https://library.example.org"}}
+
+	item := ls.citationItem(citedWork())
+
+	want := []*citation.Person{{Given: "Josiah", Family: "Carberry"}, {Given: "Anna", Family: "Ivanova"}}
+	if !reflect.DeepEqual(item.Authors, want) {
+		t.Errorf("authors = %+v, want %+v", item.Authors, want)
+	}
+
+	cover := ls.workCover(citedWork())
+	if !reflect.DeepEqual(cover.Authors, []string{"Josiah Carberry", "Anna Ivanova"}) {
+		t.Errorf("cover authors = %v", cover.Authors)
+	}
+}
+
+func TestCitationItemDOI(t *testing.T) {
+	ls := &LibrarySrv{cfg: &config.Config{LibraryURL: "https://library.example.org"}}
+
+	tests := []struct {
+		pid string
+		doi string
+	}{
+		{"10.5555/sow.2026.1", "10.5555/sow.2026.1"},
+		{"sow:2026.1", ""},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		work := citedWork()
+		work.Work.PID = test.pid
+
+		item := ls.citationItem(work)
+		if item.DOI != test.doi {
+			t.Errorf("DOI of the work identified by %q = %q, want %q", test.pid, item.DOI, test.doi)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package srv
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/envelope"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/ecies"
+)
+
+// KeyEncryption is the scheme of the data key delivered to the reader's wallet
+const KeyEncryption = "ECIES-secp256k1-AES-128-CTR-HMAC-SHA-256"
+
+// WorkKey is the data key of the work content encrypted to the reader's wallet public key,
+// the reader decrypts the content pinned to IPFS by the key
+type WorkKey struct {
+	WorkID        string `json:"work_id"`
+	CID           string `json:"cid,omitempty"`
+	Encryption    string `json:"encryption"`
+	KeyEncryption string `json:"key_encryption"`
+	EncryptedKey  string `json:"encrypted_key"` // hex encoded
+}
+
+// GetWorkKey delivers the data key of the work to the reader who has access to the content,
+// the public key must belong to the reader's wallet
+func (ls *LibrarySrv) GetWorkKey(ctx context.Context, readerAddress, workID, publicKey string) (*WorkKey, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(publicKey, "0x"))
+	if err != nil {
+		return nil, ErrWrongPublicKey
+	}
+
+	// the uncompressed or compressed secp256k1 key
+	ecdsaPub, err := crypto.UnmarshalPubkey(raw)
+	if err != nil {
+		if ecdsaPub, err = crypto.DecompressPubkey(raw); err != nil {
+			return nil, ErrWrongPublicKey
+		}
+	}
+
+	if !strings.EqualFold(crypto.PubkeyToAddress(*ecdsaPub).Hex(), readerAddress) {
+		return nil, ErrWrongPublicKey
+	}
+
+	workResp, err := ls.storage.GetWorkByID(ctx, workID)
+	if err != nil {
+		ls.log.Errorf("GetWorkKey: error get work %s, err: %v", workID, err)
+
+		return nil, err
+	}
+
+	if workResp == nil {
+		return nil, storage.ErrWorkNotExists
+	}
+
+	if !ls.hasContentAccess(readerAddress, workID) {
+		return nil, ErrContentAccessDenied
+	}
+
+	key, err := ls.storage.WorkDataKey(workResp.Work)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := ecies.Encrypt(rand.Reader, ecies.ImportECDSAPublic(ecdsaPub), key, nil, nil)
+	if err != nil {
+		ls.log.Errorf("GetWorkKey: error encrypt key of work %s, err: %v", workID, err)
+
+		return nil, err
+	}
+
+	return &WorkKey{
+		WorkID:        workID,
+		CID:           workResp.Work.CID,
+		Encryption:    envelope.Algorithm,
+		KeyEncryption: KeyEncryption,
+		EncryptedKey:  hex.EncodeToString(encrypted),
+	}, nil
+}
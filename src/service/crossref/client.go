@@ -0,0 +1,151 @@
+package crossref
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// the statuses of the submitted batch
+const (
+	BatchQueued     = "queued"
+	BatchInProcess  = "in_process"
+	BatchCompleted  = "completed"
+	BatchUnknown    = "unknown_submission"
+	RecordSuccess   = "Success"
+	RecordWarning   = "Warning"
+	RecordFailure   = "Failure"
+	depositPath     = "/servlet/deposit"
+	submissionsPath = "/servlet/submissionDownload"
+)
+
+// Client submits the deposits to the deposit API and downloads the results of their processing
+type Client struct {
+	url      string
+	login    string
+	password string
+	client   *http.Client
+}
+
+func NewClient(depositURL, login, password string) (*Client, error) {
+	u, err := url.Parse(depositURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("wrong Crossref URL %q", depositURL)
+	}
+
+	if login == "" {
+		return nil, fmt.Errorf("the Crossref login is null")
+	}
+
+	return &Client{
+		url:      strings.TrimSuffix(depositURL, "/"),
+		login:    login,
+		password: password,
+		client:   &http.Client{Timeout: time.Minute},
+	}, nil
+}
+
+// Submit uploads the deposit of the batch, the deposit is processed asynchronously
+func (c *Client) Submit(ctx context.Context, batchID string, deposit []byte) error {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for name, value := range map[string]string{
+		"operation":    "doMDUpload",
+		"login_id":     c.login,
+		"login_passwd": c.password,
+	} {
+		if err := writer.WriteField(name, value); err != nil {
+			return err
+		}
+	}
+
+	part, err := writer.CreateFormFile("fname", batchID+".xml")
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(deposit); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+depositPath, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	msg, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("crossref: unexpected status %s: %s", res.Status, strings.TrimSpace(string(msg)))
+	}
+
+	// the upload is acknowledged by the page reporting SUCCESS, the rest pages are the errors
+	if !bytes.Contains(bytes.ToUpper(msg), []byte("SUCCESS")) {
+		return fmt.Errorf("crossref: the deposit is refused: %s", strings.TrimSpace(string(msg)))
+	}
+
+	return nil
+}
+
+// RecordResult is the result of the deposit of the DOI
+type RecordResult struct {
+	Status  string `xml:"status,attr"`
+	DOI     string `xml:"doi"`
+	Message string `xml:"msg"`
+}
+
+// Result is the result of the processing of the batch, the records are reported when the batch is completed
+type Result struct {
+	XMLName xml.Name        `xml:"doi_batch_diagnostic"`
+	Status  string          `xml:"status,attr"`
+	BatchID string          `xml:"batch_id"`
+	Records []*RecordResult `xml:"record_diagnostic"`
+}
+
+// Result downloads the result of the submitted batch
+func (c *Client) Result(ctx context.Context, batchID string) (*Result, error) {
+	query := url.Values{
+		"usr":          {c.login},
+		"pwd":          {c.password},
+		"doi_batch_id": {batchID},
+		"type":         {"result"},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+submissionsPath+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return nil, fmt.Errorf("crossref: unexpected status %s: %s", res.Status, strings.TrimSpace(string(msg)))
+	}
+
+	result := new(Result)
+	if err := xml.NewDecoder(res.Body).Decode(result); err != nil {
+		return nil, fmt.Errorf("crossref: wrong result, err: %v", err)
+	}
+
+	return result, nil
+}
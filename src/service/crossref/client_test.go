@@ -0,0 +1,143 @@
+package crossref
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"testing"
+)
+
+const (
+	testLogin    = "test-login"
+	testPassword = "test-password"
+)
+
+func testClient(t *testing.T) (*Client, *StandIn) {
+	standIn := NewStandIn(testLogin, testPassword)
+	server := httptest.NewServer(standIn)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL+"/", testLogin, testPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client, standIn
+}
+
+func TestSubmit(t *testing.T) {
+	client, standIn := testClient(t)
+
+	batch := testBatch()
+	deposit, err := Marshal(batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.Submit(context.Background(), batch.ID, deposit); err != nil {
+		t.Fatal(err)
+	}
+
+	submitted, ok := standIn.Deposit(batch.ID)
+	if !ok {
+		t.Fatal("the deposit isn't stored")
+	}
+	if !bytes.Equal(submitted, deposit) {
+		t.Error("the stored deposit isn't the submitted one")
+	}
+}
+
+func TestSubmitWrongCredentials(t *testing.T) {
+	standIn := NewStandIn(testLogin, testPassword)
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	client, err := NewClient(server.URL, testLogin, "wrong-password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	batch := testBatch()
+	deposit, err := Marshal(batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.Submit(context.Background(), batch.ID, deposit); err == nil {
+		t.Fatal("the deposit is submitted with the wrong credentials")
+	}
+	if _, ok := standIn.Deposit(batch.ID); ok {
+		t.Error("the deposit with the wrong credentials is stored")
+	}
+	if _, err := client.Result(context.Background(), batch.ID); err == nil {
+		t.Error("the result is downloaded with the wrong credentials")
+	}
+}
+
+func TestResult(t *testing.T) {
+	client, standIn := testClient(t)
+
+	batch := testBatch()
+	batch.Works = append(batch.Works, testWork("10.5555/sow.2"))
+	standIn.Reject("10.5555/sow.2", "the resource is unreachable")
+
+	deposit, err := Marshal(batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Submit(context.Background(), batch.ID, deposit); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := client.Result(context.Background(), batch.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Status != BatchCompleted || result.BatchID != batch.ID {
+		t.Fatalf("result = %s of %s, want %s of %s", result.Status, result.BatchID, BatchCompleted, batch.ID)
+	}
+
+	want := map[string]string{"10.5555/sow.1": RecordSuccess, "10.5555/sow.2": RecordFailure}
+	if len(result.Records) != len(want) {
+		t.Fatalf("%d records, want %d", len(result.Records), len(want))
+	}
+	for _, record := range result.Records {
+		if record.Status != want[record.DOI] {
+			t.Errorf("the record of %s is %s, want %s", record.DOI, record.Status, want[record.DOI])
+		}
+	}
+}
+
+func TestResultUnknownBatch(t *testing.T) {
+	client, _ := testClient(t)
+
+	result, err := client.Result(context.Background(), "unknown-batch")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Status != BatchUnknown || len(result.Records) != 0 {
+		t.Errorf("result = %s with %d records, want %s", result.Status, len(result.Records), BatchUnknown)
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		name  string
+		url   string
+		login string
+	}{
+		{"wrong URL", "test.crossref.org", testLogin},
+		{"null URL", "", testLogin},
+		{"null login", "https://test.crossref.org", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := NewClient(test.url, test.login, testPassword); err == nil {
+				t.Error("the client is created")
+			}
+		})
+	}
+}
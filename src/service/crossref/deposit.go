@@ -0,0 +1,329 @@
+package crossref
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// the deposit schema the batches are generated by
+const (
+	SchemaVersion = "5.3.1"
+	Namespace     = "http://www.crossref.org/schema/5.3.1"
+	SchemaURL     = "https://www.crossref.org/schemas/crossref5.3.1.xsd"
+
+	jatsNamespace = "http://www.ncbi.nlm.nih.gov/JATS1"
+	aiNamespace   = "http://www.crossref.org/AccessIndicators.xsd"
+	xsiNamespace  = "http://www.w3.org/2001/XMLSchema-instance"
+)
+
+const ContentType = "application/vnd.crossref.deposit+xml"
+
+// PostedContentType is the type of the posted content the works are deposited as without the ISSN
+const PostedContentType = "other"
+
+// Depositor is the member depositing the metadata
+type Depositor struct {
+	Name  string `xml:"depositor_name"`
+	Email string `xml:"email_address"`
+}
+
+// Contributor is the author of the work, the author without the family name is deposited as anonymous
+type Contributor struct {
+	Given  string
+	Family string
+	// ORCID is the https://orcid.org/ URI of the author
+	ORCID string
+}
+
+// Reference is the entry of the reference list, it's deposited by its DOI or by its text
+type Reference struct {
+	DOI  string
+	Text string
+}
+
+// Work is the metadata of the work deposited as the journal article or the posted content of the library
+type Work struct {
+	DOI string
+	// URL is the landing page the DOI resolves to
+	URL          string
+	Title        string
+	Abstract     string
+	Language     string
+	Published    time.Time
+	Contributors []*Contributor
+	// LicenseURL is the license the work is available under, the work without the license is deposited without it
+	LicenseURL string
+	References []*Reference
+}
+
+// Batch is the deposit of the works
+type Batch struct {
+	ID string
+	// Timestamp orders the deposits of the same DOI, the later deposit must have the greater timestamp
+	Timestamp  time.Time
+	Depositor  *Depositor
+	Registrant string
+	// Journal is the title the works are published under
+	Journal string
+	// ISSN is the electronic ISSN of the journal, the works are deposited as the journal articles
+	// if it's given and as the posted content otherwise, as the journal needs the ISSN to be deposited
+	ISSN  string
+	Works []*Work
+}
+
+type doiBatch struct {
+	XMLName        xml.Name         `xml:"doi_batch"`
+	Version        string           `xml:"version,attr"`
+	Xmlns          string           `xml:"xmlns,attr"`
+	XmlnsXSI       string           `xml:"xmlns:xsi,attr"`
+	SchemaLocation string           `xml:"xsi:schemaLocation,attr"`
+	XmlnsJATS      string           `xml:"xmlns:jats,attr"`
+	XmlnsAI        string           `xml:"xmlns:ai,attr"`
+	Head           *head            `xml:"head"`
+	Journals       []journal        `xml:"body>journal"`
+	PostedContent  []*postedContent `xml:"body>posted_content"`
+}
+
+type head struct {
+	BatchID    string     `xml:"doi_batch_id"`
+	Timestamp  string     `xml:"timestamp"`
+	Depositor  *Depositor `xml:"depositor"`
+	Registrant string     `xml:"registrant"`
+}
+
+type journal struct {
+	Metadata journalMetadata `xml:"journal_metadata"`
+	Article  *journalArticle `xml:"journal_article"`
+}
+
+type journalMetadata struct {
+	Language  string `xml:"language,attr,omitempty"`
+	FullTitle string `xml:"full_title"`
+	ISSN      *issn  `xml:"issn"`
+}
+
+type issn struct {
+	MediaType string `xml:"media_type,attr"`
+	Value     string `xml:",chardata"`
+}
+
+type personName struct {
+	XMLName  xml.Name `xml:"person_name"`
+	Sequence string   `xml:"sequence,attr"`
+	Role     string   `xml:"contributor_role,attr"`
+	Given    string   `xml:"given_name,omitempty"`
+	Surname  string   `xml:"surname"`
+	ORCID    string   `xml:"ORCID,omitempty"`
+}
+
+type anonymous struct {
+	XMLName  xml.Name `xml:"anonymous"`
+	Sequence string   `xml:"sequence,attr"`
+	Role     string   `xml:"contributor_role,attr"`
+}
+
+type jatsAbstract struct {
+	Paragraphs []string `xml:"jats:p"`
+}
+
+type date struct {
+	Month string `xml:"month"`
+	Day   string `xml:"day"`
+	Year  string `xml:"year"`
+}
+
+type publicationDate struct {
+	MediaType string `xml:"media_type,attr"`
+	date
+}
+
+type aiProgram struct {
+	Name        string   `xml:"name,attr"`
+	LicenseRefs []string `xml:"ai:license_ref"`
+}
+
+type doiData struct {
+	DOI      string `xml:"doi"`
+	Resource string `xml:"resource"`
+}
+
+type citation struct {
+	Key          string `xml:"key,attr"`
+	DOI          string `xml:"doi,omitempty"`
+	Unstructured string `xml:"unstructured_citation,omitempty"`
+}
+
+type journalArticle struct {
+	PublicationType string   `xml:"publication_type,attr"`
+	Language        string   `xml:"language,attr,omitempty"`
+	Titles          []string `xml:"titles>title"`
+	// the contributors are the person names and the anonymous authors in the order of the authors
+	Contributors    []interface{}    `xml:"contributors>person_name"`
+	Abstract        *jatsAbstract    `xml:"jats:abstract,omitempty"`
+	PublicationDate *publicationDate `xml:"publication_date"`
+	Access          *aiProgram       `xml:"ai:program,omitempty"`
+	DOIData         *doiData         `xml:"doi_data"`
+	Citations       []*citation      `xml:"citation_list>citation,omitempty"`
+}
+
+// postedContent is the work of the library without the ISSN, its elements are ordered
+// by the schema unlike the ones of the journal article
+type postedContent struct {
+	Type         string        `xml:"type,attr"`
+	Language     string        `xml:"language,attr,omitempty"`
+	Contributors []interface{} `xml:"contributors>person_name"`
+	Titles       []string      `xml:"titles>title"`
+	PostedDate   *date         `xml:"posted_date"`
+	Abstract     *jatsAbstract `xml:"jats:abstract,omitempty"`
+	Access       *aiProgram    `xml:"ai:program,omitempty"`
+	DOIData      *doiData      `xml:"doi_data"`
+	Citations    []*citation   `xml:"citation_list>citation,omitempty"`
+}
+
+// Marshal writes the deposit of the batch, the batch is validated first
+func Marshal(batch *Batch) ([]byte, error) {
+	if err := Validate(batch); err != nil {
+		return nil, err
+	}
+
+	document := &doiBatch{
+		Version:        SchemaVersion,
+		Xmlns:          Namespace,
+		XmlnsXSI:       xsiNamespace,
+		SchemaLocation: Namespace + " " + SchemaURL,
+		XmlnsJATS:      jatsNamespace,
+		XmlnsAI:        aiNamespace,
+		Head: &head{
+			BatchID:    batch.ID,
+			Timestamp:  Timestamp(batch.Timestamp),
+			Depositor:  batch.Depositor,
+			Registrant: batch.Registrant,
+		},
+	}
+	for _, work := range batch.Works {
+		if batch.ISSN == "" {
+			document.PostedContent = append(document.PostedContent, posted(work))
+			continue
+		}
+		document.Journals = append(document.Journals, journal{
+			Metadata: journalMetadata{FullTitle: batch.Journal, ISSN: &issn{MediaType: "electronic", Value: batch.ISSN}},
+			Article:  article(work),
+		})
+	}
+
+	data, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
+
+// Timestamp formats the time of the deposit as the digits of the date, the time and the milliseconds
+func Timestamp(t time.Time) string {
+	return strings.Replace(t.UTC().Format("20060102150405.000"), ".", "", 1)
+}
+
+// metadata is the metadata the journal article and the posted content have in common
+type metadata struct {
+	contributors []interface{}
+	abstract     *jatsAbstract
+	access       *aiProgram
+	doiData      *doiData
+	citations    []*citation
+}
+
+func workMetadata(work *Work) *metadata {
+	meta := &metadata{doiData: &doiData{DOI: work.DOI, Resource: work.URL}}
+
+	for i, author := range work.Contributors {
+		sequence := "additional"
+		if i == 0 {
+			sequence = "first"
+		}
+		if author.Family == "" {
+			meta.contributors = append(meta.contributors, &anonymous{Sequence: sequence, Role: "author"})
+			continue
+		}
+		meta.contributors = append(meta.contributors, &personName{
+			Sequence: sequence,
+			Role:     "author",
+			Given:    author.Given,
+			Surname:  author.Family,
+			ORCID:    author.ORCID,
+		})
+	}
+
+	if paragraphs := abstractParagraphs(work.Abstract); len(paragraphs) > 0 {
+		meta.abstract = &jatsAbstract{Paragraphs: paragraphs}
+	}
+
+	if work.LicenseURL != "" {
+		meta.access = &aiProgram{Name: "AccessIndicators", LicenseRefs: []string{work.LicenseURL}}
+	}
+
+	for i, reference := range work.References {
+		entry := &citation{Key: "ref" + strconv.Itoa(i+1), DOI: reference.DOI}
+		if entry.DOI == "" {
+			entry.Unstructured = reference.Text
+		}
+		meta.citations = append(meta.citations, entry)
+	}
+
+	return meta
+}
+
+// publishedDate returns the date the work was published
+func publishedDate(work *Work) date {
+	published := work.Published.UTC()
+	return date{
+		Month: fmt.Sprintf("%02d", published.Month()),
+		Day:   fmt.Sprintf("%02d", published.Day()),
+		Year:  strconv.Itoa(published.Year()),
+	}
+}
+
+func article(work *Work) *journalArticle {
+	meta := workMetadata(work)
+	return &journalArticle{
+		PublicationType: "full_text",
+		Language:        work.Language,
+		Titles:          []string{work.Title},
+		Contributors:    meta.contributors,
+		Abstract:        meta.abstract,
+		PublicationDate: &publicationDate{MediaType: "online", date: publishedDate(work)},
+		Access:          meta.access,
+		DOIData:         meta.doiData,
+		Citations:       meta.citations,
+	}
+}
+
+func posted(work *Work) *postedContent {
+	meta := workMetadata(work)
+	posted := publishedDate(work)
+	return &postedContent{
+		Type:         PostedContentType,
+		Language:     work.Language,
+		Contributors: meta.contributors,
+		Titles:       []string{work.Title},
+		PostedDate:   &posted,
+		Abstract:     meta.abstract,
+		Access:       meta.access,
+		DOIData:      meta.doiData,
+		Citations:    meta.citations,
+	}
+}
+
+// abstractParagraphs splits the abstract by the blank lines
+func abstractParagraphs(abstract string) []string {
+	var paragraphs []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(abstract, "\r\n", "\n"), "\n\n") {
+		if paragraph = strings.Join(strings.Fields(paragraph), " "); paragraph != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+	return paragraphs
+}
@@ -0,0 +1,227 @@
+package crossref
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "update the golden deposits")
+
+func testWork(doi string) *Work {
+	return &Work{
+		DOI:       doi,
+		URL:       "https://library.example.org/works/" + strings.TrimPrefix(doi, "10.5555/"),
+		Title:     "On the Sea of Wisdom",
+		Abstract:  "The first paragraph\nof the abstract.\n\nThe second one.",
+		Language:  "en",
+		Published: time.Date(2026, time.March, 7, 12, 0, 0, 0, time.UTC),
+		Contributors: []*Contributor{
+			{Given: "Josiah", Family: "Carberry", ORCID: "https://orcid.org/0000-0002-1825-0097"},
+			{},
+		},
+		LicenseURL: "https://creativecommons.org/licenses/by/4.0/",
+		References: []*Reference{
+			{DOI: "10.5555/12345678"},
+			{Text: "Carberry J. Toward a Unified Theory of High-Energy Metaphysics. 2008."},
+		},
+	}
+}
+
+func testBatch() *Batch {
+	return &Batch{
+		ID:         "sow-test-batch",
+		Timestamp:  time.Date(2026, time.March, 8, 9, 30, 0, 123e6, time.UTC),
+		Depositor:  &Depositor{Name: "Sea of Wisdom", Email: "deposits@library.example.org"},
+		Registrant: "Sea of Wisdom",
+		Journal:    "Sea of Wisdom Library",
+		Works:      []*Work{testWork("10.5555/sow.1")},
+	}
+}
+
+// depositDiff returns the first line of the deposit which differs from the golden one
+func depositDiff(deposit, golden []byte) string {
+	got, want := strings.Split(string(deposit), "\n"), strings.Split(string(golden), "\n")
+	for i := 0; i < len(got) || i < len(want); i++ {
+		var gotLine, wantLine string
+		if i < len(got) {
+			gotLine = got[i]
+		}
+		if i < len(want) {
+			wantLine = want[i]
+		}
+		if gotLine != wantLine {
+			return fmt.Sprintf("line %d: %q, want %q", i+1, gotLine, wantLine)
+		}
+	}
+	return ""
+}
+
+// TestMarshal compares the deposits of the posted content and of the journal article
+// to testdata/<name>.golden.xml, the tests run with -update write the golden files
+func TestMarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		issn string
+	}{
+		{"posted", ""},
+		{"journal", "2049-3630"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			batch := testBatch()
+			batch.ISSN = test.issn
+
+			deposit, err := Marshal(batch)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			golden := filepath.Join("testdata", test.name+".golden.xml")
+			if *update {
+				if err := os.WriteFile(golden, deposit, 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("%v, run the tests with -update to create the golden deposit", err)
+			}
+			if diff := depositDiff(deposit, want); diff != "" {
+				t.Errorf("the deposit differs from %s at the %s", golden, diff)
+			}
+		})
+	}
+}
+
+// TestPostedContentOrder checks the elements of the posted content follow the sequence of the schema
+func TestPostedContentOrder(t *testing.T) {
+	deposit, err := Marshal(testBatch())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var elements []string
+	depth := 0
+	decoder := xml.NewDecoder(bytes.NewReader(deposit))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			if depth == 1 {
+				elements = append(elements, token.Name.Local)
+			}
+			if token.Name.Local == "posted_content" || depth > 0 {
+				depth++
+			}
+		case xml.EndElement:
+			if depth > 0 {
+				depth--
+			}
+		}
+	}
+
+	want := []string{"contributors", "titles", "posted_date", "abstract", "program", "doi_data", "citation_list"}
+	if !reflect.DeepEqual(elements, want) {
+		t.Errorf("the posted content elements are %v, want %v", elements, want)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		problem string
+		change  func(batch *Batch)
+	}{
+		{"malformed ISSN", "issn", func(batch *Batch) { batch.ISSN = "2049-363" }},
+		{"journal without title", "full_title", func(batch *Batch) { batch.ISSN, batch.Journal = "2049-3630", "" }},
+		{"posted content without contributors", "contributors", func(batch *Batch) { batch.Works[0].Contributors = nil }},
+		{"malformed DOI", "doi", func(batch *Batch) { batch.Works[0].DOI = "11.5555/sow.1" }},
+		{"duplicate DOI", "twice", func(batch *Batch) { batch.Works = append(batch.Works, testWork("10.5555/sow.1")) }},
+		{"malformed ORCID", "ORCID", func(batch *Batch) { batch.Works[0].Contributors[0].ORCID = "0000-0002-1825-0097" }},
+		{"relative resource", "resource", func(batch *Batch) { batch.Works[0].URL = "/works/sow.1" }},
+		{"citation without DOI and text", "citation", func(batch *Batch) { batch.Works[0].References = []*Reference{{}} }},
+		{"null depositor", "depositor", func(batch *Batch) { batch.Depositor = nil }},
+	}
+
+	if err := Validate(testBatch()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			batch := testBatch()
+			test.change(batch)
+
+			err := Validate(batch)
+			if !errors.Is(err, ErrInvalid) {
+				t.Fatalf("err = %v, want %v", err, ErrInvalid)
+			}
+			if !strings.Contains(err.Error(), test.problem) {
+				t.Errorf("err = %v, want the %s problem", err, test.problem)
+			}
+		})
+	}
+}
+
+func TestNormalizeORCID(t *testing.T) {
+	tests := []struct {
+		orcid string
+		want  string
+		ok    bool
+	}{
+		{"0000-0002-1825-0097", "https://orcid.org/0000-0002-1825-0097", true},
+		{"https://orcid.org/0000-0002-1825-0097", "https://orcid.org/0000-0002-1825-0097", true},
+		{" orcid.org/0000-0002-1694-233x ", "https://orcid.org/0000-0002-1694-233X", true},
+		{"0000-0002-1825-0098", "", false},
+		{"0000-0002-1825", "", false},
+		{"", "", false},
+	}
+
+	for _, test := range tests {
+		got, ok := NormalizeORCID(test.orcid)
+		if got != test.want || ok != test.ok {
+			t.Errorf("NormalizeORCID(%q) = %q, %v, want %q, %v", test.orcid, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+// TestSchema validates the golden deposits against the deposit schema, TestMarshal keeps them
+// equal to the marshaled ones. The schema isn't bundled, so the test runs when CROSSREF_SCHEMA
+// is the path of crossref5.3.1.xsd and xmllint is installed.
+func TestSchema(t *testing.T) {
+	schema := os.Getenv("CROSSREF_SCHEMA")
+	if schema == "" {
+		t.Skip("CROSSREF_SCHEMA isn't set")
+	}
+	xmllint, err := exec.LookPath("xmllint")
+	if err != nil {
+		t.Skip("xmllint isn't installed")
+	}
+
+	deposits, err := filepath.Glob(filepath.Join("testdata", "*.golden.xml"))
+	if err != nil || len(deposits) == 0 {
+		t.Fatalf("no golden deposits, err: %v", err)
+	}
+
+	for _, deposit := range deposits {
+		if out, err := exec.Command(xmllint, "--noout", "--schema", schema, deposit).CombinedOutput(); err != nil {
+			t.Errorf("%s doesn't match the schema: %v\n%s", deposit, err, out)
+		}
+	}
+}
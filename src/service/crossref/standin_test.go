@@ -0,0 +1,143 @@
+package crossref
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// StandIn is the stand-in of the deposit API keeping the deposits in memory, the client
+// is tested against it served by the test server. The deposits are completed at once.
+type StandIn struct {
+	login    string
+	password string
+
+	mu       sync.RWMutex
+	deposits map[string][]byte
+	results  map[string]*Result
+	rejected map[string]string
+}
+
+func NewStandIn(login, password string) *StandIn {
+	return &StandIn{
+		login:    login,
+		password: password,
+		deposits: make(map[string][]byte),
+		results:  make(map[string]*Result),
+		rejected: make(map[string]string),
+	}
+}
+
+// Reject makes the later deposits of the DOI fail with the message
+func (s *StandIn) Reject(doi, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rejected[doi] = message
+}
+
+// Deposit returns the deposit of the batch as it was submitted
+func (s *StandIn) Deposit(batchID string) ([]byte, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	deposit, ok := s.deposits[batchID]
+
+	return deposit, ok
+}
+
+// the part of the deposit the stand-in reports on
+type submittedBatch struct {
+	BatchID     string   `xml:"head>doi_batch_id"`
+	ArticleDOIs []string `xml:"body>journal>journal_article>doi_data>doi"`
+	PostedDOIs  []string `xml:"body>posted_content>doi_data>doi"`
+}
+
+func (s *StandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == depositPath:
+		s.submit(w, r)
+	case r.Method == http.MethodGet && r.URL.Path == submissionsPath:
+		s.result(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *StandIn) submit(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if r.FormValue("login_id") != s.login || r.FormValue("login_passwd") != s.password {
+		http.Error(w, "FAILURE: wrong credentials", http.StatusUnauthorized)
+		return
+	}
+
+	if r.FormValue("operation") != "doMDUpload" {
+		http.Error(w, "FAILURE: unknown operation", http.StatusBadRequest)
+		return
+	}
+
+	file, _, err := r.FormFile("fname")
+	if err != nil {
+		http.Error(w, "FAILURE: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	deposit, err := io.ReadAll(file)
+	if err != nil {
+		http.Error(w, "FAILURE: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	batch := new(submittedBatch)
+	if err := xml.Unmarshal(deposit, batch); err != nil || batch.BatchID == "" {
+		http.Error(w, "FAILURE: the deposit isn't the doi_batch", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := &Result{Status: BatchCompleted, BatchID: batch.BatchID}
+	for _, doi := range append(batch.ArticleDOIs, batch.PostedDOIs...) {
+		record := &RecordResult{Status: RecordSuccess, DOI: doi, Message: "Successfully added"}
+		if message, ok := s.rejected[doi]; ok {
+			record.Status, record.Message = RecordFailure, message
+		}
+		result.Records = append(result.Records, record)
+	}
+	s.deposits[batch.BatchID] = deposit
+	s.results[batch.BatchID] = result
+
+	fmt.Fprint(w, "<html><body><h2>SUCCESS</h2></body></html>")
+}
+
+func (s *StandIn) result(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("usr") != s.login || query.Get("pwd") != s.password {
+		http.Error(w, "wrong credentials", http.StatusUnauthorized)
+		return
+	}
+
+	s.mu.RLock()
+	result, ok := s.results[query.Get("doi_batch_id")]
+	s.mu.RUnlock()
+	if !ok {
+		result = &Result{Status: BatchUnknown, BatchID: query.Get("doi_batch_id")}
+	}
+
+	data, err := xml.Marshal(result)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	w.Write(append([]byte(xml.Header), data...))
+}
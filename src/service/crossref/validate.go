@@ -0,0 +1,139 @@
+package crossref
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var ErrInvalid = errors.New("the deposit doesn't match the schema")
+
+// the patterns and the length limits of the deposit schema
+var (
+	doiPattern      = regexp.MustCompile(`^10\.[0-9]{4,9}/.{1,200}$`)
+	orcidPattern    = regexp.MustCompile(`^https?://orcid\.org/[0-9]{4}-[0-9]{4}-[0-9]{4}-[0-9]{3}[X0-9]$`)
+	emailPattern    = regexp.MustCompile(`^[\p{L}\p{N}!/+\-_]+(\.[\p{L}\p{N}!/+\-_]+)*@[\p{L}\p{N}!/+\-_]+(\.[\p{L}\p{N}!/+\-_]+)+$`)
+	languagePattern = regexp.MustCompile(`^[a-z]{2}$`)
+	issnPattern     = regexp.MustCompile(`^\d{4}-?\d{3}[\dX]$`)
+)
+
+const (
+	maxBatchIDLength    = 64
+	minBatchIDLength    = 4
+	maxDepositorLength  = 130
+	maxRegistrantLength = 255
+	maxNameLength       = 60
+	maxURLLength        = 2048
+)
+
+// Validate checks the batch against the constraints the deposit schema puts on the elements the batch
+// is written to: the required elements, the lengths, the patterns of the DOIs, the ORCIDs and the emails.
+// It's the hand written subset of the crossref5.3.1 schema, not the XSD validation, the marshaled
+// deposits are validated against the schema itself by TestSchema when the schema is available.
+func Validate(batch *Batch) error {
+	var problems []string
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+	length := func(value string, min, max int) bool {
+		n := utf8.RuneCountInString(strings.TrimSpace(value))
+		return n >= min && n <= max
+	}
+
+	check(length(batch.ID, minBatchIDLength, maxBatchIDLength), "doi_batch_id must have %d-%d characters", minBatchIDLength, maxBatchIDLength)
+	check(!batch.Timestamp.IsZero(), "timestamp is null")
+	if batch.Depositor == nil {
+		check(false, "depositor is null")
+	} else {
+		check(length(batch.Depositor.Name, 1, maxDepositorLength), "depositor_name must have 1-%d characters", maxDepositorLength)
+		check(emailPattern.MatchString(batch.Depositor.Email), "email_address %q is malformed", batch.Depositor.Email)
+	}
+	check(length(batch.Registrant, 1, maxRegistrantLength), "registrant must have 1-%d characters", maxRegistrantLength)
+	if batch.ISSN != "" {
+		check(strings.TrimSpace(batch.Journal) != "", "full_title of the journal is null")
+		check(issnPattern.MatchString(batch.ISSN), "issn %q is malformed", batch.ISSN)
+	}
+	check(len(batch.Works) > 0, "the batch has no works")
+
+	dois := make(map[string]bool, len(batch.Works))
+	for _, work := range batch.Works {
+		check(doiPattern.MatchString(work.DOI), "doi %q is malformed", work.DOI)
+		check(!dois[work.DOI], "doi %q is deposited twice", work.DOI)
+		dois[work.DOI] = true
+
+		check(validURL(work.URL), "resource %q of %s isn't the HTTP URL", work.URL, work.DOI)
+		check(strings.TrimSpace(work.Title) != "", "title of %s is null", work.DOI)
+		check(work.Language == "" || languagePattern.MatchString(work.Language), "language %q of %s isn't the ISO 639-1 code", work.Language, work.DOI)
+		check(!work.Published.IsZero(), "publication_date of %s is null", work.DOI)
+		check(work.Published.IsZero() || work.Published.Year() >= 1400 && work.Published.Year() <= 2200, "publication_date of %s is out of range", work.DOI)
+		// the posted content has the contributors required
+		check(batch.ISSN != "" || len(work.Contributors) > 0, "contributors of %s are null", work.DOI)
+
+		for _, author := range work.Contributors {
+			if author.Family == "" {
+				continue
+			}
+			check(length(author.Family, 1, maxNameLength), "surname %q of %s must have 1-%d characters", author.Family, work.DOI, maxNameLength)
+			check(author.Given == "" || length(author.Given, 1, maxNameLength), "given_name %q of %s must have 1-%d characters", author.Given, work.DOI, maxNameLength)
+			check(author.ORCID == "" || orcidPattern.MatchString(author.ORCID), "ORCID %q of %s is malformed", author.ORCID, work.DOI)
+		}
+
+		check(work.LicenseURL == "" || validURL(work.LicenseURL), "license_ref %q of %s isn't the HTTP URL", work.LicenseURL, work.DOI)
+
+		for i, reference := range work.References {
+			check(reference.DOI == "" || doiPattern.MatchString(reference.DOI), "citation %d of %s has the malformed doi %q", i+1, work.DOI, reference.DOI)
+			check(reference.DOI != "" || strings.TrimSpace(reference.Text) != "", "citation %d of %s has neither doi nor text", i+1, work.DOI)
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalid, strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+func validURL(rawURL string) bool {
+	if rawURL == "" || len(rawURL) > maxURLLength {
+		return false
+	}
+	u, err := url.Parse(rawURL)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// NormalizeORCID returns the https://orcid.org/ URI of the ORCID iD given bare or as the URI,
+// the iD with the wrong checksum is malformed
+func NormalizeORCID(orcid string) (string, bool) {
+	id := strings.TrimSpace(orcid)
+	for _, prefix := range []string{"https://orcid.org/", "http://orcid.org/", "orcid.org/"} {
+		id = strings.TrimPrefix(id, prefix)
+	}
+
+	uri := "https://orcid.org/" + strings.ToUpper(id)
+	if !orcidPattern.MatchString(uri) {
+		return "", false
+	}
+
+	// the check digit is ISO 7064 11,2 of the preceding digits
+	digits := strings.ReplaceAll(uri[len("https://orcid.org/"):], "-", "")
+	total := 0
+	for _, digit := range digits[:len(digits)-1] {
+		total = (total + int(digit-'0')) * 2
+	}
+	check := (12 - total%11) % 11
+	want := byte('0' + check)
+	if check == 10 {
+		want = 'X'
+	}
+
+	if digits[len(digits)-1] != want {
+		return "", false
+	}
+
+	return uri, true
+}
@@ -0,0 +1,253 @@
+package srv
+
+import (
+	"context"
+	"time"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+)
+
+// topics of the review deadline events
+const (
+	ReviewReminderTopic   = "review:reminder"
+	ReviewOverdueTopic    = "review:overdue"
+	ReviewReassignedTopic = "review:reassigned"
+)
+
+// ReviewDeadlineEvent is emitted to notify the validator about the review deadline
+type ReviewDeadlineEvent struct {
+	ReviewID         string    `json:"review_id"`
+	WorkID           string    `json:"work_id"`
+	ValidatorAddress string    `json:"validator_address"`
+	DueAt            time.Time `json:"due_date"`
+}
+
+// reviewDueAt returns the deadline of the review assigned now
+func (ls *LibrarySrv) reviewDueAt() time.Time {
+	return time.Now().UTC().AddDate(0, 0, int(ls.cfg.ReviewDueDays))
+}
+
+// CheckReviewDeadlines reminds validators about the approaching deadlines, marks
+// the reviews overdue and reassigns them after the grace period if it is enabled.
+func (ls *LibrarySrv) CheckReviewDeadlines() {
+	reviews, err := ls.storage.GetUnfinishedParticipantsWorkReviews()
+	if err != nil {
+		ls.log.Errorf("CheckReviewDeadlines: error get unfinished reviews, err: %v", err)
+
+		return
+	}
+
+	var (
+		now        = time.Now().UTC()
+		remindFrom = time.Duration(ls.cfg.ReviewReminderHours) * time.Hour
+		grace      = time.Duration(ls.cfg.ReviewGraceDays) * 24 * time.Hour
+	)
+	for _, review := range reviews {
+		// the reviews created before deadlines were introduced
+		if review.DueAt.IsZero() {
+			review.DueAt = review.CreatedAt.UTC().AddDate(0, 0, int(ls.cfg.ReviewDueDays))
+			if err := ls.storage.UpdateParticipantsWorkReviewDeadline(review); err != nil {
+				ls.log.Errorf("CheckReviewDeadlines: error set deadline of review %s, err: %v", review.ID, err)
+
+				continue
+			}
+		}
+
+		switch {
+		case review.Status == storage.WorkReviewOverdue:
+			if ls.cfg.ReviewReassign && now.After(review.DueAt.Add(grace)) {
+				ls.reassignReview(review)
+			}
+
+		case now.After(review.DueAt):
+			review.Status = storage.WorkReviewOverdue
+			review.OverdueAt = &now
+			if err := ls.storage.UpdateParticipantsWorkReviewDeadline(review); err != nil {
+				ls.log.Errorf("CheckReviewDeadlines: error mark review %s overdue, err: %v", review.ID, err)
+
+				continue
+			}
+
+			ls.notifyValidator(ReviewOverdueTopic, review)
+
+		case review.RemindedAt == nil && now.After(review.DueAt.Add(-remindFrom)):
+			review.RemindedAt = &now
+			if err := ls.storage.UpdateParticipantsWorkReviewDeadline(review); err != nil {
+				ls.log.Errorf("CheckReviewDeadlines: error mark review %s reminded, err: %v", review.ID, err)
+
+				continue
+			}
+
+			ls.notifyValidator(ReviewReminderTopic, review)
+		}
+	}
+}
+
+// reassignReview gives the overdue review to another eligible validator
+func (ls *LibrarySrv) reassignReview(review *storage.ParticipantsWorkReview) {
+	validator := ls.findEligibleValidator(review.WorkID)
+	if validator == nil {
+		ls.log.Warnf("reassignReview: there is no eligible validator for work %s", review.WorkID)
+
+		return
+	}
+
+	review.Status = storage.WorkReviewReassigned
+	if err := ls.storage.UpdateParticipantsWorkReviewDeadline(review); err != nil {
+		ls.log.Errorf("reassignReview: error update review %s, err: %v", review.ID, err)
+
+		return
+	}
+
+	ctx := context.Background()
+	if workReview, err := ls.storage.GetWorkReviewByID(ctx, review.ID); err == nil && workReview != nil {
+		workReview.Status = storage.WorkReviewReassigned
+		if err := ls.storage.UpdateWorkReview(ctx, workReview); err != nil {
+			ls.log.Errorf("reassignReview: error update work review %s, err: %v", review.ID, err)
+		}
+	}
+
+	ls.notifyValidator(ReviewReassignedTopic, review)
+
+	newReview, err := ls.storage.CreateParticipantsWorkReview(validator.ID, review.WorkID, ls.reviewDueAt())
+	if err != nil {
+		ls.log.Errorf("reassignReview: error assign work %s to validator %s, err: %v", review.WorkID, validator.Web3Address, err)
+
+		return
+	}
+
+	ls.log.Infof("reassignReview: work %s was reassigned to validator %s", review.WorkID, validator.Web3Address)
+	ls.notifyValidator(ReviewReminderTopic, newReview)
+}
+
+// findEligibleValidator returns the validator who neither wrote nor reviews the work,
+// the validators of the work's science, with higher reputation and fewer unfinished reviews are preferred.
+func (ls *LibrarySrv) findEligibleValidator(workID string) *storage.Participant {
+	participantsWork, err := ls.storage.GetParticipantWorkByID(workID)
+	if err != nil {
+		ls.log.Errorf("findEligibleValidator: error get participant work %s, err: %v", workID, err)
+
+		return nil
+	}
+
+	reviews, err := ls.storage.FindParticipantsWorkReviews(workID)
+	if err != nil {
+		ls.log.Errorf("findEligibleValidator: error get reviews of work %s, err: %v", workID, err)
+
+		return nil
+	}
+
+	excluded := map[string]bool{participantsWork.ParticipantID: true}
+	for _, review := range reviews {
+		excluded[review.ParticipantID] = true
+	}
+
+	var science string
+	if work, err := ls.storage.GetWorkByID(context.Background(), workID); err == nil && work != nil {
+		science = work.Work.Science
+	}
+
+	var (
+		best      *storage.Participant
+		bestMatch bool
+		bestScore float64
+		bestLoad  int64
+	)
+	for _, candidate := range ls.storage.GetParticipantsByRole(storage.ValidatorRole) {
+		if excluded[candidate.ID] {
+			continue
+		}
+
+		stats, err := ls.storage.GetValidatorReviewStats(candidate.ID)
+		if err != nil {
+			continue
+		}
+		load := stats.InProgress + stats.Overdue
+
+		var match bool
+		if science != "" {
+			if validator, err := ls.storage.GetValidatorById(context.Background(), candidate.ID); err == nil && validator != nil {
+				match = contains(validator.Sciences, science)
+			}
+		}
+
+		score := ls.validatorReputationScore(candidate.ID)
+
+		switch {
+		case best == nil,
+			match && !bestMatch,
+			match == bestMatch && score > bestScore,
+			match == bestMatch && score == bestScore && load < bestLoad:
+			best, bestMatch, bestScore, bestLoad = candidate, match, score, load
+		}
+	}
+
+	return best
+}
+
+// notifyValidator saves the notice the validator fetches and emits the event
+func (ls *LibrarySrv) notifyValidator(topic string, review *storage.ParticipantsWorkReview) {
+	if err := ls.storage.CreateReviewNotification(&storage.ReviewNotification{
+		ParticipantID: review.ParticipantID,
+		ReviewID:      review.ID,
+		WorkID:        review.WorkID,
+		Topic:         topic,
+		DueAt:         review.DueAt,
+	}); err != nil {
+		ls.log.Errorf("notifyValidator: error save %s of review %s, err: %v", topic, review.ID, err)
+	}
+
+	event := &ReviewDeadlineEvent{
+		ReviewID: review.ID,
+		WorkID:   review.WorkID,
+		DueAt:    review.DueAt,
+	}
+	if validator := ls.storage.GetParticipantById(review.ParticipantID); validator != nil {
+		event.ValidatorAddress = validator.Web3Address
+	}
+
+	ls.log.Infof("notifyValidator: %s, review %s of work %s, validator %s, due date %s",
+		topic, event.ReviewID, event.WorkID, event.ValidatorAddress, event.DueAt.Format(time.RFC3339))
+	ls.events.Emit(topic, event)
+}
+
+// GetReviewNotifications returns the notices of the review deadlines of the validator, the latest first
+func (ls *LibrarySrv) GetReviewNotifications(validatorAddress string, unread bool) ([]*storage.ReviewNotification, error) {
+	participant, err := ls.storage.GetParticipantByAddress(validatorAddress)
+	if err != nil {
+		ls.log.Errorf("GetReviewNotifications: error get participant with address %s, err: %v", validatorAddress, err)
+
+		return nil, err
+	}
+
+	notifications, err := ls.storage.GetReviewNotifications(participant.ID, unread)
+	if err != nil {
+		ls.log.Errorf("GetReviewNotifications: error get notifications of %s, err: %v", validatorAddress, err)
+
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+// ReadReviewNotification marks the notice of the validator read
+func (ls *LibrarySrv) ReadReviewNotification(validatorAddress, notificationID string) error {
+	participant, err := ls.storage.GetParticipantByAddress(validatorAddress)
+	if err != nil {
+		ls.log.Errorf("ReadReviewNotification: error get participant with address %s, err: %v", validatorAddress, err)
+
+		return err
+	}
+
+	return ls.storage.ReadReviewNotification(participant.ID, notificationID)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,332 @@
+package srv
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/citation"
+	"github.com/SeaOfWisdom/sow_library/src/service/crossref"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+
+	"github.com/google/uuid"
+)
+
+// the default number of the works deposited in one batch
+const defaultDepositBatchSize = 50
+
+// the failed deposit is retried after the interval, the metadata may be fixed meanwhile
+const depositRetryInterval = 24 * time.Hour
+
+// depositBatchID identifies the new batch, the id is unique per deposit
+func depositBatchID() string {
+	return "sow-" + uuid.New().String()
+}
+
+// newDepositBatch starts the batch of the works
+func (ls *LibrarySrv) newDepositBatch(batchID string, works []*crossref.Work) *crossref.Batch {
+	return &crossref.Batch{
+		ID:         batchID,
+		Timestamp:  time.Now(),
+		Depositor:  &crossref.Depositor{Name: ls.cfg.CrossrefDepositorName, Email: ls.cfg.CrossrefDepositorEmail},
+		Registrant: citation.Publisher,
+		Journal:    citation.Publisher,
+		ISSN:       ls.cfg.CrossrefISSN,
+		Works:      works,
+	}
+}
+
+// workPersistentID returns the DOI minted for the open work
+func (ls *LibrarySrv) workPersistentID(ctx context.Context, workID string) (*storage.PersistentID, error) {
+	workResp, err := ls.getOpenWork(ctx, workID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(workResp.Work.PID, "10.") {
+		return nil, ErrNoDOI
+	}
+
+	return ls.storage.GetPersistentID(workResp.Work.PID)
+}
+
+// depositWork collects the metadata of the open work identified by the DOI, the date of the
+// publication is the date the work was opened
+func (ls *LibrarySrv) depositWork(ctx context.Context, workID string) (*crossref.Work, error) {
+	workResp, err := ls.getOpenWork(ctx, workID)
+	if err != nil {
+		return nil, err
+	}
+
+	work := workResp.Work
+	if !strings.HasPrefix(work.PID, "10.") {
+		return nil, ErrNoDOI
+	}
+
+	stamp, err := ls.storage.GetOpenWorkStamp(workID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the works reserved by the authors and the ones under the custom terms are deposited without the license reference
+	deposit := &crossref.Work{
+		DOI:        work.PID,
+		URL:        ls.workURL(work.ID),
+		Title:      work.Name,
+		Abstract:   work.Annotation,
+		Published:  stamp.Datestamp,
+		LicenseURL: storage.LicenseOf(work).URL,
+	}
+	if language := strings.ToLower(work.Language); len(language) == 2 {
+		deposit.Language = language
+	}
+
+	if workResp.Author != nil {
+		deposit.Contributors = append(deposit.Contributors, ls.depositContributor(work.PID, workResp.Author.AuthorInfo))
+	}
+	for _, coAuthor := range work.CoAuthors {
+		if coAuthor.Name != "" || coAuthor.Surname != "" {
+			deposit.Contributors = append(deposit.Contributors, ls.depositContributor(work.PID, coAuthor))
+		}
+	}
+
+	references, err := ls.storage.GetWorkReferences(work.ID)
+	if err != nil {
+		ls.log.Errorf("depositWork: error get references of work %s, err: %v", work.ID, err)
+
+		return nil, err
+	}
+	for _, reference := range references {
+		entry := &crossref.Reference{DOI: reference.DOI, Text: reference.Text}
+		if entry.DOI == "" && reference.CitedWorkID != "" {
+			if cited := ls.citedWork(ctx, "", reference.CitedWorkID); cited != nil {
+				if strings.HasPrefix(cited.Work.PID, "10.") {
+					entry.DOI = cited.Work.PID
+				} else if entry.Text == "" {
+					entry.Text = citation.APA(ls.citationItem(cited))
+				}
+			}
+		}
+		if entry.DOI == "" && entry.Text == "" {
+			entry.Text = reference.URL
+		}
+		if entry.DOI != "" || entry.Text != "" {
+			deposit.References = append(deposit.References, entry)
+		}
+	}
+
+	return deposit, nil
+}
+
+// depositContributor returns the author, the author without the surname is deposited as anonymous
+// and the malformed ORCID is dropped
+func (ls *LibrarySrv) depositContributor(doi string, author *storage.Author) *crossref.Contributor {
+	if author == nil || author.Surname == "" {
+		return &crossref.Contributor{}
+	}
+
+	contributor := &crossref.Contributor{Given: author.Name, Family: author.Surname}
+	if author.MiddleName != "" {
+		contributor.Given = strings.TrimSpace(author.Name + " " + author.MiddleName)
+	}
+	if author.Orcid != "" {
+		orcid, ok := crossref.NormalizeORCID(author.Orcid)
+		if !ok {
+			ls.log.Warnf("depositContributor: malformed ORCID %q of the author of %s", author.Orcid, doi)
+		}
+		contributor.ORCID = orcid
+	}
+
+	return contributor
+}
+
+// DepositXML generates the deposit of the open works identified by the DOIs, the deposit isn't submitted
+func (ls *LibrarySrv) DepositXML(ctx context.Context, workIDs []string) ([]byte, error) {
+	works := make([]*crossref.Work, 0, len(workIDs))
+	for _, workID := range workIDs {
+		work, err := ls.depositWork(ctx, workID)
+		if err != nil {
+			return nil, err
+		}
+		works = append(works, work)
+	}
+
+	return crossref.Marshal(ls.newDepositBatch(depositBatchID(), works))
+}
+
+// DepositWorks submits the deposit of the open works identified by the DOIs, the works deposited before
+// are deposited again. The works whose metadata doesn't match the schema fail at once.
+func (ls *LibrarySrv) DepositWorks(ctx context.Context, workIDs []string) ([]*storage.WorkDeposit, error) {
+	if ls.crossref == nil {
+		return nil, ErrDepositsDisabled
+	}
+
+	persistentIDs := make([]*storage.PersistentID, 0, len(workIDs))
+	for _, workID := range workIDs {
+		persistentID, err := ls.workPersistentID(ctx, workID)
+		if err != nil {
+			return nil, err
+		}
+		persistentIDs = append(persistentIDs, persistentID)
+	}
+
+	batchID, err := ls.startDeposit(ctx, persistentIDs)
+	if err != nil {
+		ls.log.Errorf("DepositWorks: error deposit batch %s, err: %v", batchID, err)
+
+		if batchID == "" {
+			return nil, err
+		}
+	}
+
+	return ls.storage.GetBatchDeposits(batchID)
+}
+
+// GetWorkDeposits returns the deposits of the work and(or) in the status
+func (ls *LibrarySrv) GetWorkDeposits(workID string, status storage.DepositStatus) ([]*storage.WorkDeposit, error) {
+	deposits, err := ls.storage.GetWorkDeposits(workID, status)
+	if err != nil {
+		ls.log.Errorf("GetWorkDeposits: error get deposits, err: %v", err)
+
+		return nil, err
+	}
+
+	return deposits, nil
+}
+
+// startDeposit saves the pending deposits of the new batch and submits it
+func (ls *LibrarySrv) startDeposit(ctx context.Context, persistentIDs []*storage.PersistentID) (string, error) {
+	batchID := depositBatchID()
+	deposits, err := ls.storage.CreateWorkDeposits(batchID, persistentIDs)
+	if err != nil {
+		return "", err
+	}
+
+	return batchID, ls.submitDeposit(ctx, batchID, deposits)
+}
+
+// submitDeposit submits the pending deposits of the batch, the deposits stay pending if the submission
+// has failed, so the batch is submitted again by the cron
+func (ls *LibrarySrv) submitDeposit(ctx context.Context, batchID string, deposits []*storage.WorkDeposit) error {
+	var works []*crossref.Work
+	for _, deposit := range deposits {
+		if deposit.Status != storage.DepositPending {
+			continue
+		}
+
+		work, err := ls.depositWork(ctx, deposit.WorkID)
+		if err == nil {
+			err = crossref.Validate(ls.newDepositBatch(batchID, []*crossref.Work{work}))
+		}
+		if err != nil {
+			if err := ls.storage.SetWorkDepositStatus(batchID, deposit.PID, storage.DepositFailed, err.Error()); err != nil {
+				return err
+			}
+			continue
+		}
+		works = append(works, work)
+	}
+
+	if len(works) == 0 {
+		return nil
+	}
+
+	data, err := crossref.Marshal(ls.newDepositBatch(batchID, works))
+	if err != nil {
+		return err
+	}
+
+	if err := ls.crossref.Submit(ctx, batchID, data); err != nil {
+		if err := ls.storage.SetBatchDepositStatus(batchID, storage.DepositPending, err.Error()); err != nil {
+			return err
+		}
+
+		return err
+	}
+
+	return ls.storage.SetBatchDepositStatus(batchID, storage.DepositSubmitted, "")
+}
+
+// checkDeposit saves the results of the submitted batch once it's processed, the deposits the
+// result doesn't report on fail
+func (ls *LibrarySrv) checkDeposit(ctx context.Context, batchID string) error {
+	result, err := ls.crossref.Result(ctx, batchID)
+	if err != nil {
+		return err
+	}
+
+	if result.Status != crossref.BatchCompleted {
+		return nil
+	}
+
+	for _, record := range result.Records {
+		status := storage.DepositSucceeded
+		if record.Status == crossref.RecordFailure {
+			status = storage.DepositFailed
+		}
+		if err := ls.storage.SetWorkDepositStatus(batchID, record.DOI, status, strings.TrimSpace(record.Message)); err != nil {
+			return err
+		}
+	}
+
+	return ls.storage.SetBatchDepositStatus(batchID, storage.DepositFailed, "the result of the batch has no record of the DOI")
+}
+
+// depositIdentifiedWorks submits the batches which failed to be submitted, checks the results of
+// the submitted ones and deposits the identified works which have never been deposited or whose
+// deposit has failed a while ago
+func (ls *LibrarySrv) depositIdentifiedWorks(ctx context.Context) {
+	if ls.crossref == nil || !strings.HasPrefix(ls.cfg.PIDPrefix, "10.") {
+		return
+	}
+
+	pendingIDs, err := ls.storage.GetDepositBatchIDs(storage.DepositPending)
+	if err != nil {
+		ls.log.Errorf("depositIdentifiedWorks: error get pending deposits, err: %v", err)
+
+		return
+	}
+
+	for _, batchID := range pendingIDs {
+		deposits, err := ls.storage.GetBatchDeposits(batchID)
+		if err == nil {
+			err = ls.submitDeposit(ctx, batchID, deposits)
+		}
+		if err != nil {
+			ls.log.Errorf("depositIdentifiedWorks: error submit batch %s, err: %v", batchID, err)
+		}
+	}
+
+	submittedIDs, err := ls.storage.GetDepositBatchIDs(storage.DepositSubmitted)
+	if err != nil {
+		ls.log.Errorf("depositIdentifiedWorks: error get submitted deposits, err: %v", err)
+
+		return
+	}
+
+	for _, batchID := range submittedIDs {
+		if err := ls.checkDeposit(ctx, batchID); err != nil {
+			ls.log.Errorf("depositIdentifiedWorks: error check batch %s, err: %v", batchID, err)
+		}
+	}
+
+	batchSize := ls.cfg.CrossrefBatchSize
+	if batchSize <= 0 {
+		batchSize = defaultDepositBatchSize
+	}
+
+	persistentIDs, err := ls.storage.GetUndepositedPersistentIDs(batchSize, time.Now().Add(-depositRetryInterval))
+	if err != nil {
+		ls.log.Errorf("depositIdentifiedWorks: error get undeposited works, err: %v", err)
+
+		return
+	}
+
+	if len(persistentIDs) == 0 {
+		return
+	}
+
+	if batchID, err := ls.startDeposit(ctx, persistentIDs); err != nil {
+		ls.log.Errorf("depositIdentifiedWorks: error deposit batch %s, err: %v", batchID, err)
+	}
+}
@@ -0,0 +1,357 @@
+package srv
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/ingest"
+	"github.com/SeaOfWisdom/sow_library/src/service/render"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+	ocr "github.com/SeaOfWisdom/sow_proto/ocr-srv"
+
+	"github.com/google/uuid"
+)
+
+// the recognition of a long paper may take minutes
+const extractionTimeout = 10 * time.Minute
+
+// CreateDraftFromPaper saves the paper to the blob store and starts its recognition
+// into the draft work, the progress is tracked by the returned extraction.
+func (ls *LibrarySrv) CreateDraftFromPaper(ctx context.Context, authorAddress, name string, data []byte) (*storage.DocumentExtraction, error) {
+	participant, err := ls.storage.GetParticipantByAddress(authorAddress)
+	if err != nil {
+		ls.log.Errorf("CreateDraftFromPaper: error get participant with address %s, err: %v", authorAddress, err)
+
+		return nil, err
+	}
+
+	if participant.Role < storage.AuthorRole {
+		return nil, ErrNotAuthor
+	}
+
+	record, err := ls.saveBlob(ctx, participant.ID, storage.WorkDocumentBlob, name, data, "")
+	if err != nil {
+		return nil, err
+	}
+
+	extraction := &storage.DocumentExtraction{
+		ID:            uuid.New().String(),
+		ParticipantID: participant.ID,
+		BlobID:        record.ID,
+		Status:        storage.ExtractionPending,
+	}
+	if err := ls.storage.CreateExtraction(extraction); err != nil {
+		ls.log.Errorf("CreateDraftFromPaper: error create extraction, err: %v", err)
+
+		return nil, err
+	}
+
+	if !ls.trackExtraction() {
+		extraction.Status = storage.ExtractionFailed
+		extraction.Error = ErrServiceStopped.Error()
+		ls.updateExtraction(extraction)
+
+		return nil, ErrServiceStopped
+	}
+
+	go func() {
+		defer ls.extractions.Done()
+		ls.extractDraft(*extraction, record.ContentType, data)
+	}()
+
+	return extraction, nil
+}
+
+// trackExtraction adds the extraction to the ones waited for on the stop,
+// no extraction is started once the service is stopping
+func (ls *LibrarySrv) trackExtraction() bool {
+	ls.stopMu.Lock()
+	defer ls.stopMu.Unlock()
+
+	if ls.stopCtx.Err() != nil {
+		return false
+	}
+	ls.extractions.Add(1)
+
+	return true
+}
+
+// extractDraft recognizes the paper and creates the draft work of the author,
+// PDF is processed page by page, the image is recognized as a whole
+func (ls *LibrarySrv) extractDraft(extraction storage.DocumentExtraction, contentType string, data []byte) {
+	ctx, cancel := context.WithTimeout(ls.stopCtx, extractionTimeout)
+	defer cancel()
+
+	extraction.Status = storage.ExtractionRunning
+	ls.updateExtraction(&extraction)
+
+	var (
+		work *storage.Work
+		err  error
+	)
+	if contentType == ingest.PDFContentType {
+		work, err = ls.ingestPaper(ctx, &extraction, data)
+	} else {
+		work, err = ls.recognizePaper(ctx, data)
+	}
+
+	if err == nil {
+		extraction.WorkID, err = ls.storage.CreateDraftWork(ctx, extraction.ParticipantID, work)
+	}
+	if err != nil {
+		ls.log.Errorf("extractDraft: extraction %s has failed, err: %v", extraction.ID, err)
+		extraction.Status = storage.ExtractionFailed
+		extraction.Error = err.Error()
+		ls.updateExtraction(&extraction)
+
+		return
+	}
+
+	// the recognized text is kept for the bibliography the author may edit out of the draft
+	if work.Content != nil && work.Content.WorkData != "" {
+		if err := ls.storage.SetExtractionText(extraction.ID, work, work.Content.WorkData); err != nil {
+			ls.log.Errorf("extractDraft: error save text of extraction %s, err: %v", extraction.ID, err)
+		}
+	}
+
+	if err := ls.storage.LinkBlobToWork(extraction.BlobID, extraction.WorkID); err != nil {
+		ls.log.Errorf("extractDraft: error link blob %s to work %s, err: %v", extraction.BlobID, extraction.WorkID, err)
+	}
+
+	extraction.Status = storage.ExtractionDone
+	ls.updateExtraction(&extraction)
+}
+
+func (ls *LibrarySrv) updateExtraction(extraction *storage.DocumentExtraction) {
+	if err := ls.storage.UpdateExtraction(extraction); err != nil {
+		ls.log.Errorf("updateExtraction: error update extraction %s, err: %v", extraction.ID, err)
+	}
+}
+
+// recognizePaper recognizes the image of the paper via OCR
+func (ls *LibrarySrv) recognizePaper(ctx context.Context, data []byte) (*storage.Work, error) {
+	resp, err := ls.ocrSrv.ExtractText(ctx, &ocr.ExtractTextRequest{
+		Image:   data,
+		IsPaper: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &storage.Work{
+		Name:       resp.Title,
+		Annotation: resp.Abstract,
+		Tags:       resp.Keywords,
+		Content:    &storage.WorkContent{WorkData: mainText(resp.Main), Format: render.PlainFormat},
+	}, nil
+}
+
+// ingestPaper extracts the text of the PDF pages, the progress of each page is saved
+// to the extraction. The pages are reassembled into the sections, the abstract goes
+// to the annotation and the rest sections go to the content.
+func (ls *LibrarySrv) ingestPaper(ctx context.Context, extraction *storage.DocumentExtraction, data []byte) (*storage.Work, error) {
+	doc, err := ingest.Open(data)
+	if err != nil {
+		return nil, err
+	}
+
+	extraction.TotalPages = doc.NumPages()
+	if err := ls.storage.CreateExtractionPages(extraction.ID, extraction.TotalPages); err != nil {
+		return nil, err
+	}
+	ls.updateExtraction(extraction)
+
+	pages := ls.ingest.Process(ctx, doc, func(page *ingest.Page) {
+		pageStatus := &storage.ExtractionPage{
+			Number: page.Number,
+			Status: storage.ExtractionDone,
+			Source: string(page.Source),
+		}
+		if page.Err != nil {
+			pageStatus.Status = storage.ExtractionFailed
+			pageStatus.Error = page.Err.Error()
+			extraction.FailedPages++
+		}
+		extraction.ProcessedPages++
+
+		if err := ls.storage.UpdateExtractionPage(extraction.ID, pageStatus); err != nil {
+			ls.log.Errorf("ingestPaper: error update page %d of extraction %s, err: %v", page.Number, extraction.ID, err)
+		}
+		ls.updateExtraction(extraction)
+	})
+
+	if extraction.FailedPages == len(pages) {
+		return nil, ErrNoPagesExtracted
+	}
+
+	work := &storage.Work{Content: &storage.WorkContent{Format: render.PlainFormat}}
+	sections := ingest.Sections(pages)
+	work.Name = ingest.Title(sections)
+
+	var content []string
+	for _, section := range sections {
+		if work.Annotation == "" && isAbstract(section.Title) {
+			work.Annotation = section.Text
+			continue
+		}
+		if section.Title != "" {
+			content = append(content, section.Title+"\n\n"+section.Text)
+		} else {
+			content = append(content, section.Text)
+		}
+	}
+	work.Content.WorkData = strings.Join(content, "\n\n")
+
+	return work, nil
+}
+
+func isAbstract(title string) bool {
+	title = strings.ToLower(title)
+	return title == "abstract" || title == "аннотация"
+}
+
+// ocrRecognizer recognizes the scans of the PDF pages via the OCR service
+type ocrRecognizer struct {
+	ocrSrv ocr.OCRClient
+}
+
+func (r ocrRecognizer) Recognize(ctx context.Context, image []byte) (string, error) {
+	resp, err := r.ocrSrv.ExtractText(ctx, &ocr.ExtractTextRequest{Image: image})
+	if err != nil {
+		return "", err
+	}
+
+	var parts []string
+	for _, part := range []string{resp.Title, resp.Abstract, mainText(resp.Main)} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	return strings.Join(parts, "\n"), nil
+}
+
+// mainText joins the recognized sections of the paper, ordered by their names
+func mainText(sections map[string]string) string {
+	names := make([]string, 0, len(sections))
+	for name := range sections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, name+"\n\n"+sections[name])
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// GetExtraction returns the extraction to the participant who has uploaded the paper or to an admin
+func (ls *LibrarySrv) GetExtraction(ctx context.Context, participantAddress, extractionID string) (*storage.DocumentExtraction, error) {
+	participant, err := ls.storage.GetParticipantByAddress(participantAddress)
+	if err != nil {
+		ls.log.Errorf("GetExtraction: error get participant with address %s, err: %v", participantAddress, err)
+
+		return nil, err
+	}
+
+	extraction, err := ls.storage.GetExtraction(extractionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if extraction.ParticipantID != participant.ID && participant.Role < storage.AdminRole {
+		return nil, ErrBlobAccessDenied
+	}
+
+	return extraction, nil
+}
+
+// getDraft returns the draft if the participant is its author
+func (ls *LibrarySrv) getDraft(ctx context.Context, authorAddress, workID string) (*storage.WorkResponse, error) {
+	participant, err := ls.storage.GetParticipantByAddress(authorAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	participantsWork, err := ls.storage.GetParticipantWorkByID(workID)
+	if err != nil {
+		return nil, err
+	}
+
+	if participantsWork.ParticipantID != participant.ID {
+		return nil, ErrDraftAccessDenied
+	}
+
+	if participantsWork.Status != storage.DraftWorkStatus {
+		return nil, ErrNotDraft
+	}
+
+	return ls.storage.GetWorkByID(ctx, workID)
+}
+
+// UpdateDraft saves the author's corrections of the recognized draft
+func (ls *LibrarySrv) UpdateDraft(ctx context.Context, authorAddress, workID string, work *storage.Work) (*storage.WorkResponse, error) {
+	draft, err := ls.getDraft(ctx, authorAddress, workID)
+	if err != nil {
+		ls.log.Errorf("UpdateDraft: error get draft %s, err: %v", workID, err)
+
+		return nil, err
+	}
+
+	draft.Work.Name = work.Name
+	draft.Work.Annotation = work.Annotation
+	draft.Work.Tags = work.Tags
+	draft.Work.Sources = work.Sources
+	draft.Work.Language = work.Language
+	draft.Work.Science = work.Science
+	draft.Work.ReviewMode = work.ReviewMode
+	draft.Work.License = work.License
+	draft.Work.OpenAccess = work.OpenAccess
+	draft.Work.CoAuthors = work.CoAuthors
+	draft.Work.Content = work.Content
+	if err := ls.checkLicense(draft.Work); err != nil {
+		return nil, err
+	}
+	if err := ls.storage.UpdateWork(ctx, draft.Work); err != nil {
+		ls.log.Errorf("UpdateDraft: error update work %s, err: %v", workID, err)
+
+		return nil, err
+	}
+
+	return draft, nil
+}
+
+// SubmitDraft sends the draft to the review the same way as a new work
+func (ls *LibrarySrv) SubmitDraft(ctx context.Context, authorAddress, workID string) (*storage.WorkResponse, error) {
+	draft, err := ls.getDraft(ctx, authorAddress, workID)
+	if err != nil {
+		ls.log.Errorf("SubmitDraft: error get draft %s, err: %v", workID, err)
+
+		return nil, err
+	}
+
+	if draft.Work.Name == "" || draft.Work.Annotation == "" ||
+		draft.Work.Content == nil || draft.Work.Content.WorkData == "" {
+		return nil, ErrIncompleteDraft
+	}
+
+	// the licensing rules may have changed since the draft was saved
+	if err := ls.checkLicense(draft.Work); err != nil {
+		return nil, err
+	}
+
+	ls.setWorkScience(ctx, draft.Work.AuthorID, draft.Work)
+
+	if err := ls.storage.SubmitDraftWork(ctx, workID, draft.Work.Science); err != nil {
+		ls.log.Errorf("SubmitDraft: error submit draft %s, err: %v", workID, err)
+
+		return nil, err
+	}
+	draft.Work.Status = storage.ReviewWorkStatus
+
+	return draft, nil
+}
@@ -0,0 +1,119 @@
+package envelope
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Algorithm is the cipher of the content and the data keys
+const Algorithm = "AES-256-GCM"
+
+const keySize = 32
+
+var ErrMalformed = errors.New("the ciphertext is malformed")
+
+// Keyring wraps the data keys by the master key
+type Keyring struct {
+	master cipher.AEAD
+}
+
+// NewKeyring creates the keyring of the hex encoded 256 bit master key
+func NewKeyring(masterKey string) (*Keyring, error) {
+	key, err := hex.DecodeString(masterKey)
+	if err != nil || len(key) != keySize {
+		return nil, fmt.Errorf("the master key must be %d hex encoded bytes", keySize)
+	}
+
+	master, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Keyring{master: master}, nil
+}
+
+// NewDataKey generates the data key and returns it with its wrapped form to store
+func (k *Keyring) NewDataKey() (key, wrapped []byte, err error) {
+	key = make([]byte, keySize)
+	if _, err = io.ReadFull(rand.Reader, key); err != nil {
+		return nil, nil, err
+	}
+
+	if wrapped, err = k.Wrap(key); err != nil {
+		return nil, nil, err
+	}
+
+	return key, wrapped, nil
+}
+
+// Wrap wraps the data key by the master key. When the master key is rotated the data
+// keys unwrapped by the previous keyring are wrapped again, the content isn't resealed.
+func (k *Keyring) Wrap(key []byte) ([]byte, error) {
+	if len(key) != keySize {
+		return nil, fmt.Errorf("the data key must be %d bytes", keySize)
+	}
+
+	return seal(k.master, key)
+}
+
+// Unwrap returns the data key wrapped by the master key
+func (k *Keyring) Unwrap(wrapped []byte) ([]byte, error) {
+	return open(k.master, wrapped)
+}
+
+// Seal encrypts the data by the data key, the nonce is prepended to the ciphertext
+func Seal(key, data []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return seal(aead, data)
+}
+
+// Open decrypts the data sealed by the data key
+func Open(key, ciphertext []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return open(aead, ciphertext)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func seal(aead cipher.AEAD, data []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(data)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, data, nil), nil
+}
+
+func open(aead cipher.AEAD, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize()+aead.Overhead() {
+		return nil, ErrMalformed
+	}
+
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	data, err := aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, ErrMalformed
+	}
+
+	return data, nil
+}
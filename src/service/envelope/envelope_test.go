@@ -0,0 +1,199 @@
+package envelope
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const (
+	masterKey     = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+	nextMasterKey = "1f1e1d1c1b1a191817161514131211100f0e0d0c0b0a09080706050403020100"
+)
+
+func newKeyring(t *testing.T, masterKey string) *Keyring {
+	t.Helper()
+
+	keyring, err := NewKeyring(masterKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keyring
+}
+
+func TestNewKeyring(t *testing.T) {
+	tests := []struct {
+		name      string
+		masterKey string
+		valid     bool
+	}{
+		{"valid", masterKey, true},
+		{"upper case", strings.ToUpper(masterKey), true},
+		{"empty", "", false},
+		{"short", masterKey[:32], false},
+		{"long", masterKey + "00", false},
+		{"not hex", strings.Repeat("zz", keySize), false},
+	}
+
+	for _, test := range tests {
+		if _, err := NewKeyring(test.masterKey); (err == nil) != test.valid {
+			t.Errorf("%s: err = %v", test.name, err)
+		}
+	}
+}
+
+func TestSealOpen(t *testing.T) {
+	key, _, err := newKeyring(t, masterKey).NewDataKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, data := range []string{"", "# The work", strings.Repeat("Море мудрости ", 1000)} {
+		sealed, err := Seal(key, []byte(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(data) > 0 && bytes.Contains(sealed, []byte(data)) {
+			t.Error("the ciphertext contains the plain text")
+		}
+
+		opened, err := Open(key, sealed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(opened) != data {
+			t.Errorf("Open = %q, want %q", opened, data)
+		}
+	}
+
+	first, _ := Seal(key, []byte("# The work"))
+	second, _ := Seal(key, []byte("# The work"))
+	if bytes.Equal(first, second) {
+		t.Error("the data is sealed with the same nonce")
+	}
+}
+
+func TestOpenFails(t *testing.T) {
+	keyring := newKeyring(t, masterKey)
+	key, _, err := keyring.NewDataKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, _, err := keyring.NewDataKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sealed, err := Seal(key, []byte("# The work"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tampered := func(at int) []byte {
+		data := append([]byte(nil), sealed...)
+		data[at] ^= 1
+		return data
+	}
+
+	tests := []struct {
+		name       string
+		key        []byte
+		ciphertext []byte
+	}{
+		{"wrong key", otherKey, sealed},
+		{"tampered nonce", key, tampered(0)},
+		{"tampered content", key, tampered(len(sealed) / 2)},
+		{"tampered tag", key, tampered(len(sealed) - 1)},
+		{"truncated", key, sealed[:len(sealed)-1]},
+		{"short", key, sealed[:10]},
+		{"empty", key, nil},
+	}
+
+	for _, test := range tests {
+		if _, err := Open(test.key, test.ciphertext); !errors.Is(err, ErrMalformed) {
+			t.Errorf("%s: err = %v, want %v", test.name, err, ErrMalformed)
+		}
+	}
+
+	if _, err := Open(key[:10], sealed); err == nil {
+		t.Error("the ciphertext is opened by the invalid key")
+	}
+}
+
+func TestDataKey(t *testing.T) {
+	keyring := newKeyring(t, masterKey)
+
+	key, wrapped, err := keyring.NewDataKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(key) != keySize {
+		t.Fatalf("the data key is %d bytes", len(key))
+	}
+	if bytes.Contains(wrapped, key) {
+		t.Error("the wrapped key contains the data key")
+	}
+
+	unwrapped, err := keyring.Unwrap(wrapped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(unwrapped, key) {
+		t.Error("the unwrapped key differs from the data key")
+	}
+
+	// the same master key is loaded again after the restart
+	if unwrapped, err = newKeyring(t, masterKey).Unwrap(wrapped); err != nil || !bytes.Equal(unwrapped, key) {
+		t.Errorf("the data key isn't unwrapped by the same master key, err: %v", err)
+	}
+
+	if _, err := keyring.Wrap(key[:16]); err == nil {
+		t.Error("the short data key is wrapped")
+	}
+}
+
+// TestRotation checks the data keys wrapped again by the next master key open the content
+// sealed before the rotation and the previous master key doesn't unwrap them
+func TestRotation(t *testing.T) {
+	previous := newKeyring(t, masterKey)
+	next := newKeyring(t, nextMasterKey)
+
+	key, wrapped, err := previous.NewDataKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealed, err := Seal(key, []byte("# The work"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := next.Unwrap(wrapped); !errors.Is(err, ErrMalformed) {
+		t.Fatalf("the key wrapped by the previous master key is unwrapped by the next one, err: %v", err)
+	}
+
+	unwrapped, err := previous.Unwrap(wrapped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rewrapped, err := next.Wrap(unwrapped)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := previous.Unwrap(rewrapped); !errors.Is(err, ErrMalformed) {
+		t.Errorf("the rewrapped key is unwrapped by the previous master key, err: %v", err)
+	}
+
+	rotated, err := next.Unwrap(rewrapped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := Open(rotated, sealed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "# The work" {
+		t.Errorf("the content sealed before the rotation is %q", data)
+	}
+}
@@ -0,0 +1,113 @@
+package ethrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+var ErrTxNotFound = errors.New("the transaction is not found")
+
+// Client reads the chain via the Ethereum JSON-RPC API
+type Client struct {
+	url    string
+	client *http.Client
+	nextID uint64
+}
+
+func NewClient(rpcURL string) (*Client, error) {
+	u, err := url.Parse(rpcURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("wrong Ethereum RPC URL %q", rpcURL)
+	}
+
+	return &Client{
+		url:    rpcURL,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+type request struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      uint64        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type response struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (c *Client) call(ctx context.Context, result interface{}, method string, params ...interface{}) error {
+	body, err := json.Marshal(&request{
+		JSONRPC: "2.0",
+		ID:      atomic.AddUint64(&c.nextID, 1),
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: unexpected status %s", method, res.Status)
+	}
+
+	resp := new(response)
+	if err := json.NewDecoder(res.Body).Decode(resp); err != nil {
+		return fmt.Errorf("%s: wrong response, err: %v", method, err)
+	}
+
+	if resp.Error != nil {
+		return fmt.Errorf("%s: %s (%d)", method, resp.Error.Message, resp.Error.Code)
+	}
+
+	return json.Unmarshal(resp.Result, result)
+}
+
+type transaction struct {
+	Input string `json:"input"`
+}
+
+// TransactionInput returns the call data of the transaction
+func (c *Client) TransactionInput(ctx context.Context, txHash string) ([]byte, error) {
+	var tx *transaction
+	if err := c.call(ctx, &tx, "eth_getTransactionByHash", txHash); err != nil {
+		return nil, err
+	}
+
+	if tx == nil {
+		return nil, ErrTxNotFound
+	}
+
+	input, err := hex.DecodeString(strings.TrimPrefix(tx.Input, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("wrong input of the transaction %s, err: %v", txHash, err)
+	}
+
+	return input, nil
+}
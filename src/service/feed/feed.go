@@ -0,0 +1,225 @@
+package feed
+
+import (
+	"encoding/xml"
+	"errors"
+	"time"
+)
+
+// the syndication formats of the feeds
+const (
+	AtomFormat = "atom"
+	RSSFormat  = "rss"
+)
+
+var ErrUnknownFormat = errors.New("unknown feed format")
+
+const atomNamespace = "http://www.w3.org/2005/Atom"
+
+// Feed is the list of the newly opened works
+type Feed struct {
+	// ID is the permanent IRI of the feed, the URL of the feed itself
+	ID       string
+	Title    string
+	Subtitle string
+	// Link is the page of the library the feed is about
+	Link string
+	// Publisher is the author of the feed, the entries may have no authors
+	Publisher string
+	Language  string
+	Updated   time.Time
+	Entries   []*Entry
+}
+
+// Entry is the opened work
+type Entry struct {
+	ID         string
+	Title      string
+	Link       string
+	Summary    string
+	Authors    []string
+	Categories []string
+	Published  time.Time
+	Updated    time.Time
+}
+
+// ContentType returns the MIME type of the feed format
+func ContentType(format string) string {
+	if format == RSSFormat {
+		return "application/rss+xml; charset=utf-8"
+	}
+	return "application/atom+xml; charset=utf-8"
+}
+
+// Marshal writes the feed in the format
+func Marshal(feed *Feed, format string) ([]byte, error) {
+	var document interface{}
+	switch format {
+	case AtomFormat:
+		document = atom(feed)
+	case RSSFormat:
+		document = rss(feed)
+	default:
+		return nil, ErrUnknownFormat
+	}
+
+	data, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomText struct {
+	Type string `xml:"type,attr,omitempty"`
+	Text string `xml:",chardata"`
+}
+
+type atomEntry struct {
+	ID         string          `xml:"id"`
+	Title      atomText        `xml:"title"`
+	Links      []*atomLink     `xml:"link"`
+	Authors    []*atomPerson   `xml:"author"`
+	Categories []*atomCategory `xml:"category"`
+	Published  string          `xml:"published"`
+	Updated    string          `xml:"updated"`
+	Summary    *atomText       `xml:"summary,omitempty"`
+}
+
+type atomFeed struct {
+	XMLName  xml.Name     `xml:"feed"`
+	Xmlns    string       `xml:"xmlns,attr"`
+	Lang     string       `xml:"xml:lang,attr,omitempty"`
+	ID       string       `xml:"id"`
+	Title    atomText     `xml:"title"`
+	Subtitle *atomText    `xml:"subtitle,omitempty"`
+	Links    []*atomLink  `xml:"link"`
+	Updated  string       `xml:"updated"`
+	Author   *atomPerson  `xml:"author"`
+	Entries  []*atomEntry `xml:"entry"`
+}
+
+// atom converts the feed to Atom 1.0
+func atom(feed *Feed) *atomFeed {
+	document := &atomFeed{
+		Xmlns:   atomNamespace,
+		Lang:    feed.Language,
+		ID:      feed.ID,
+		Title:   atomText{Type: "text", Text: feed.Title},
+		Updated: feed.Updated.UTC().Format(time.RFC3339),
+		Author:  &atomPerson{Name: feed.Publisher},
+		Links: []*atomLink{
+			{Rel: "self", Type: ContentType(AtomFormat), Href: feed.ID},
+			{Rel: "alternate", Type: "text/html", Href: feed.Link},
+		},
+	}
+	if feed.Subtitle != "" {
+		document.Subtitle = &atomText{Type: "text", Text: feed.Subtitle}
+	}
+
+	for _, entry := range feed.Entries {
+		item := &atomEntry{
+			ID:        entry.ID,
+			Title:     atomText{Type: "text", Text: entry.Title},
+			Links:     []*atomLink{{Rel: "alternate", Type: "text/html", Href: entry.Link}},
+			Published: entry.Published.UTC().Format(time.RFC3339),
+			Updated:   entry.Updated.UTC().Format(time.RFC3339),
+		}
+		if entry.Summary != "" {
+			item.Summary = &atomText{Type: "text", Text: entry.Summary}
+		}
+		for _, author := range entry.Authors {
+			item.Authors = append(item.Authors, &atomPerson{Name: author})
+		}
+		for _, category := range entry.Categories {
+			item.Categories = append(item.Categories, &atomCategory{Term: category})
+		}
+		document.Entries = append(document.Entries, item)
+	}
+
+	return document
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	Description string   `xml:"description,omitempty"`
+	Creators    []string `xml:"dc:creator"`
+	Categories  []string `xml:"category"`
+	GUID        rssGUID  `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+}
+
+type rssChannel struct {
+	Title         string     `xml:"title"`
+	Link          string     `xml:"link"`
+	Description   string     `xml:"description"`
+	Language      string     `xml:"language,omitempty"`
+	LastBuildDate string     `xml:"lastBuildDate"`
+	AtomLink      *atomLink  `xml:"atom:link"`
+	Items         []*rssItem `xml:"item"`
+}
+
+type rssFeed struct {
+	XMLName xml.Name    `xml:"rss"`
+	Version string      `xml:"version,attr"`
+	Atom    string      `xml:"xmlns:atom,attr"`
+	DC      string      `xml:"xmlns:dc,attr"`
+	Channel *rssChannel `xml:"channel"`
+}
+
+// rss converts the feed to RSS 2.0, the authors are the Dublin Core creators as
+// the RSS author must be the email
+func rss(feed *Feed) *rssFeed {
+	description := feed.Subtitle
+	if description == "" {
+		description = feed.Title
+	}
+
+	channel := &rssChannel{
+		Title:         feed.Title,
+		Link:          feed.Link,
+		Description:   description,
+		Language:      feed.Language,
+		LastBuildDate: feed.Updated.UTC().Format(time.RFC1123Z),
+		AtomLink:      &atomLink{Rel: "self", Type: ContentType(RSSFormat), Href: feed.ID},
+	}
+	for _, entry := range feed.Entries {
+		channel.Items = append(channel.Items, &rssItem{
+			Title:       entry.Title,
+			Link:        entry.Link,
+			Description: entry.Summary,
+			Creators:    entry.Authors,
+			Categories:  entry.Categories,
+			GUID:        rssGUID{IsPermaLink: entry.ID == entry.Link, Value: entry.ID},
+			PubDate:     entry.Published.UTC().Format(time.RFC1123Z),
+		})
+	}
+
+	return &rssFeed{
+		Version: "2.0",
+		Atom:    atomNamespace,
+		DC:      "http://purl.org/dc/elements/1.1/",
+		Channel: channel,
+	}
+}
@@ -0,0 +1,68 @@
+package feed
+
+import (
+	"encoding/json"
+	"unicode/utf8"
+)
+
+const JSONLDContentType = "application/ld+json; charset=utf-8"
+
+// the max length of the headline recommended by the search engines
+const maxHeadline = 110
+
+// Thing is the Schema.org entity referenced by the article
+type Thing struct {
+	Type       string   `json:"@type"`
+	ID         string   `json:"@id,omitempty"`
+	Name       string   `json:"name,omitempty"`
+	URL        string   `json:"url,omitempty"`
+	SameAs     []string `json:"sameAs,omitempty"`
+	PropertyID string   `json:"propertyID,omitempty"`
+	Value      string   `json:"value,omitempty"`
+}
+
+// ScholarlyArticle is the Schema.org description of the work embedded into its page
+type ScholarlyArticle struct {
+	Context       string   `json:"@context"`
+	Type          string   `json:"@type"`
+	ID            string   `json:"@id"`
+	URL           string   `json:"url"`
+	Headline      string   `json:"headline"`
+	Name          string   `json:"name"`
+	Abstract      string   `json:"abstract,omitempty"`
+	Authors       []*Thing `json:"author,omitempty"`
+	Publisher     *Thing   `json:"publisher,omitempty"`
+	DatePublished string   `json:"datePublished,omitempty"`
+	DateModified  string   `json:"dateModified,omitempty"`
+	InLanguage    string   `json:"inLanguage,omitempty"`
+	Keywords      []string `json:"keywords,omitempty"`
+	About         []*Thing `json:"about,omitempty"`
+	Identifier    []*Thing `json:"identifier,omitempty"`
+	SameAs        []string `json:"sameAs,omitempty"`
+	Citation      []*Thing `json:"citation,omitempty"`
+	// License is the URL of the license, the works under the custom terms have none
+	License             string `json:"license,omitempty"`
+	IsAccessibleForFree bool   `json:"isAccessibleForFree"`
+}
+
+// NewScholarlyArticle returns the article of the page, the headline is the title shortened to the recommended length
+func NewScholarlyArticle(url, title string) *ScholarlyArticle {
+	headline := title
+	if utf8.RuneCountInString(headline) > maxHeadline {
+		headline = string([]rune(headline)[:maxHeadline-1]) + "…"
+	}
+
+	return &ScholarlyArticle{
+		Context:  "https://schema.org",
+		Type:     "ScholarlyArticle",
+		ID:       url,
+		URL:      url,
+		Headline: headline,
+		Name:     title,
+	}
+}
+
+// JSONLD writes the article as the JSON-LD script content
+func JSONLD(article *ScholarlyArticle) ([]byte, error) {
+	return json.MarshalIndent(article, "", "  ")
+}
@@ -0,0 +1,44 @@
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+const (
+	SitemapContentType = "application/xml; charset=utf-8"
+	// MaxSitemapURLs is the limit of the URLs in the sitemap set by the protocol
+	MaxSitemapURLs = 50000
+)
+
+// URL is the page of the sitemap
+type URL struct {
+	Loc        string `xml:"loc"`
+	LastMod    string `xml:"lastmod,omitempty"`
+	ChangeFreq string `xml:"changefreq,omitempty"`
+}
+
+type urlSet struct {
+	XMLName xml.Name `xml:"urlset"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	URLs    []*URL   `xml:"url"`
+}
+
+// LastMod formats the date of the last modification of the page
+func LastMod(t time.Time) string {
+	return t.UTC().Format("2006-01-02")
+}
+
+// Sitemap writes the sitemap of the pages, the pages above the limit are dropped
+func Sitemap(urls []*URL) ([]byte, error) {
+	if len(urls) > MaxSitemapURLs {
+		urls = urls[:MaxSitemapURLs]
+	}
+
+	data, err := xml.MarshalIndent(&urlSet{Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9", URLs: urls}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
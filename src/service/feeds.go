@@ -0,0 +1,250 @@
+package srv
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/citation"
+	"github.com/SeaOfWisdom/sow_library/src/service/feed"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+)
+
+// the default number of the latest opened works in the feed
+const defaultFeedSize = 50
+
+// FeedFilter selects the opened works of the feed, the empty fields don't limit the works
+type FeedFilter struct {
+	Tag           string
+	Language      string
+	AuthorAddress string
+}
+
+// WorksFeed returns the feed of the latest opened works in the format, the self URL is the address the feed is served at
+func (ls *LibrarySrv) WorksFeed(ctx context.Context, selfURL, format string, filter *FeedFilter) ([]byte, error) {
+	if format != feed.AtomFormat && format != feed.RSSFormat {
+		return nil, feed.ErrUnknownFormat
+	}
+
+	title := citation.Publisher + ": new works"
+	harvest := new(storage.HarvestFilter)
+	restrict := func(workIDs []string) {
+		if harvest.WorkIDs == nil {
+			harvest.WorkIDs = workIDs
+			return
+		}
+		harvest.WorkIDs = intersectIDs(harvest.WorkIDs, workIDs)
+	}
+
+	if filter.AuthorAddress != "" {
+		participant, err := ls.storage.GetParticipantByAddress(filter.AuthorAddress)
+		if err != nil {
+			return nil, err
+		}
+
+		workIDs, err := ls.storage.GetOpenWorkIDsOfParticipant(participant.ID)
+		if err != nil {
+			ls.log.Errorf("WorksFeed: error get works of author %s, err: %v", filter.AuthorAddress, err)
+
+			return nil, err
+		}
+		restrict(workIDs)
+
+		name := participant.NickName
+		if name == "" {
+			name = participant.Web3Address
+		}
+		title += " by " + name
+	}
+	for _, subject := range []struct{ field, value, title string }{
+		{storage.TagsField, filter.Tag, " tagged " + filter.Tag},
+		{storage.LanguageField, filter.Language, " in " + filter.Language},
+	} {
+		if subject.value == "" {
+			continue
+		}
+
+		workIDs, err := ls.storage.GetWorkIDsBySubject(ctx, subject.field, subject.value)
+		if err != nil {
+			ls.log.Errorf("WorksFeed: error get works by %s %s, err: %v", subject.field, subject.value, err)
+
+			return nil, err
+		}
+		restrict(workIDs)
+		title += subject.title
+	}
+
+	size := ls.cfg.FeedSize
+	if size <= 0 {
+		size = defaultFeedSize
+	}
+
+	stamps, err := ls.storage.GetLatestOpenWorkStamps(harvest, size)
+	if err != nil {
+		ls.log.Errorf("WorksFeed: error get opened works, err: %v", err)
+
+		return nil, err
+	}
+
+	worksFeed := &feed.Feed{
+		ID:        selfURL,
+		Title:     title,
+		Subtitle:  "The works opened in the library after the review",
+		Link:      ls.cfg.LibraryURL,
+		Publisher: citation.Publisher,
+		Language:  filter.Language,
+		Updated:   time.Now(),
+	}
+	// the feed is updated when the latest work is opened
+	if len(stamps) > 0 {
+		worksFeed.Updated = stamps[0].Datestamp
+	}
+
+	for _, stamp := range stamps {
+		workResp := ls.citedWork(ctx, "", stamp.WorkID)
+		if workResp == nil {
+			continue
+		}
+		worksFeed.Entries = append(worksFeed.Entries, ls.feedEntry(workResp, stamp))
+	}
+
+	return feed.Marshal(worksFeed, format)
+}
+
+// feedEntry describes the opened work, the work is identified by its page
+func (ls *LibrarySrv) feedEntry(workResp *storage.WorkResponse, stamp *storage.WorkStamp) *feed.Entry {
+	work := workResp.Work
+	entry := &feed.Entry{
+		ID:        ls.workURL(work.ID),
+		Title:     work.Name,
+		Link:      ls.workURL(work.ID),
+		Summary:   work.Annotation,
+		Published: stamp.Datestamp,
+		Updated:   stamp.Datestamp,
+	}
+
+	for _, author := range workAuthors(workResp) {
+		entry.Authors = append(entry.Authors, author.FullName())
+	}
+	if work.Science != "" {
+		entry.Categories = append(entry.Categories, work.Science)
+	}
+	for _, tag := range work.Tags {
+		if tag != "" {
+			entry.Categories = append(entry.Categories, tag)
+		}
+	}
+
+	return entry
+}
+
+// intersectIDs returns the ids found in both lists
+func intersectIDs(ids, other []string) []string {
+	found := make(map[string]bool, len(other))
+	for _, id := range other {
+		found[id] = true
+	}
+
+	intersection := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if found[id] {
+			intersection = append(intersection, id)
+		}
+	}
+	return intersection
+}
+
+// Sitemap lists the library page and the pages of the open works for the search engines
+func (ls *LibrarySrv) Sitemap(ctx context.Context) ([]byte, error) {
+	stamps, _, err := ls.storage.GetOpenWorkStamps(new(storage.HarvestFilter), feed.MaxSitemapURLs-1)
+	if err != nil {
+		ls.log.Errorf("Sitemap: error get open works, err: %v", err)
+
+		return nil, err
+	}
+
+	urls := make([]*feed.URL, 0, len(stamps)+1)
+	urls = append(urls, &feed.URL{Loc: strings.TrimSuffix(ls.cfg.LibraryURL, "/") + "/", ChangeFreq: "daily"})
+	for _, stamp := range stamps {
+		urls = append(urls, &feed.URL{Loc: ls.workURL(stamp.WorkID), LastMod: feed.LastMod(stamp.Datestamp)})
+	}
+
+	return feed.Sitemap(urls)
+}
+
+// WorkJSONLD describes the open work as the Schema.org scholarly article embedded into the work page
+func (ls *LibrarySrv) WorkJSONLD(ctx context.Context, workID string) ([]byte, error) {
+	workResp, err := ls.getOpenWork(ctx, workID)
+	if err != nil {
+		return nil, err
+	}
+
+	stamp, err := ls.storage.GetOpenWorkStamp(workID)
+	if err != nil {
+		return nil, err
+	}
+
+	work := workResp.Work
+	article := feed.NewScholarlyArticle(ls.workURL(work.ID), work.Name)
+	article.Abstract = work.Annotation
+	article.InLanguage = work.Language
+	article.Keywords = work.Tags
+	article.DatePublished = stamp.Datestamp.UTC().Format(time.RFC3339)
+	article.Publisher = &feed.Thing{Type: "Organization", Name: citation.Publisher, URL: ls.cfg.LibraryURL}
+	if work.Science != "" {
+		article.About = []*feed.Thing{{Type: "Thing", Name: work.Science}}
+	}
+	article.License = storage.LicenseOf(work).URL
+	article.IsAccessibleForFree = work.OpenAccess
+
+	if work.PID != "" {
+		article.SameAs = []string{ls.pidURL(work.PID)}
+		if strings.HasPrefix(work.PID, "10.") {
+			article.Identifier = []*feed.Thing{{Type: "PropertyValue", PropertyID: "DOI", Value: work.PID}}
+		}
+	}
+
+	addAuthor := func(author *citation.Person, orcid string) {
+		person := &feed.Thing{Type: "Person", Name: author.FullName()}
+		if orcid != "" {
+			person.SameAs = []string{"https://orcid.org/" + orcid}
+		}
+		article.Authors = append(article.Authors, person)
+	}
+	if author := citationAuthor(workResp.Author); author != nil {
+		var orcid string
+		if info := workResp.Author.AuthorInfo; info != nil {
+			orcid = info.Orcid
+		}
+		addAuthor(author, orcid)
+	}
+	for _, coAuthor := range work.CoAuthors {
+		if coAuthor.Name != "" || coAuthor.Surname != "" {
+			addAuthor(&citation.Person{Given: coAuthor.Name, Middle: coAuthor.MiddleName, Family: coAuthor.Surname}, coAuthor.Orcid)
+		}
+	}
+
+	references, err := ls.storage.GetWorkReferences(work.ID)
+	if err != nil {
+		ls.log.Errorf("WorkJSONLD: error get references of work %s, err: %v", work.ID, err)
+
+		return nil, err
+	}
+	for _, reference := range references {
+		cited := &feed.Thing{Type: "CreativeWork", Name: reference.Text, URL: reference.URL}
+		if reference.DOI != "" {
+			cited.URL = "https://doi.org/" + reference.DOI
+		}
+		if reference.CitedWorkID != "" {
+			if citedResp := ls.citedWork(ctx, "", reference.CitedWorkID); citedResp != nil {
+				cited.Name, cited.URL = citedResp.Work.Name, ls.workURL(citedResp.Work.ID)
+			}
+		}
+		if cited.Name == "" && cited.URL == "" {
+			continue
+		}
+		article.Citation = append(article.Citation, cited)
+	}
+
+	return feed.JSONLD(article)
+}
@@ -0,0 +1,283 @@
+package ingest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/ledongthuc/pdf"
+)
+
+var (
+	ErrNotPDF  = errors.New("the document isn't a PDF")
+	ErrNoPages = errors.New("the document has no pages")
+)
+
+// PDFContentType is the MIME type of the documents processed page by page
+const PDFContentType = "application/pdf"
+
+// Document is the parsed PDF, its pages are read one at a time since
+// the PDF reader isn't safe for concurrent use
+type Document struct {
+	mu       sync.Mutex
+	data     []byte
+	reader   *pdf.Reader
+	numPages int
+}
+
+// Open parses the cross-reference table of the PDF, the pages are parsed on demand
+func Open(data []byte) (doc *Document, err error) {
+	// the PDF reader panics on the malformed documents
+	defer func() {
+		if r := recover(); r != nil {
+			doc, err = nil, fmt.Errorf("%w: %v", ErrNotPDF, r)
+		}
+	}()
+
+	reader, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotPDF, err)
+	}
+
+	numPages := reader.NumPage()
+	if numPages <= 0 {
+		return nil, ErrNoPages
+	}
+
+	return &Document{data: data, reader: reader, numPages: numPages}, nil
+}
+
+func (d *Document) NumPages() int {
+	return d.numPages
+}
+
+// pageContent returns the embedded text of the page(number starts from 1)
+// and the largest image of the page to recognize if there is no text
+func (d *Document) pageContent(number int) (text string, img []byte, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	defer func() {
+		if r := recover(); r != nil {
+			text, img, err = "", nil, fmt.Errorf("malformed page %d: %v", number, r)
+		}
+	}()
+
+	page := d.reader.Page(number)
+	if page.V.IsNull() {
+		return "", nil, fmt.Errorf("page %d is not found", number)
+	}
+
+	text = pageText(page.Content().Text)
+	if hasText(text) {
+		return text, nil, nil
+	}
+
+	img, err = d.pageImage(page)
+
+	return text, img, err
+}
+
+// pageText joins the glyphs into the lines and the words by their positions
+func pageText(glyphs []pdf.Text) string {
+	var (
+		sb   strings.Builder
+		prev *pdf.Text
+	)
+	for i := range glyphs {
+		glyph := &glyphs[i]
+		if prev != nil {
+			size := math.Max(glyph.FontSize, 1)
+			switch {
+			case math.Abs(glyph.Y-prev.Y) > size/2:
+				sb.WriteByte('\n')
+			case glyph.X-(prev.X+prev.W) > size/5 && glyph.S != " " && prev.S != " ":
+				sb.WriteByte(' ')
+			}
+		}
+		sb.WriteString(glyph.S)
+		prev = glyph
+	}
+
+	return sb.String()
+}
+
+// the page with fewer letters is considered as a scan, e.g. it has a page number only
+const minPageLetters = 16
+
+func hasText(text string) bool {
+	letters := 0
+	for _, r := range text {
+		if ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || r > 0x7f && r != 0xfffd {
+			letters++
+		}
+	}
+
+	return letters >= minPageLetters
+}
+
+// pageImage returns the largest image of the page as JPEG or PNG
+func (d *Document) pageImage(page pdf.Page) ([]byte, error) {
+	xObjects := page.Resources().Key("XObject")
+
+	var (
+		largest pdf.Value
+		area    int64
+	)
+	for _, name := range xObjects.Keys() {
+		x := xObjects.Key(name)
+		if x.Key("Subtype").Name() != "Image" {
+			continue
+		}
+		if a := x.Key("Width").Int64() * x.Key("Height").Int64(); a > area {
+			largest, area = x, a
+		}
+	}
+	if area == 0 {
+		return nil, errors.New("the page has neither text nor images")
+	}
+
+	switch filter := imageFilter(largest); filter {
+	case "DCTDecode":
+		return d.rawStream(largest)
+	case "", "FlateDecode":
+		return decodeRawImage(largest)
+	default:
+		return nil, fmt.Errorf("unsupported image filter %s", filter)
+	}
+}
+
+func imageFilter(img pdf.Value) string {
+	filter := img.Key("Filter")
+	switch filter.Kind() {
+	case pdf.Name:
+		return filter.Name()
+	case pdf.Array:
+		if filter.Len() == 1 {
+			return filter.Index(0).Name()
+		}
+		return "chained filters"
+	default:
+		return ""
+	}
+}
+
+// rawStream returns the undecoded data of the JPEG image, it's sent to OCR as is.
+// The PDF reader can't decode JPEG and doesn't expose the undecoded data, so the stream
+// is found in the document by its length and the size of the image it holds.
+func (d *Document) rawStream(stream pdf.Value) ([]byte, error) {
+	if !d.reader.Trailer().Key("Encrypt").IsNull() {
+		return nil, errors.New("the images of the encrypted document can't be extracted")
+	}
+
+	length := stream.Key("Length").Int64()
+	if length <= 0 || length > int64(len(d.data)) {
+		return nil, errors.New("wrong length of the image stream")
+	}
+	width, height := int(stream.Key("Width").Int64()), int(stream.Key("Height").Int64())
+
+	for from := 0; ; {
+		at := bytes.Index(d.data[from:], streamKeyword)
+		if at < 0 {
+			return nil, errors.New("the image stream isn't found in the document")
+		}
+		at += from
+		from = at + len(streamKeyword)
+
+		start := streamStart(d.data, at)
+		if start < 0 || int64(start)+length > int64(len(d.data)) {
+			continue
+		}
+
+		data := d.data[start : int64(start)+length]
+		if jpegOfSize(data, width, height) {
+			return data, nil
+		}
+	}
+}
+
+var streamKeyword = []byte("stream")
+
+// streamStart returns the offset of the stream data after the "stream" keyword at,
+// the keyword is followed by CRLF or LF, "endstream" is skipped
+func streamStart(data []byte, at int) int {
+	if bytes.HasSuffix(data[:at], []byte("end")) {
+		return -1
+	}
+
+	start := at + len(streamKeyword)
+	if start < len(data) && data[start] == '\r' {
+		start++
+	}
+	if start >= len(data) || data[start] != '\n' {
+		return -1
+	}
+
+	return start + 1
+}
+
+func jpegOfSize(data []byte, width, height int) bool {
+	if !bytes.HasPrefix(data, []byte{0xff, 0xd8}) {
+		return false
+	}
+
+	config, err := jpeg.DecodeConfig(bytes.NewReader(data))
+
+	return err == nil && config.Width == width && config.Height == height
+}
+
+// decodeRawImage encodes the uncompressed 8 bit gray or RGB samples to PNG
+func decodeRawImage(stream pdf.Value) ([]byte, error) {
+	if bpc := stream.Key("BitsPerComponent").Int64(); bpc != 8 {
+		return nil, fmt.Errorf("unsupported %d bits per component of the image", bpc)
+	}
+
+	width, height := int(stream.Key("Width").Int64()), int(stream.Key("Height").Int64())
+	rect := image.Rect(0, 0, width, height)
+
+	var (
+		img        image.Image
+		pix        []uint8
+		components int
+	)
+	switch space := stream.Key("ColorSpace").Name(); space {
+	case "DeviceGray":
+		gray := image.NewGray(rect)
+		img, pix, components = gray, gray.Pix, 1
+	case "DeviceRGB":
+		rgba := image.NewRGBA(rect)
+		img, pix, components = rgba, rgba.Pix, 3
+	default:
+		return nil, fmt.Errorf("unsupported color space %q of the image", space)
+	}
+
+	samples, err := io.ReadAll(stream.Reader())
+	if err != nil {
+		return nil, err
+	}
+	if len(samples) < width*height*components {
+		return nil, errors.New("the image data is truncated")
+	}
+
+	if components == 1 {
+		copy(pix, samples)
+	} else {
+		for i := 0; i < width*height; i++ {
+			copy(pix[i*4:i*4+3], samples[i*3:i*3+3])
+			pix[i*4+3] = 0xff
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
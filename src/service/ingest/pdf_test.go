@@ -0,0 +1,95 @@
+package ingest
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+
+	"github.com/go-pdf/fpdf"
+)
+
+func testJPEG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = uint8(i)
+	}
+	img.Set(0, 0, color.White)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+// scannedPDF returns the document with the page of the images only, as the scanner makes it
+func scannedPDF(t *testing.T, images ...[]byte) []byte {
+	t.Helper()
+
+	doc := fpdf.New("P", "mm", "A4", "")
+	doc.AddPage()
+	for i, data := range images {
+		name := string(rune('a' + i))
+		options := fpdf.ImageOptions{ImageType: "JPG"}
+		doc.RegisterImageOptionsReader(name, options, bytes.NewReader(data))
+		doc.ImageOptions(name, 10, 10+float64(i)*100, 90, 90, false, options, 0, "")
+	}
+
+	var buf bytes.Buffer
+	if err := doc.Output(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestPageImageJPEG(t *testing.T) {
+	small, large := testJPEG(t, 40, 30), testJPEG(t, 120, 90)
+
+	doc, err := Open(scannedPDF(t, small, large))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.NumPages() != 1 {
+		t.Fatalf("NumPages = %d, want 1", doc.NumPages())
+	}
+
+	text, img, err := doc.pageContent(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hasText(text) {
+		t.Errorf("the scanned page has the text %q", text)
+	}
+
+	// the largest image is sent to OCR as it's stored in the document
+	if !bytes.Equal(img, large) {
+		t.Errorf("the page image isn't the largest JPEG of the page, got %d bytes", len(img))
+	}
+}
+
+func TestStreamStart(t *testing.T) {
+	tests := []struct {
+		data string
+		want int
+	}{
+		{"<<>>stream\nDATA", 11},
+		{"<<>>stream\r\nDATA", 12},
+		{"<<>>streamDATA", -1},
+		{"DATA\nendstream\nendobj", -1},
+	}
+
+	for _, test := range tests {
+		data := []byte(test.data)
+		if got := streamStart(data, bytes.Index(data, streamKeyword)); got != test.want {
+			t.Errorf("streamStart(%q) = %d, want %d", test.data, got, test.want)
+		}
+	}
+}
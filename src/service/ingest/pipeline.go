@@ -0,0 +1,123 @@
+package ingest
+
+import (
+	"context"
+	"sync"
+)
+
+// Recognizer extracts the text from the image of the page
+type Recognizer interface {
+	Recognize(ctx context.Context, image []byte) (string, error)
+}
+
+// PageSource says how the text of the page was obtained
+type PageSource string
+
+const (
+	// the text embedded into the PDF
+	TextPageSource PageSource = "PAGE_TEXT"
+	// the text recognized from the scan of the page
+	OCRPageSource PageSource = "PAGE_OCR"
+)
+
+// Page is the processed page, the number starts from 1
+type Page struct {
+	Number int
+	Source PageSource
+	Text   string
+	Err    error
+}
+
+// Pipeline extracts the text of the PDF pages concurrently, the pages without
+// the embedded text are recognized by OCR
+type Pipeline struct {
+	recognizer Recognizer
+	workers    int
+}
+
+func NewPipeline(recognizer Recognizer, workers int) *Pipeline {
+	if workers < 1 {
+		workers = 1
+	}
+
+	return &Pipeline{recognizer: recognizer, workers: workers}
+}
+
+// Process handles all pages of the document, the progress is called sequentially
+// as each page is done or failed. Returns the pages ordered by their numbers,
+// the failed pages have Err set.
+func (p *Pipeline) Process(ctx context.Context, doc *Document, progress func(page *Page)) []*Page {
+	numbers := make(chan int)
+	results := make(chan *Page)
+
+	var wg sync.WaitGroup
+	for i := 0; i < p.workers && i < doc.NumPages(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for number := range numbers {
+				results <- p.processPage(ctx, doc, number)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(numbers)
+		for number := 1; number <= doc.NumPages(); number++ {
+			select {
+			case numbers <- number:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	pages := make([]*Page, doc.NumPages())
+	for page := range results {
+		pages[page.Number-1] = page
+		if progress != nil {
+			progress(page)
+		}
+	}
+
+	// the pages which weren't started before the cancellation
+	for i, page := range pages {
+		if page == nil {
+			pages[i] = &Page{Number: i + 1, Err: ctx.Err()}
+			if progress != nil {
+				progress(pages[i])
+			}
+		}
+	}
+
+	return pages
+}
+
+func (p *Pipeline) processPage(ctx context.Context, doc *Document, number int) *Page {
+	page := &Page{Number: number, Source: TextPageSource}
+	if err := ctx.Err(); err != nil {
+		page.Err = err
+		return page
+	}
+
+	text, img, err := doc.pageContent(number)
+	if err != nil {
+		page.Err = err
+		return page
+	}
+
+	if img == nil {
+		page.Text = text
+		return page
+	}
+
+	page.Source = OCRPageSource
+	page.Text, page.Err = p.recognizer.Recognize(ctx, img)
+
+	return page
+}
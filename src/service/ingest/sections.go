@@ -0,0 +1,87 @@
+package ingest
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Section is the part of the paper under the heading, the text before
+// the first heading has an empty title
+type Section struct {
+	Title string `json:"title"`
+	Text  string `json:"text"`
+}
+
+// the common headings of the papers
+var knownHeadings = map[string]bool{
+	"abstract": true, "introduction": true, "background": true, "related work": true,
+	"methods": true, "methodology": true, "materials and methods": true, "results": true,
+	"discussion": true, "conclusion": true, "conclusions": true, "acknowledgements": true,
+	"acknowledgments": true, "references": true, "bibliography": true, "appendix": true,
+	"аннотация": true, "введение": true, "методы": true, "результаты": true,
+	"обсуждение": true, "заключение": true, "выводы": true, "литература": true,
+	"список литературы": true,
+}
+
+// numbered heading, e.g. "2.1 Data collection"
+var numberedHeading = regexp.MustCompile(`^\d+(\.\d+)*\.?\s+\p{Lu}[^.!?]*$`)
+
+const maxHeadingWords = 8
+
+func isHeading(line string) bool {
+	line = strings.TrimSpace(line)
+	if line == "" || len(strings.Fields(line)) > maxHeadingWords {
+		return false
+	}
+
+	return knownHeadings[strings.ToLower(strings.TrimRight(line, ":"))] || numberedHeading.MatchString(line)
+}
+
+// Sections reassembles the text of the pages in their order and splits it by the headings,
+// the failed pages are skipped
+func Sections(pages []*Page) []*Section {
+	current := &Section{}
+	sections := []*Section{current}
+	var body []string
+
+	flush := func() {
+		current.Text = strings.TrimSpace(strings.Join(body, "\n"))
+		body = nil
+	}
+
+	for _, page := range pages {
+		if page.Err != nil {
+			continue
+		}
+		for _, line := range strings.Split(page.Text, "\n") {
+			if isHeading(line) {
+				flush()
+				current = &Section{Title: strings.TrimRight(strings.TrimSpace(line), ":")}
+				sections = append(sections, current)
+				continue
+			}
+			body = append(body, line)
+		}
+	}
+	flush()
+
+	// the paper may start with a heading
+	if sections[0].Text == "" {
+		sections = sections[1:]
+	}
+
+	return sections
+}
+
+// Title returns the first line of the paper
+func Title(sections []*Section) string {
+	for _, section := range sections {
+		for _, line := range strings.Split(section.Text, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				return line
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,331 @@
+package srv
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/citation"
+	"github.com/SeaOfWisdom/sow_library/src/service/jats"
+	"github.com/SeaOfWisdom/sow_library/src/service/render"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+)
+
+// the custom metadata of the reviewer report holding the verdict of the review
+const recommendationMeta = "recommendation"
+
+// isContributor checks the JATS contributor is the author of the profile by the ORCID, the email or the name
+func isContributor(contributor *jats.Contributor, author *storage.Author) bool {
+	switch {
+	case contributor.ORCID != "" && author.Orcid != "":
+		return strings.EqualFold(contributor.ORCID, author.Orcid)
+	case contributor.Email != "" && author.EmailAddress != "":
+		return strings.EqualFold(contributor.Email, author.EmailAddress)
+	default:
+		return author.Surname != "" && strings.EqualFold(contributor.Surname, author.Surname)
+	}
+}
+
+// contributorAuthor converts the contributor to the author, the middle name is split from the given names
+func contributorAuthor(contributor *jats.Contributor) *storage.Author {
+	author := &storage.Author{
+		Surname:      contributor.Surname,
+		EmailAddress: contributor.Email,
+		Orcid:        contributor.ORCID,
+	}
+	given := strings.Fields(contributor.Given)
+	if len(given) > 0 {
+		author.Name = given[0]
+		author.MiddleName = strings.Join(given[1:], " ")
+	}
+	if author.Surname == "" && author.Name == "" {
+		author.Name = contributor.Literal
+	}
+	return author
+}
+
+// authorContributor converts the author to the contributor, the email isn't exported
+func authorContributor(author *storage.Author) *jats.Contributor {
+	if author == nil || author.Surname == "" && author.Name == "" {
+		return nil
+	}
+	return &jats.Contributor{
+		Type:    "author",
+		Given:   strings.TrimSpace(author.Name + " " + author.MiddleName),
+		Surname: author.Surname,
+		ORCID:   author.Orcid,
+	}
+}
+
+// ImportJATS creates the draft work of the author from the JATS article. The contributors except
+// the one matching the author's profile become the co-authors, the profile isn't changed by the import.
+// The references identified by the DOI or the URL are kept in the reference list of the work.
+func (ls *LibrarySrv) ImportJATS(ctx context.Context, authorAddress string, data []byte) (*storage.WorkResponse, error) {
+	participant, err := ls.storage.GetParticipantByAddress(authorAddress)
+	if err != nil {
+		ls.log.Errorf("ImportJATS: error get participant with address %s, err: %v", authorAddress, err)
+
+		return nil, err
+	}
+
+	if participant.Role < storage.AuthorRole {
+		return nil, ErrNotAuthor
+	}
+
+	article, err := jats.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	work := &storage.Work{
+		Name:     article.TitleText(),
+		Tags:     article.Keywords,
+		Language: article.Lang,
+		Content:  &storage.WorkContent{Format: render.MarkdownFormat},
+	}
+	if len(article.Subjects) > 0 {
+		work.Science = article.Subjects[0]
+	}
+	if article.Abstract != nil {
+		work.Annotation = article.Abstract.Text()
+	}
+	if article.Body != nil {
+		work.Content.WorkData = article.Body.Markdown()
+	}
+	// the article under the Creative Commons license stays open access
+	if article.License != nil {
+		if id, ok := storage.LicenseByURL(article.License.URL); ok {
+			work.License = &storage.WorkLicense{ID: id}
+		} else if custom := (&storage.WorkLicense{ID: storage.CustomLicense, Text: article.License.Text}); storage.ValidLicense(custom) {
+			work.License = custom
+		}
+		work.OpenAccess = storage.LicenseOf(work).Open()
+	}
+
+	profile, err := ls.storage.GetAuthorById(ctx, participant.ID)
+	if err != nil || profile == nil {
+		profile = &storage.Author{ID: participant.ID}
+	}
+
+	// the contributor matching the uploader is the author of the draft, the profile is kept as is
+	self := false
+	for _, contributor := range article.Contributors {
+		if contributor.Type != "" && contributor.Type != "author" {
+			continue
+		}
+		if !self && isContributor(contributor, profile) {
+			self = true
+			continue
+		}
+		work.CoAuthors = append(work.CoAuthors, contributorAuthor(contributor))
+	}
+
+	workID, err := ls.storage.CreateDraftWork(ctx, participant.ID, work)
+	if err != nil {
+		ls.log.Errorf("ImportJATS: error create draft of author %s, err: %v", participant.ID, err)
+
+		return nil, err
+	}
+
+	references := make([]*storage.WorkReference, 0, len(article.References))
+	for _, ref := range article.References {
+		reference := &storage.WorkReference{Position: len(references) + 1, Text: ref.Text, URL: ref.URL}
+		if ref.DOI != "" {
+			reference.DOI = normalizeDOI(ref.DOI)
+			// the works of the library are cited by their persistent identifiers
+			if pid, err := ls.storage.GetPersistentID(reference.DOI); err == nil {
+				reference.CitedWorkID = pid.WorkID
+			}
+		}
+		if reference.DOI != "" && !doiPattern.MatchString(reference.DOI) {
+			reference.DOI = ""
+		}
+		if reference.URL != "" && !strings.HasPrefix(reference.URL, "http://") && !strings.HasPrefix(reference.URL, "https://") {
+			reference.URL = ""
+		}
+		if ls.checkReference(workID, reference) != nil {
+			continue
+		}
+		references = append(references, reference)
+	}
+	if len(references) > 0 {
+		if err := ls.storage.SetWorkReferences(workID, references); err != nil {
+			ls.log.Errorf("ImportJATS: error set references of work %s, err: %v", workID, err)
+		}
+	}
+
+	return ls.storage.GetWorkByID(ctx, workID)
+}
+
+// ExportJATS returns the open work as the JATS article. The body is exported if the reader
+// has access to the content, the finished reviews are exported as the reviewer reports
+// if the review of the work is open.
+func (ls *LibrarySrv) ExportJATS(ctx context.Context, readerAddress, workID string) ([]byte, error) {
+	workResp, err := ls.getOpenWork(ctx, workID)
+	if err != nil {
+		return nil, err
+	}
+
+	article, err := ls.jatsArticle(ctx, readerAddress, workResp)
+	if err != nil {
+		return nil, err
+	}
+
+	work := workResp.Work
+	withBody := work.Content != nil && work.Content.WorkData != "" && ls.hasContentAccess(readerAddress, workID)
+	if withBody {
+		if article.Body, err = render.Parse(work.Content.Format, work.Content.WorkData); err != nil {
+			return nil, err
+		}
+	}
+
+	data := jats.Marshal(article)
+
+	// the authors get their own works unmarked
+	if !withBody || isWorkAuthor(workResp, readerAddress) || ls.marker == nil {
+		return data, nil
+	}
+
+	mark, err := ls.readerMark(readerAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	// the body which can't be marked isn't served
+	marked, err := ls.marker.EmbedHTML(string(data), mark)
+	if err != nil {
+		ls.log.Errorf("ExportJATS: error mark JATS of work %s, err: %v", workID, err)
+
+		return nil, fmt.Errorf("%w: %v", ErrWatermarkFailed, err)
+	}
+
+	return []byte(marked), nil
+}
+
+// jatsArticle collects the metadata, the references and the reviewer reports of the open work
+func (ls *LibrarySrv) jatsArticle(ctx context.Context, readerAddress string, workResp *storage.WorkResponse) (*jats.Article, error) {
+	work := workResp.Work
+	article := &jats.Article{
+		Type:      jats.ResearchArticle,
+		Lang:      work.Language,
+		Journal:   citation.Publisher,
+		IDs:       []*jats.ID{{Type: jats.PublisherIDType, Value: work.ID}},
+		Title:     []*render.Inline{{Kind: render.TextInline, Text: work.Name}},
+		Published: work.CreatedAt.UTC(),
+		Keywords:  work.Tags,
+	}
+	if strings.HasPrefix(work.PID, "10.") {
+		article.IDs = append(article.IDs, &jats.ID{Type: jats.DOIType, Value: work.PID})
+	}
+	if work.Science != "" {
+		article.Subjects = []string{work.Science}
+	}
+	if license := storage.LicenseOf(work); license.ID == storage.CustomLicense {
+		article.License = &jats.License{Text: license.Text}
+	} else {
+		article.License = &jats.License{URL: license.URL, Text: license.Name}
+	}
+
+	if workResp.Author != nil {
+		if contributor := authorContributor(workResp.Author.AuthorInfo); contributor != nil {
+			article.Contributors = append(article.Contributors, contributor)
+		} else if person := citationAuthor(workResp.Author); person != nil {
+			article.Contributors = append(article.Contributors, &jats.Contributor{Type: "author", Literal: person.Literal})
+		}
+	}
+	for _, coAuthor := range work.CoAuthors {
+		if contributor := authorContributor(coAuthor); contributor != nil {
+			article.Contributors = append(article.Contributors, contributor)
+		}
+	}
+
+	abstract, err := render.Parse(render.PlainFormat, work.Annotation)
+	if err != nil {
+		return nil, err
+	}
+	article.Abstract = abstract
+
+	references, err := ls.storage.GetWorkReferences(work.ID)
+	if err != nil {
+		ls.log.Errorf("jatsArticle: error get references of work %s, err: %v", work.ID, err)
+
+		return nil, err
+	}
+	for _, reference := range references {
+		text := reference.Text
+		if text == "" && reference.CitedWorkID != "" {
+			if cited := ls.citedWork(ctx, readerAddress, reference.CitedWorkID); cited != nil {
+				text = citation.APA(ls.citationItem(cited))
+			}
+		}
+		article.References = append(article.References, &jats.Reference{Text: text, DOI: reference.DOI, URL: reference.URL})
+	}
+
+	if work.ReviewMode == storage.OpenReviewMode {
+		article.SubArticles = ls.reviewerReports(ctx, work.ID)
+	}
+
+	return article, nil
+}
+
+// reviewerReports returns the finished reviews of the work as the reviewer reports signed by the validators
+func (ls *LibrarySrv) reviewerReports(ctx context.Context, workID string) []*jats.Article {
+	participantReviews, err := ls.storage.GetReviewsByWorkId(workID)
+	if err != nil {
+		ls.log.Errorf("reviewerReports: error get reviews of work %s, err: %v", workID, err)
+
+		return nil
+	}
+
+	var reports []*jats.Article
+	for _, participantReview := range participantReviews {
+		var recommendation string
+		switch participantReview.Status {
+		case storage.WorkReviewSubmitted:
+			recommendation = "accept"
+		case storage.WorkReviewRejected:
+			recommendation = "reject"
+		case storage.WorkReviewAccepted:
+			// the verdict is superseded by the admin's decision
+		default:
+			continue
+		}
+
+		review, err := ls.storage.GetWorkReviewByID(ctx, participantReview.ID)
+		if err != nil || review == nil || review.Body == nil || strings.TrimSpace(review.Body.Review) == "" {
+			continue
+		}
+
+		body, err := render.Parse(render.PlainFormat, review.Body.Review)
+		if err != nil {
+			continue
+		}
+
+		reviewer := &jats.Contributor{Type: "author", Role: "Reviewer"}
+		if validator, err := ls.storage.GetValidatorById(ctx, participantReview.ParticipantID); err == nil && validator != nil && validator.Surname != "" {
+			reviewer.Given = strings.TrimSpace(validator.Name + " " + validator.MiddleName)
+			reviewer.Surname = validator.Surname
+			reviewer.ORCID = validator.Orcid
+		} else if participant := ls.storage.GetParticipantById(participantReview.ParticipantID); participant != nil {
+			reviewer.Literal = participant.NickName
+		}
+
+		report := &jats.Article{
+			ID:           "review" + strconv.Itoa(len(reports)+1),
+			Type:         jats.ReviewerReport,
+			Lang:         review.Language,
+			IDs:          []*jats.ID{{Type: jats.PublisherIDType, Value: review.ID}},
+			Title:        []*render.Inline{{Kind: render.TextInline, Text: "Reviewer report"}},
+			Contributors: []*jats.Contributor{reviewer},
+			Published:    review.UpdatedAt.UTC(),
+			Body:         body,
+		}
+		if recommendation != "" {
+			report.Meta = []*jats.Meta{{Name: recommendationMeta, Value: recommendation}}
+		}
+		reports = append(reports, report)
+	}
+
+	return reports
+}
@@ -0,0 +1,241 @@
+package jats
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/render"
+)
+
+// rawText returns the text of the element and its descendants as is
+func (n *node) rawText() string {
+	if n == nil {
+		return ""
+	}
+	var sb strings.Builder
+	n.writeText(&sb)
+	return strings.Trim(sb.String(), "\n")
+}
+
+// formula returns the TeX of the formula, the MathML one is converted to its text
+func formula(n *node) string {
+	if tex := n.child("tex-math"); tex != nil {
+		return strings.TrimSpace(tex.rawText())
+	}
+	return n.content()
+}
+
+// inlines converts the mixed content of the element, the unknown markup is replaced by its content
+func inlines(n *node) []*render.Inline {
+	if n == nil {
+		return nil
+	}
+
+	var result []*render.Inline
+	for _, child := range n.children {
+		switch child.name {
+		case "":
+			result = append(result, &render.Inline{Kind: render.TextInline, Text: child.text})
+		case "bold":
+			result = append(result, &render.Inline{Kind: render.StrongInline, Children: inlines(child)})
+		case "italic":
+			result = append(result, &render.Inline{Kind: render.EmphasisInline, Children: inlines(child)})
+		case "monospace", "code":
+			result = append(result, &render.Inline{Kind: render.CodeInline, Text: child.content()})
+		case "inline-formula", "disp-formula":
+			result = append(result, &render.Inline{Kind: render.MathInline, Text: formula(child)})
+		case "tex-math":
+			result = append(result, &render.Inline{Kind: render.MathInline, Text: strings.TrimSpace(child.rawText())})
+		case "ext-link", "uri":
+			href := child.get("xlink:href")
+			if href == "" && child.name == "uri" {
+				href = child.content()
+			}
+			result = append(result, &render.Inline{Kind: render.LinkInline, Href: href, Children: inlines(child)})
+		case "break":
+			result = append(result, &render.Inline{Kind: render.BreakInline})
+		default:
+			result = append(result, inlines(child)...)
+		}
+	}
+
+	return result
+}
+
+var spaces = regexp.MustCompile(`\s+`)
+
+// collapse joins the adjacent texts and collapses their spaces
+func collapse(list []*render.Inline) []*render.Inline {
+	var result []*render.Inline
+	for _, inline := range list {
+		if inline.Kind != render.TextInline {
+			inline.Children = collapse(inline.Children)
+			result = append(result, inline)
+			continue
+		}
+		if n := len(result); n > 0 && result[n-1].Kind == render.TextInline {
+			result[n-1].Text += inline.Text
+			continue
+		}
+		result = append(result, &render.Inline{Kind: render.TextInline, Text: inline.Text})
+	}
+
+	for _, inline := range result {
+		if inline.Kind == render.TextInline {
+			inline.Text = spaces.ReplaceAllString(inline.Text, " ")
+		}
+	}
+
+	return result
+}
+
+// trimInlines collapses the spaces of the paragraph and trims its edges
+func trimInlines(list []*render.Inline) []*render.Inline {
+	list = collapse(list)
+	if n := len(list); n > 0 && list[0].Kind == render.TextInline {
+		list[0].Text = strings.TrimLeft(list[0].Text, " ")
+	}
+	if n := len(list); n > 0 && list[n-1].Kind == render.TextInline {
+		list[n-1].Text = strings.TrimRight(list[n-1].Text, " ")
+	}
+
+	result := list[:0]
+	for _, inline := range list {
+		if inline.Kind != render.TextInline || inline.Text != "" {
+			result = append(result, inline)
+		}
+	}
+
+	return result
+}
+
+// joinParagraphs joins the paragraphs of the list item or the quote by the line breaks
+func joinParagraphs(n *node) []*render.Inline {
+	var result []*render.Inline
+	for _, p := range n.all("p") {
+		if len(result) > 0 {
+			result = append(result, &render.Inline{Kind: render.BreakInline})
+		}
+		result = append(result, trimInlines(inlines(p))...)
+	}
+	return result
+}
+
+// blocks converts the content of the body, the section or the abstract,
+// the sections are converted to the headings of the level
+func blocks(n *node, level int) []*render.Block {
+	var result []*render.Block
+	for _, child := range n.children {
+		switch child.name {
+		case "p":
+			if paragraph := trimInlines(inlines(child)); len(paragraph) > 0 {
+				result = append(result, &render.Block{Kind: render.ParagraphBlock, Inlines: paragraph})
+			}
+		case "sec":
+			if title := trimInlines(inlines(child.child("title"))); len(title) > 0 {
+				result = append(result, &render.Block{Kind: render.HeadingBlock, Level: level, Inlines: title})
+			}
+			result = append(result, blocks(child, level+1)...)
+		case "list":
+			list := &render.Block{Kind: render.ListBlock, Ordered: child.get("list-type") == "order"}
+			for _, item := range child.all("list-item") {
+				list.Items = append(list.Items, joinParagraphs(item))
+			}
+			if len(list.Items) > 0 {
+				result = append(result, list)
+			}
+		case "disp-quote":
+			if quote := joinParagraphs(child); len(quote) > 0 {
+				result = append(result, &render.Block{Kind: render.QuoteBlock, Inlines: quote})
+			}
+		case "preformat", "code":
+			result = append(result, &render.Block{Kind: render.CodeBlock, Text: child.rawText()})
+		case "disp-formula":
+			result = append(result, &render.Block{Kind: render.MathBlock, Text: formula(child)})
+		case "", "title", "label", "sec-meta", "ref-list", "fn-group", "graphic", "table":
+		default:
+			// the figures, the boxes and the like keep their paragraphs
+			result = append(result, blocks(child, level)...)
+		}
+	}
+
+	return result
+}
+
+// mixed returns the element with the inlines
+func mixed(name string, list []*render.Inline) *node {
+	element := elem(name)
+	for _, inline := range list {
+		switch inline.Kind {
+		case render.TextInline:
+			element.add(text(inline.Text))
+		case render.StrongInline:
+			element.add(mixed("bold", inline.Children))
+		case render.EmphasisInline:
+			element.add(mixed("italic", inline.Children))
+		case render.CodeInline:
+			element.add(elem("monospace", text(inline.Text)))
+		case render.MathInline:
+			element.add(elem("inline-formula", elem("tex-math", text(inline.Text))))
+		case render.LinkInline:
+			element.add(mixed("ext-link", inline.Children).attr("ext-link-type", "uri").attr("xlink:href", strings.TrimSpace(inline.Href)))
+		case render.BreakInline:
+			// the break isn't allowed in the paragraphs
+			element.add(text(" "))
+		}
+	}
+	return element
+}
+
+func blockNode(block *render.Block) *node {
+	switch block.Kind {
+	case render.ListBlock:
+		listType := "bullet"
+		if block.Ordered {
+			listType = "order"
+		}
+		list := elem("list").attr("list-type", listType)
+		for _, item := range block.Items {
+			list.add(elem("list-item", mixed("p", item)))
+		}
+		return list
+	case render.CodeBlock:
+		return elem("preformat", text(block.Text))
+	case render.MathBlock:
+		return elem("disp-formula", elem("tex-math", text(block.Text)))
+	case render.QuoteBlock:
+		return elem("disp-quote", mixed("p", block.Inlines))
+	default:
+		return mixed("p", block.Inlines)
+	}
+}
+
+// addBlocks adds the blocks to the body or the abstract, the headings open the nested sections,
+// the heading of the skipped level is nested one level deeper
+func addBlocks(root *node, doc *render.Document) *node {
+	if doc == nil {
+		return root
+	}
+
+	stack := []*node{root}
+	for _, block := range doc.Blocks {
+		if block.Kind != render.HeadingBlock {
+			stack[len(stack)-1].add(blockNode(block))
+			continue
+		}
+
+		depth := block.Level
+		if depth < 1 {
+			depth = 1
+		}
+		if depth > len(stack) {
+			depth = len(stack)
+		}
+		stack = stack[:depth]
+		sec := elem("sec", mixed("title", block.Inlines))
+		stack[len(stack)-1].add(sec)
+		stack = append(stack, sec)
+	}
+
+	return root
+}
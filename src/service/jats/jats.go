@@ -0,0 +1,447 @@
+package jats
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/render"
+)
+
+// the articles are written by the JATS Journal Archiving and Interchange tag set 1.3
+const (
+	DTDVersion  = "1.3"
+	ContentType = "application/jats+xml; charset=utf-8"
+	// Namespace is the namespace of the article embedded into another XML document
+	Namespace = "http://jats.nlm.nih.gov/ns/archiving/1.3/"
+	doctype   = `<!DOCTYPE article PUBLIC "-//NLM//DTD JATS (Z39.96) Journal Archiving and Interchange DTD v1.3 20210610//EN" "JATS-archivearticle1-3.dtd">`
+)
+
+// article types
+const (
+	ResearchArticle = "research-article"
+	ReviewerReport  = "reviewer-report"
+)
+
+// the identifier types of the articles and the contributors
+const (
+	DOIType         = "doi"
+	PublisherIDType = "publisher-id"
+	ORCIDType       = "orcid"
+)
+
+var ErrMalformed = errors.New("malformed JATS article")
+
+// ID is the identifier of the article
+type ID struct {
+	Type  string
+	Value string
+}
+
+// Contributor is the author of the article or the reviewer of the report
+type Contributor struct {
+	Type    string // author, reviewer
+	Given   string
+	Surname string
+	// Literal is the name which can't be split
+	Literal string
+	ORCID   string
+	Email   string
+	Role    string
+}
+
+// Reference is the entry of the reference list
+type Reference struct {
+	ID   string
+	Text string
+	DOI  string
+	URL  string
+}
+
+// License is the license of the article, the URL refers to the standard license
+type License struct {
+	URL  string
+	Text string
+}
+
+// Meta is the custom metadata of the article
+type Meta struct {
+	Name  string
+	Value string
+}
+
+// Article is the front matter, the body and the back matter of the JATS article,
+// the sub-articles are the reviewer reports
+type Article struct {
+	// ID is the id of the sub-article
+	ID           string
+	Type         string
+	Lang         string
+	Journal      string
+	IDs          []*ID
+	Subjects     []string
+	Title        []*render.Inline
+	Contributors []*Contributor
+	Published    time.Time
+	License      *License
+	Abstract     *render.Document
+	Keywords     []string
+	Body         *render.Document
+	References   []*Reference
+	Meta         []*Meta
+	SubArticles  []*Article
+}
+
+// TitleText returns the title as the plain text
+func (a *Article) TitleText() string {
+	return render.InlineText(a.Title)
+}
+
+// GetID returns the identifier of the type
+func (a *Article) GetID(idType string) string {
+	for _, id := range a.IDs {
+		if id.Type == idType {
+			return id.Value
+		}
+	}
+	return ""
+}
+
+// Parse reads the JATS article
+func Parse(data []byte) (*Article, error) {
+	root, err := parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	if root.name != "article" {
+		return nil, fmt.Errorf("%w: the root element is %s", ErrMalformed, root.name)
+	}
+
+	article := readArticle(root, root.path("front", "article-meta"))
+	article.Journal = root.path("front", "journal-meta", "journal-title-group", "journal-title").content()
+	for _, sub := range root.all("sub-article") {
+		subArticle := readArticle(sub, sub.child("front-stub"))
+		subArticle.ID = sub.get("id")
+		article.SubArticles = append(article.SubArticles, subArticle)
+	}
+
+	return article, nil
+}
+
+// readArticle reads the article or the sub-article by its metadata
+func readArticle(root, meta *node) *Article {
+	article := &Article{
+		Type:  root.get("article-type"),
+		Lang:  root.get("xml:lang"),
+		Title: trimInlines(inlines(meta.path("title-group", "article-title"))),
+	}
+
+	for _, id := range meta.all("article-id") {
+		article.IDs = append(article.IDs, &ID{Type: id.get("pub-id-type"), Value: id.content()})
+	}
+	for _, group := range meta.path("article-categories").all("subj-group") {
+		for _, subject := range group.all("subject") {
+			article.Subjects = append(article.Subjects, subject.content())
+		}
+	}
+	for _, group := range meta.all("contrib-group") {
+		for _, contrib := range group.all("contrib") {
+			article.Contributors = append(article.Contributors, readContributor(contrib))
+		}
+	}
+	for _, date := range meta.all("pub-date") {
+		if published := readDate(date); !published.IsZero() {
+			article.Published = published
+			break
+		}
+	}
+	if license := meta.path("permissions", "license"); license != nil {
+		article.License = &License{URL: license.get("xlink:href"), Text: license.content()}
+	}
+	if abstract := meta.child("abstract"); abstract != nil {
+		article.Abstract = &render.Document{Blocks: blocks(abstract, 1)}
+	}
+	for _, group := range meta.all("kwd-group") {
+		for _, kwd := range group.all("kwd") {
+			article.Keywords = append(article.Keywords, kwd.content())
+		}
+	}
+	for _, custom := range meta.path("custom-meta-group").all("custom-meta") {
+		article.Meta = append(article.Meta, &Meta{
+			Name:  custom.child("meta-name").content(),
+			Value: custom.child("meta-value").content(),
+		})
+	}
+
+	if body := root.child("body"); body != nil {
+		article.Body = &render.Document{Blocks: blocks(body, 1)}
+	}
+	for _, refList := range root.path("back").all("ref-list") {
+		for _, ref := range refList.all("ref") {
+			article.References = append(article.References, readReference(ref))
+		}
+	}
+
+	return article
+}
+
+func readContributor(contrib *node) *Contributor {
+	contributor := &Contributor{
+		Type:  contrib.get("contrib-type"),
+		Email: contrib.child("email").content(),
+		Role:  contrib.child("role").content(),
+	}
+	if name := contrib.child("name"); name != nil {
+		contributor.Given = name.child("given-names").content()
+		contributor.Surname = name.child("surname").content()
+	} else {
+		contributor.Literal = contrib.child("string-name").content()
+	}
+	for _, id := range contrib.all("contrib-id") {
+		if strings.EqualFold(id.get("contrib-id-type"), ORCIDType) {
+			contributor.ORCID = strings.TrimPrefix(strings.TrimPrefix(id.content(), "https://orcid.org/"), "http://orcid.org/")
+		}
+	}
+	return contributor
+}
+
+// readDate reads the ISO 8601 date or the date of the parts, the month may be missing
+func readDate(date *node) time.Time {
+	if iso, err := time.Parse("2006-01-02", date.get("iso-8601-date")); err == nil {
+		return iso
+	}
+
+	part := func(name string, fallback int) int {
+		value, err := strconv.Atoi(date.child(name).content())
+		if err != nil || value == 0 {
+			return fallback
+		}
+		return value
+	}
+	year := part("year", 0)
+	if year == 0 {
+		return time.Time{}
+	}
+	return time.Date(year, time.Month(part("month", 1)), part("day", 1), 0, 0, 0, 0, time.UTC)
+}
+
+var spaceBeforePunct = regexp.MustCompile(`\s+([.,;:)])`)
+
+// readReference reads the mixed or the element citation, its DOI and URL
+func readReference(ref *node) *Reference {
+	reference := &Reference{ID: ref.get("id")}
+
+	citation := ref.child("mixed-citation")
+	if citation == nil {
+		citation = ref.child("element-citation")
+	}
+	if citation == nil {
+		return reference
+	}
+
+	var parts []string
+	for _, child := range citation.children {
+		switch {
+		case child.name == "pub-id" && child.get("pub-id-type") == DOIType:
+			reference.DOI = child.content()
+			parts = append(parts, reference.DOI)
+		case child.name == "uri" || child.name == "ext-link":
+			if reference.URL = child.get("xlink:href"); reference.URL == "" {
+				reference.URL = child.content()
+			}
+			parts = append(parts, child.content())
+		case child.name == "":
+			parts = append(parts, child.text)
+		case citation.name == "element-citation":
+			// the elements of the element citation aren't separated by the text
+			parts = append(parts, " "+child.words()+" ")
+		default:
+			parts = append(parts, child.content())
+		}
+	}
+	reference.Text = spaceBeforePunct.ReplaceAllString(strings.Join(strings.Fields(strings.Join(parts, "")), " "), "$1")
+
+	return reference
+}
+
+// Marshal writes the JATS article with the XML declaration and the DOCTYPE
+func Marshal(article *Article) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n" + doctype + "\n")
+	articleNode(article, "").write(&buf, 0)
+	buf.WriteString("\n")
+
+	return buf.Bytes()
+}
+
+// Element writes the article element only to embed it into another XML document,
+// the element declares the JATS namespace
+func Element(article *Article) []byte {
+	var buf bytes.Buffer
+	articleNode(article, Namespace).write(&buf, 0)
+
+	return buf.Bytes()
+}
+
+func articleNode(article *Article, namespace string) *node {
+	root := elem("article").
+		attr("xmlns", namespace).
+		attr("xmlns:xlink", "http://www.w3.org/1999/xlink").
+		attr("xmlns:mml", "http://www.w3.org/1998/Math/MathML").
+		attr("dtd-version", DTDVersion).
+		attr("article-type", article.Type).
+		attr("xml:lang", article.Lang)
+
+	journal := elem("journal-meta",
+		elem("journal-id", text(article.Journal)).attr("journal-id-type", PublisherIDType),
+		elem("journal-title-group", textElem("journal-title", article.Journal)),
+		elem("publisher", textElem("publisher-name", article.Journal)),
+	)
+	root.add(elem("front", journal, writeMeta(elem("article-meta"), article)))
+
+	if article.Body != nil && len(article.Body.Blocks) > 0 {
+		root.add(addBlocks(elem("body"), article.Body))
+	}
+
+	if len(article.References) > 0 {
+		refList := elem("ref-list", elem("title", text("References")))
+		for i, reference := range article.References {
+			refList.add(writeReference(i, reference))
+		}
+		root.add(elem("back", refList))
+	}
+
+	for i, sub := range article.SubArticles {
+		id := sub.ID
+		if id == "" {
+			id = "sub" + strconv.Itoa(i+1)
+		}
+		subArticle := elem("sub-article").attr("article-type", sub.Type).attr("id", id).attr("xml:lang", sub.Lang)
+		subArticle.add(writeMeta(elem("front-stub"), sub))
+		if sub.Body != nil && len(sub.Body.Blocks) > 0 {
+			subArticle.add(addBlocks(elem("body"), sub.Body))
+		}
+		root.add(subArticle)
+	}
+
+	return root
+}
+
+// writeMeta writes the metadata of the article or the sub-article in the order of the DTD
+func writeMeta(meta *node, article *Article) *node {
+	for _, id := range article.IDs {
+		meta.add(elem("article-id", text(id.Value)).attr("pub-id-type", id.Type))
+	}
+
+	if len(article.Subjects) > 0 {
+		group := elem("subj-group").attr("subj-group-type", "discipline")
+		for _, subject := range article.Subjects {
+			group.add(textElem("subject", subject))
+		}
+		meta.add(elem("article-categories", group))
+	}
+
+	meta.add(elem("title-group", mixed("article-title", article.Title)))
+
+	if len(article.Contributors) > 0 {
+		group := elem("contrib-group")
+		for _, contributor := range article.Contributors {
+			group.add(writeContributor(contributor))
+		}
+		meta.add(group)
+	}
+
+	if !article.Published.IsZero() {
+		published := article.Published.UTC()
+		meta.add(elem("pub-date",
+			textElem("day", fmt.Sprintf("%02d", published.Day())),
+			textElem("month", fmt.Sprintf("%02d", published.Month())),
+			textElem("year", strconv.Itoa(published.Year())),
+		).attr("publication-format", "electronic").attr("date-type", "pub").attr("iso-8601-date", published.Format("2006-01-02")))
+	}
+
+	if article.License != nil {
+		meta.add(elem("permissions",
+			elem("license", textElem("license-p", article.License.Text)).attr("xlink:href", article.License.URL),
+		))
+	}
+
+	if article.Abstract != nil && len(article.Abstract.Blocks) > 0 {
+		meta.add(addBlocks(elem("abstract"), article.Abstract))
+	}
+
+	if len(article.Keywords) > 0 {
+		group := elem("kwd-group").attr("kwd-group-type", "author")
+		for _, keyword := range article.Keywords {
+			group.add(textElem("kwd", keyword))
+		}
+		meta.add(group)
+	}
+
+	if len(article.Meta) > 0 {
+		group := elem("custom-meta-group")
+		for _, custom := range article.Meta {
+			group.add(elem("custom-meta", textElem("meta-name", custom.Name), textElem("meta-value", custom.Value)))
+		}
+		meta.add(group)
+	}
+
+	return meta
+}
+
+func writeContributor(contributor *Contributor) *node {
+	contrib := elem("contrib").attr("contrib-type", contributor.Type)
+	if contributor.ORCID != "" {
+		contrib.add(elem("contrib-id", text("https://orcid.org/"+contributor.ORCID)).attr("contrib-id-type", ORCIDType))
+	}
+	if contributor.Surname != "" {
+		contrib.add(elem("name", textElem("surname", contributor.Surname), textElem("given-names", contributor.Given)))
+	} else {
+		contrib.add(textElem("string-name", contributor.Literal))
+	}
+	contrib.add(textElem("email", contributor.Email), textElem("role", contributor.Role))
+	return contrib
+}
+
+func writeReference(i int, reference *Reference) *node {
+	id := reference.ID
+	if id == "" {
+		id = "ref" + strconv.Itoa(i+1)
+	}
+
+	// the DOI and the URL are marked up in the text, the missing ones are appended
+	citation := elem("mixed-citation")
+	rest := reference.Text
+	markup := func(value string, element *node) {
+		if value == "" {
+			return
+		}
+		at := strings.Index(rest, value)
+		if at < 0 {
+			if rest = strings.TrimSpace(rest); rest != "" {
+				rest += " "
+			}
+			at = len(rest)
+			rest += value
+		}
+		citation.add(textOrNil(rest[:at]), element)
+		rest = rest[at+len(value):]
+	}
+	markup(reference.DOI, elem("pub-id", text(reference.DOI)).attr("pub-id-type", DOIType))
+	markup(reference.URL, elem("uri", text(reference.URL)).attr("xlink:href", reference.URL))
+	citation.add(textOrNil(rest))
+
+	return elem("ref", citation).attr("id", id)
+}
+
+// textOrNil returns the text node, nil if the text is empty
+func textOrNil(s string) *node {
+	if s == "" {
+		return nil
+	}
+	return text(s)
+}
@@ -0,0 +1,147 @@
+package jats
+
+import (
+	"bytes"
+	"encoding/xml"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/render"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// libraryArticle returns the article as the library keeps the imported work:
+// the body in Markdown and the abstract in plain text
+func libraryArticle(t *testing.T, article *Article) *Article {
+	t.Helper()
+
+	kept := *article
+	body, err := render.Parse(render.MarkdownFormat, article.Body.Markdown())
+	if err != nil {
+		t.Fatal(err)
+	}
+	abstract, err := render.Parse(render.PlainFormat, article.Abstract.Text())
+	if err != nil {
+		t.Fatal(err)
+	}
+	kept.Body, kept.Abstract = body, abstract
+
+	return &kept
+}
+
+func readGolden(t *testing.T, path string, got []byte) []byte {
+	t.Helper()
+
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v, run the tests with -update to create the golden file", err)
+	}
+
+	return want
+}
+
+// TestRoundTrip imports the article, exports it and imports the export again,
+// the export is compared to the golden file and the second import to the first one
+func TestRoundTrip(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join("testdata", "*.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, input := range inputs {
+		if strings.HasSuffix(input, ".golden.xml") {
+			continue
+		}
+
+		t.Run(filepath.Base(input), func(t *testing.T) {
+			data, err := os.ReadFile(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			imported, err := Parse(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			exported := Marshal(libraryArticle(t, imported))
+			golden := strings.TrimSuffix(input, ".xml") + ".golden.xml"
+			if want := readGolden(t, golden, exported); !bytes.Equal(exported, want) {
+				t.Errorf("the export differs from %s:\n%s", golden, exported)
+			}
+
+			reimported, err := Parse(exported)
+			if err != nil {
+				t.Fatalf("the export isn't imported: %v", err)
+			}
+
+			if !reflect.DeepEqual(reimported, libraryArticle(t, reimported)) {
+				t.Error("the body of the export changes when the library keeps it")
+			}
+
+			imported, reimported = libraryArticle(t, imported), libraryArticle(t, reimported)
+			if !reflect.DeepEqual(reimported, imported) {
+				t.Errorf("the reimported article differs:\n%s\nwant:\n%s", Marshal(reimported), Marshal(imported))
+			}
+
+			if again := Marshal(reimported); !bytes.Equal(again, exported) {
+				t.Errorf("the export of the reimported article differs:\n%s", again)
+			}
+		})
+	}
+}
+
+func TestElementNamespace(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "article.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	article, err := Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	element := Element(article)
+	var root struct {
+		XMLName xml.Name
+	}
+	if err := xml.Unmarshal(element, &root); err != nil {
+		t.Fatal(err)
+	}
+
+	if root.XMLName.Space != Namespace || root.XMLName.Local != "article" {
+		t.Errorf("the root element is {%s}%s, want {%s}article", root.XMLName.Space, root.XMLName.Local, Namespace)
+	}
+
+	embedded, err := Parse(element)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(embedded, article) {
+		t.Errorf("the embedded article differs:\n%s", element)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, data := range []string{
+		"",
+		"<article><front>",
+		`<?xml version="1.0"?><book/>`,
+	} {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("Parse(%q) succeeded", data)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package jats
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+// the prefixes of the namespaces used by JATS, the names of the tree keep the prefixes
+var prefixes = map[string]string{
+	"http://www.w3.org/XML/1998/namespace":      "xml",
+	"http://www.w3.org/1999/xlink":              "xlink",
+	"http://www.w3.org/1998/Math/MathML":        "mml",
+	"http://www.w3.org/2001/XMLSchema-instance": "xsi",
+}
+
+// node is the element of the XML tree, the text node has no name
+type node struct {
+	name     string
+	attrs    []xml.Attr
+	children []*node
+	text     string
+}
+
+func elem(name string, children ...*node) *node {
+	return (&node{name: name}).add(children...)
+}
+
+func text(s string) *node {
+	return &node{text: s}
+}
+
+// textElem returns the element with the text, nil if the text is empty
+func textElem(name, s string) *node {
+	if s = strings.TrimSpace(s); s == "" {
+		return nil
+	}
+	return elem(name, text(s))
+}
+
+// add appends the children, the nil ones are skipped
+func (n *node) add(children ...*node) *node {
+	for _, child := range children {
+		if child != nil {
+			n.children = append(n.children, child)
+		}
+	}
+	return n
+}
+
+// attr sets the attribute unless the value is empty
+func (n *node) attr(name, value string) *node {
+	if value != "" {
+		n.attrs = append(n.attrs, xml.Attr{Name: xml.Name{Local: name}, Value: value})
+	}
+	return n
+}
+
+func (n *node) get(name string) string {
+	for _, attr := range n.attrs {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// child returns the first child element of the name
+func (n *node) child(name string) *node {
+	if n == nil {
+		return nil
+	}
+	for _, child := range n.children {
+		if child.name == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// path returns the first element found by the names of the nested elements
+func (n *node) path(names ...string) *node {
+	for _, name := range names {
+		n = n.child(name)
+	}
+	return n
+}
+
+// all returns the child elements of the name
+func (n *node) all(name string) []*node {
+	if n == nil {
+		return nil
+	}
+	var children []*node
+	for _, child := range n.children {
+		if child.name == name {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// content returns the text of the element and its descendants with the collapsed spaces
+func (n *node) content() string {
+	if n == nil {
+		return ""
+	}
+	var sb strings.Builder
+	n.writeText(&sb)
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// words returns the texts of the element and its descendants separated by the spaces
+func (n *node) words() string {
+	if n.name == "" {
+		return n.text
+	}
+	words := make([]string, 0, len(n.children))
+	for _, child := range n.children {
+		words = append(words, child.words())
+	}
+	return strings.Join(strings.Fields(strings.Join(words, " ")), " ")
+}
+
+func (n *node) writeText(sb *strings.Builder) {
+	if n.name == "" {
+		sb.WriteString(n.text)
+		return
+	}
+	for _, child := range n.children {
+		child.writeText(sb)
+	}
+}
+
+// qualified returns the name with the prefix of the known namespace
+func qualified(name xml.Name) string {
+	if prefix, ok := prefixes[name.Space]; ok {
+		return prefix + ":" + name.Local
+	}
+	// the undeclared prefix is kept as is
+	if name.Space != "" && !strings.Contains(name.Space, "/") {
+		return name.Space + ":" + name.Local
+	}
+	return name.Local
+}
+
+// parse reads the XML tree, the HTML entities are allowed as they are common in the JATS files
+func parse(data []byte) (*node, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Entity = xml.HTMLEntity
+
+	var (
+		root  *node
+		stack []*node
+	)
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			element := &node{name: qualified(token.Name)}
+			for _, attr := range token.Attr {
+				if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+					continue
+				}
+				element.attrs = append(element.attrs, xml.Attr{Name: xml.Name{Local: qualified(attr.Name)}, Value: attr.Value})
+			}
+			if len(stack) == 0 {
+				root = element
+			} else {
+				stack[len(stack)-1].add(element)
+			}
+			stack = append(stack, element)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].add(text(string(token)))
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	return root, nil
+}
+
+// the elements holding the other elements only, they are written indented,
+// the rest ones have the mixed content and are written as is
+var containers = map[string]bool{
+	"article": true, "front": true, "journal-meta": true, "journal-title-group": true, "publisher": true,
+	"article-meta": true, "article-categories": true, "subj-group": true, "title-group": true,
+	"contrib-group": true, "contrib": true, "name": true, "pub-date": true, "permissions": true,
+	"license": true, "abstract": true, "kwd-group": true, "body": true, "sec": true, "list": true,
+	"list-item": true, "disp-quote": true, "disp-formula": true, "back": true, "ref-list": true,
+	"ref": true, "sub-article": true, "front-stub": true, "custom-meta-group": true, "custom-meta": true,
+}
+
+var (
+	textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "\n", "&#xA;", "\t", "&#x9;")
+)
+
+// validChars drops the characters which aren't allowed in XML
+func validChars(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' || r >= 0x20 && r <= 0xD7FF || r >= 0xE000 && r <= 0xFFFD || r >= 0x10000 && r <= 0x10FFFF {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+func (n *node) write(buf *bytes.Buffer, depth int) {
+	if n.name == "" {
+		buf.WriteString(textEscaper.Replace(validChars(n.text)))
+		return
+	}
+
+	buf.WriteString("<" + n.name)
+	for _, attr := range n.attrs {
+		buf.WriteString(" " + attr.Name.Local + `="` + attrEscaper.Replace(validChars(attr.Value)) + `"`)
+	}
+	if len(n.children) == 0 {
+		buf.WriteString("/>")
+		return
+	}
+	buf.WriteString(">")
+
+	if containers[n.name] {
+		indent := "\n" + strings.Repeat("  ", depth+1)
+		for _, child := range n.children {
+			if child.name == "" && strings.TrimSpace(child.text) == "" {
+				continue
+			}
+			buf.WriteString(indent)
+			child.write(buf, depth+1)
+		}
+		buf.WriteString("\n" + strings.Repeat("  ", depth))
+	} else {
+		for _, child := range n.children {
+			child.write(buf, depth)
+		}
+	}
+
+	buf.WriteString("</" + n.name + ">")
+}
@@ -0,0 +1,19 @@
+package srv
+
+import (
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+)
+
+// checkLicense applies the licensing rules to the work: the license is one of the known ones
+// and the work under the license allowing the commercial reuse can't be sold unless the platform allows it
+func (ls *LibrarySrv) checkLicense(work *storage.Work) error {
+	if !storage.ValidLicense(work.License) {
+		return ErrWrongLicense
+	}
+
+	if storage.LicenseOf(work).MustBeOpen() && !work.OpenAccess && !ls.cfg.PaywallOpenLicenses {
+		return ErrLicensePaywalled
+	}
+
+	return nil
+}
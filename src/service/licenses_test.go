@@ -0,0 +1,41 @@
+package srv
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/SeaOfWisdom/sow_library/src/config"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+)
+
+func TestCheckLicense(t *testing.T) {
+	tests := []struct {
+		name       string
+		license    *storage.WorkLicense
+		openAccess bool
+		paywall    bool
+		want       error
+	}{
+		{"null license sold", nil, false, false, nil},
+		{"CC BY open", &storage.WorkLicense{ID: storage.CCBYLicense}, true, false, nil},
+		{"CC BY sold", &storage.WorkLicense{ID: storage.CCBYLicense}, false, false, ErrLicensePaywalled},
+		{"CC BY-SA sold", &storage.WorkLicense{ID: storage.CCBYSALicense}, false, false, ErrLicensePaywalled},
+		{"CC BY sold on the paywall platform", &storage.WorkLicense{ID: storage.CCBYLicense}, false, true, nil},
+		{"CC BY-NC sold", &storage.WorkLicense{ID: storage.CCBYNCLicense}, false, false, nil},
+		{"all rights reserved sold", &storage.WorkLicense{ID: storage.AllRightsReservedLicense}, false, false, nil},
+		{"custom sold", &storage.WorkLicense{ID: storage.CustomLicense, Text: "The work may be read only."}, false, false, nil},
+		{"custom without the text", &storage.WorkLicense{ID: storage.CustomLicense}, true, false, ErrWrongLicense},
+		{"unknown", &storage.WorkLicense{ID: "GPL-3.0"}, true, false, ErrWrongLicense},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ls := &LibrarySrv{cfg: &config.Config{PaywallOpenLicenses: test.paywall}}
+			work := &storage.Work{License: test.license, OpenAccess: test.openAccess}
+
+			if err := ls.checkLicense(work); !errors.Is(err, test.want) {
+				t.Errorf("checkLicense() = %v, want %v", err, test.want)
+			}
+		})
+	}
+}
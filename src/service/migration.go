@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/SeaOfWisdom/sow_library/src/config"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
 	contractor "github.com/SeaOfWisdom/sow_proto/contractor-srv"
 )
 
@@ -51,8 +52,14 @@ func (ls *LibrarySrv) MigrateFromMongo() {
 		time.Sleep(5 * time.Second)
 	}
 
-	// 4. ensure all works are added into smart contract
+	// 4. ensure all approved works are added into smart contract,
+	// the rest are added once they're approved
 	for _, paper := range papers {
+		if participantsWork, err := ls.storage.GetParticipantWorkByID(paper.Work.ID); err != nil ||
+			participantsWork.Status != storage.OpenWorkStatus {
+			continue
+		}
+
 		paperAddress, err := ls.contractorSrv.GetPaperById(ctx, &contractor.PaperByIdRequest{
 			Id: uuidToUint256(paper.Work.ID),
 		})
@@ -91,20 +98,13 @@ func (ls *LibrarySrv) MigrateFromMongo() {
 			time.Sleep(5 * time.Second)
 		}
 
-		txHash, err := ls.contractorSrv.PublishWork(ctx, &contractor.PublishWorkRequest{
-			Authors: []string{paper.Author.BasicInfo.Web3Address},
-			Name:    paper.Work.Name,
-			Uri:     "DUMMY_URI",
-			WorkId:  uuidToUint256(paper.Work.ID),
-			Price:   faucetCount,
-		})
-		if err != nil {
-			ls.log.Errorf("MigrateFromMongo: add participant, err: %v", err)
+		if err := ls.publishApprovedWork(ctx, paper.Work.ID); err != nil {
+			ls.log.Errorf("MigrateFromMongo: publish work, err: %v", err)
 			time.Sleep(10 * time.Second)
 			continue
 		}
 
-		ls.log.Infof("paper(%s) was added with tx %s", paper.Work.Name, txHash)
+		ls.log.Infof("paper(%s) was added", paper.Work.Name)
 		time.Sleep(5 * time.Second)
 	}
 }
@@ -0,0 +1,160 @@
+package srv
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+	contractor "github.com/SeaOfWisdom/sow_proto/contractor-srv"
+)
+
+// NFTMetadata is the ERC-721 metadata JSON of the work
+type NFTMetadata struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Image       string          `json:"image"`
+	ExternalURL string          `json:"external_url"`
+	Attributes  []*NFTAttribute `json:"attributes"`
+}
+
+type NFTAttribute struct {
+	TraitType string `json:"trait_type"`
+	Value     string `json:"value"`
+}
+
+// OptInWorkNFT opts the work of the author in to be minted as NFT. The contractor can't mint
+// the tokens yet, so the open work is linked to the token of its on-chain paper right away,
+// the rest are linked once they are published.
+func (ls *LibrarySrv) OptInWorkNFT(ctx context.Context, authorAddress, workID string) (*storage.ParticipantsWork, error) {
+	participant, err := ls.storage.GetParticipantByAddress(authorAddress)
+	if err != nil {
+		ls.log.Errorf("OptInWorkNFT: error get participant with address %s, err: %v", authorAddress, err)
+
+		return nil, err
+	}
+
+	participantsWork, err := ls.storage.GetParticipantWorkByID(workID)
+	if err != nil {
+		return nil, err
+	}
+
+	if participantsWork.ParticipantID != participant.ID {
+		return nil, ErrNFTAccessDenied
+	}
+
+	if participantsWork.Status == storage.DeclinedWorkStatus {
+		return nil, ErrNFTNotAllowed
+	}
+
+	if !participantsWork.MintNFT {
+		if err := ls.storage.SetWorkMintNFT(workID); err != nil {
+			ls.log.Errorf("OptInWorkNFT: error opt in work %s, err: %v", workID, err)
+
+			return nil, err
+		}
+	}
+
+	// failed link is retried by the cron
+	if err := ls.linkWorkNFT(ctx, workID); err != nil {
+		ls.log.Errorf("OptInWorkNFT: error link NFT of work %s, err: %v", workID, err)
+	}
+
+	return ls.storage.GetParticipantWorkByID(workID)
+}
+
+// linkApprovedWorksNFT links the open works opted in to be minted to their tokens
+func (ls *LibrarySrv) linkApprovedWorksNFT(ctx context.Context) {
+	workIDs, err := ls.storage.GetUnlinkedNFTWorkIDs()
+	if err != nil {
+		ls.log.Errorf("linkApprovedWorksNFT: error get unlinked works, err: %v", err)
+
+		return
+	}
+
+	for _, workID := range workIDs {
+		if err := ls.linkWorkNFT(ctx, workID); err != nil {
+			ls.log.Errorf("linkApprovedWorksNFT: error link NFT of work %s, err: %v", workID, err)
+		}
+	}
+}
+
+// linkWorkNFT saves the token of the open work opted in to be minted. Nothing is minted here:
+// the contractor has no mint call, the token is the paper created by the library contract
+// on the publication, so its id is the work id and the address is the paper's one.
+func (ls *LibrarySrv) linkWorkNFT(ctx context.Context, workID string) error {
+	participantsWork, err := ls.storage.GetParticipantWorkByID(workID)
+	if err != nil {
+		return err
+	}
+
+	if !participantsWork.MintNFT || participantsWork.NFTTokenID != "" ||
+		participantsWork.Status != storage.OpenWorkStatus {
+		return nil
+	}
+
+	tokenID := uuidToUint256(workID)
+	paper, err := ls.contractorSrv.GetPaperById(ctx, &contractor.PaperByIdRequest{Id: tokenID})
+	if err != nil {
+		return fmt.Errorf("while getting the paper from the contract, err: %v", err)
+	}
+
+	// the work is linked after it's published
+	if paper.Address == zeroAddress {
+		return nil
+	}
+
+	ls.log.Infof("linkWorkNFT: work %s is linked to the token %s of paper %s", workID, tokenID, paper.Address)
+
+	return ls.storage.SetWorkNFT(workID, paper.Address, tokenID)
+}
+
+// GetNFTMetadata returns the metadata of the work linked to the token
+func (ls *LibrarySrv) GetNFTMetadata(ctx context.Context, tokenID string) (*NFTMetadata, error) {
+	participantsWork, err := ls.storage.GetParticipantWorkByTokenID(tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	workResp, err := ls.storage.GetWorkByID(ctx, participantsWork.WorkID)
+	if err != nil {
+		ls.log.Errorf("GetNFTMetadata: error get work %s, err: %v", participantsWork.WorkID, err)
+
+		return nil, err
+	}
+
+	if workResp == nil {
+		return nil, storage.ErrNFTNotExists
+	}
+
+	work := workResp.Work
+	metadata := &NFTMetadata{
+		Name:        work.Name,
+		Description: work.Annotation,
+		Image:       ls.cfg.NFTImageURL,
+		ExternalURL: ls.workURL(work.ID),
+		Attributes:  make([]*NFTAttribute, 0, len(work.Tags)+3),
+	}
+	for _, tag := range work.Tags {
+		metadata.Attributes = append(metadata.Attributes, &NFTAttribute{TraitType: "tag", Value: tag})
+	}
+	if work.Language != "" {
+		metadata.Attributes = append(metadata.Attributes, &NFTAttribute{TraitType: "language", Value: work.Language})
+	}
+	if work.Science != "" {
+		metadata.Attributes = append(metadata.Attributes, &NFTAttribute{TraitType: "science", Value: work.Science})
+	}
+	if workResp.Author != nil && workResp.Author.BasicInfo != nil {
+		metadata.Attributes = append(metadata.Attributes, &NFTAttribute{
+			TraitType: "author",
+			Value:     workResp.Author.BasicInfo.Web3Address,
+		})
+	}
+
+	return metadata, nil
+}
+
+// workURL is the public page of the work in the library
+func (ls *LibrarySrv) workURL(workID string) string {
+	return strings.TrimSuffix(ls.cfg.LibraryURL, "/") + "/works/" + workID
+}
@@ -0,0 +1,377 @@
+package srv
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/citation"
+	"github.com/SeaOfWisdom/sow_library/src/service/jats"
+	"github.com/SeaOfWisdom/sow_library/src/service/oaipmh"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+)
+
+// the formats the records of the open works are disseminated in
+var oaiFormats = []*oaipmh.MetadataFormat{oaipmh.DublinCoreFormat, oaipmh.JATSFormat}
+
+// the default number of the records in the part of the harvested list
+const defaultOAIPageSize = 100
+
+// oaiRepositoryID is the host of the provider, the works are identified as oai:host:work_id
+func (ls *LibrarySrv) oaiRepositoryID() string {
+	for _, rawURL := range []string{ls.cfg.OAIBaseURL, ls.cfg.LibraryURL} {
+		if u, err := url.Parse(rawURL); err == nil && u.Hostname() != "" {
+			return u.Hostname()
+		}
+	}
+	return "localhost"
+}
+
+// HarvestOAI handles the OAI-PMH request, only the open works are exposed. The errors of
+// the request are reported in the response, the error is returned if the request has failed.
+func (ls *LibrarySrv) HarvestOAI(ctx context.Context, values url.Values) (*oaipmh.Response, error) {
+	req, err := oaipmh.ParseRequest(ls.cfg.OAIBaseURL, values)
+	resp := oaipmh.NewResponse(req)
+	if err == nil {
+		err = ls.harvestOAI(ctx, req, resp)
+	}
+
+	var oaiErr *oaipmh.Error
+	if errors.As(err, &oaiErr) {
+		return resp.Fail(oaiErr), nil
+	}
+	if err != nil {
+		ls.log.Errorf("HarvestOAI: error handle %s, err: %v", req.Verb, err)
+
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (ls *LibrarySrv) harvestOAI(ctx context.Context, req *oaipmh.Request, resp *oaipmh.Response) (err error) {
+	switch req.Verb {
+	case oaipmh.IdentifyVerb:
+		resp.Identify, err = ls.oaiIdentify()
+	case oaipmh.ListMetadataFormatsVerb:
+		if req.Identifier != "" {
+			if _, err := ls.oaiWorkStamp(req.Identifier); err != nil {
+				return err
+			}
+		}
+		resp.ListMetadataFormats = &oaipmh.ListMetadataFormats{Formats: oaiFormats}
+	case oaipmh.ListSetsVerb:
+		if req.ResumptionToken != "" {
+			return oaipmh.Errorf(oaipmh.BadResumptionToken, "the list of the sets is never split")
+		}
+		resp.ListSets, err = ls.oaiSets(ctx)
+	case oaipmh.GetRecordVerb:
+		format, err := oaiFormat(req.MetadataPrefix)
+		if err != nil {
+			return err
+		}
+
+		stamp, err := ls.oaiWorkStamp(req.Identifier)
+		if err != nil {
+			return err
+		}
+
+		record, err := ls.oaiRecord(ctx, stamp, format)
+		if err != nil {
+			return err
+		}
+		resp.GetRecord = &oaipmh.GetRecord{Record: record}
+	case oaipmh.ListIdentifiersVerb, oaipmh.ListRecordsVerb:
+		stamps, format, resumption, err := ls.oaiList(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		if req.Verb == oaipmh.ListIdentifiersVerb {
+			workIDs := make([]string, 0, len(stamps))
+			for _, stamp := range stamps {
+				workIDs = append(workIDs, stamp.WorkID)
+			}
+
+			// the headers need the subjects of the works only
+			works, err := ls.storage.GetWorksSubjects(ctx, workIDs)
+			if err != nil {
+				return err
+			}
+
+			list := &oaipmh.ListIdentifiers{ResumptionToken: resumption}
+			for _, stamp := range stamps {
+				work, ok := works[stamp.WorkID]
+				if !ok {
+					return storage.ErrWorkNotExists
+				}
+				list.Headers = append(list.Headers, ls.oaiHeader(stamp, work))
+			}
+			resp.ListIdentifiers = list
+
+			return nil
+		}
+
+		list := &oaipmh.ListRecords{ResumptionToken: resumption}
+		for _, stamp := range stamps {
+			record, err := ls.oaiRecord(ctx, stamp, format)
+			if err != nil {
+				return err
+			}
+			list.Records = append(list.Records, record)
+		}
+		resp.ListRecords = list
+	}
+
+	return err
+}
+
+func oaiFormat(prefix string) (*oaipmh.MetadataFormat, error) {
+	for _, format := range oaiFormats {
+		if format.Prefix == prefix {
+			return format, nil
+		}
+	}
+	return nil, oaipmh.Errorf(oaipmh.CannotDisseminateFormat, "the metadata format %q isn't supported", prefix)
+}
+
+// oaiIdentify describes the repository, the earliest datestamp is the datestamp of the first open work
+func (ls *LibrarySrv) oaiIdentify() (*oaipmh.Identify, error) {
+	identify := &oaipmh.Identify{
+		RepositoryName:  citation.Publisher,
+		BaseURL:         ls.cfg.OAIBaseURL,
+		ProtocolVersion: oaipmh.ProtocolVersion,
+		AdminEmails:     []string{ls.cfg.OAIAdminEmail},
+		DeletedRecord:   oaipmh.DeletedRecord,
+		Granularity:     oaipmh.Granularity,
+	}
+
+	stamps, _, err := ls.storage.GetOpenWorkStamps(&storage.HarvestFilter{}, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	// the repository without the open works is described by the sample of the work id
+	sampleID := "00000000-0000-0000-0000-000000000000"
+	identify.EarliestDatestamp = oaipmh.Datestamp(time.Now())
+	if len(stamps) > 0 {
+		sampleID = stamps[0].WorkID
+		identify.EarliestDatestamp = oaipmh.Datestamp(stamps[0].Datestamp)
+	}
+	identify.Descriptions = []*oaipmh.Description{oaipmh.IdentifierDescription(ls.oaiRepositoryID(), sampleID)}
+
+	return identify, nil
+}
+
+// oaiSets lists the sciences and the tags of the open works
+func (ls *LibrarySrv) oaiSets(ctx context.Context) (*oaipmh.ListSets, error) {
+	sciences, tags, err := ls.storage.GetOpenWorkSubjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &oaipmh.ListSets{Sets: []*oaipmh.Set{
+		{Spec: oaipmh.ScienceSet, Name: "Sciences"},
+		{Spec: oaipmh.TagSet, Name: "Tags"},
+	}}
+	for _, science := range sciences {
+		list.Sets = append(list.Sets, &oaipmh.Set{Spec: oaipmh.SetSpec(oaipmh.ScienceSet, science), Name: science})
+	}
+	for _, tag := range tags {
+		list.Sets = append(list.Sets, &oaipmh.Set{Spec: oaipmh.SetSpec(oaipmh.TagSet, tag), Name: tag})
+	}
+
+	return list, nil
+}
+
+// oaiWorkStamp returns the open work by its OAI identifier
+func (ls *LibrarySrv) oaiWorkStamp(identifier string) (*storage.WorkStamp, error) {
+	workID, ok := oaipmh.ItemID(ls.oaiRepositoryID(), identifier)
+	if !ok {
+		return nil, oaipmh.Errorf(oaipmh.IDDoesNotExist, "the identifier %q is unknown", identifier)
+	}
+
+	stamp, err := ls.storage.GetOpenWorkStamp(workID)
+	if errors.Is(err, storage.ErrWorkNotExists) {
+		return nil, oaipmh.Errorf(oaipmh.IDDoesNotExist, "the identifier %q is unknown", identifier)
+	}
+
+	return stamp, err
+}
+
+// oaiList returns the part of the open works selected by the request, the requested format and the resumption
+// token of the next part. The token is set if the list is split, it's empty in the last part.
+func (ls *LibrarySrv) oaiList(ctx context.Context, req *oaipmh.Request) ([]*storage.WorkStamp, *oaipmh.MetadataFormat, *oaipmh.ResumptionToken, error) {
+	filter := new(storage.HarvestFilter)
+	cursor := 0
+	if req.ResumptionToken != "" {
+		token, err := oaipmh.DecodeToken(req.ResumptionToken)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		req = req.Resume(token)
+		filter.AfterStamp, filter.AfterID = token.AfterStamp, token.AfterID
+		cursor = token.Cursor
+	}
+
+	format, err := oaiFormat(req.MetadataPrefix)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if filter.From, filter.Until, err = req.Range(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	if req.Set != "" {
+		kind, value, err := oaipmh.ParseSetSpec(req.Set)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		field := storage.ScienceField
+		if kind == oaipmh.TagSet {
+			field = storage.TagsField
+		}
+		if filter.WorkIDs, err = ls.storage.GetWorkIDsBySubject(ctx, field, value); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	pageSize := ls.cfg.OAIPageSize
+	if pageSize <= 0 {
+		pageSize = defaultOAIPageSize
+	}
+
+	stamps, total, err := ls.storage.GetOpenWorkStamps(filter, pageSize)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if len(stamps) == 0 {
+		if cursor > 0 {
+			return nil, nil, nil, oaipmh.Errorf(oaipmh.BadResumptionToken, "the list has changed")
+		}
+		return nil, nil, nil, oaipmh.Errorf(oaipmh.NoRecordsMatch, "no works match the request")
+	}
+
+	next := cursor + len(stamps)
+	if cursor == 0 && int64(next) >= total {
+		return stamps, format, nil, nil
+	}
+
+	resumption := &oaipmh.ResumptionToken{CompleteListSize: int(total), Cursor: cursor}
+	if int64(next) < total {
+		last := stamps[len(stamps)-1]
+		resumption.Token = (&oaipmh.Token{
+			MetadataPrefix: req.MetadataPrefix,
+			From:           req.From,
+			Until:          req.Until,
+			Set:            req.Set,
+			AfterStamp:     last.Datestamp,
+			AfterID:        last.WorkID,
+			Cursor:         next,
+		}).Encode()
+	}
+
+	return stamps, format, resumption, nil
+}
+
+// oaiHeader returns the header of the open work with its sets
+func (ls *LibrarySrv) oaiHeader(stamp *storage.WorkStamp, work *storage.Work) *oaipmh.Header {
+	header := &oaipmh.Header{
+		Identifier: oaipmh.Identifier(ls.oaiRepositoryID(), stamp.WorkID),
+		Datestamp:  oaipmh.Datestamp(stamp.Datestamp),
+	}
+	if work.Science != "" {
+		header.SetSpecs = append(header.SetSpecs, oaipmh.SetSpec(oaipmh.ScienceSet, work.Science))
+	}
+	for _, tag := range work.Tags {
+		if tag != "" {
+			header.SetSpecs = append(header.SetSpecs, oaipmh.SetSpec(oaipmh.TagSet, tag))
+		}
+	}
+
+	return header
+}
+
+// oaiRecord returns the metadata of the open work in the format, the content isn't exposed,
+// so the work is read without decrypting it
+func (ls *LibrarySrv) oaiRecord(ctx context.Context, stamp *storage.WorkStamp, format *oaipmh.MetadataFormat) (*oaipmh.Record, error) {
+	workResp, err := ls.storage.GetWorkInfoByID(ctx, stamp.WorkID)
+	if err != nil {
+		return nil, err
+	}
+
+	if workResp == nil {
+		return nil, storage.ErrWorkNotExists
+	}
+	workResp.Work.Status = storage.OpenWorkStatus
+
+	record := &oaipmh.Record{Header: ls.oaiHeader(stamp, workResp.Work)}
+	switch format {
+	case oaipmh.JATSFormat:
+		article, err := ls.jatsArticle(ctx, "", workResp)
+		if err != nil {
+			return nil, err
+		}
+		record.Metadata = &oaipmh.Metadata{Inner: jats.Element(article)}
+	default:
+		if record.Metadata, err = ls.dublinCore(workResp, stamp).Metadata(); err != nil {
+			return nil, err
+		}
+	}
+
+	return record, nil
+}
+
+// dublinCore describes the open work, the date is the datestamp of its opening
+func (ls *LibrarySrv) dublinCore(workResp *storage.WorkResponse, stamp *storage.WorkStamp) *oaipmh.DublinCore {
+	work := workResp.Work
+	item := ls.citationItem(workResp)
+	dc := &oaipmh.DublinCore{
+		Titles:      []string{work.Name},
+		Publishers:  []string{citation.Publisher},
+		Dates:       []string{stamp.Datestamp.UTC().Format("2006-01-02")},
+		Types:       []string{"Text", "info:eu-repo/semantics/article"},
+		Identifiers: []string{ls.workURL(work.ID)},
+	}
+	if work.PID != "" {
+		dc.Identifiers = append(dc.Identifiers, ls.pidURL(work.PID))
+	}
+
+	for _, author := range item.Authors {
+		dc.Creators = append(dc.Creators, author.SortName())
+	}
+	for _, coAuthor := range work.CoAuthors {
+		person := &citation.Person{Given: coAuthor.Name, Middle: coAuthor.MiddleName, Family: coAuthor.Surname, Literal: coAuthor.Name}
+		if name := person.SortName(); name != "" {
+			dc.Creators = append(dc.Creators, name)
+		}
+	}
+
+	if work.Science != "" {
+		dc.Subjects = append(dc.Subjects, work.Science)
+	}
+	dc.Subjects = append(dc.Subjects, work.Tags...)
+	if work.Annotation != "" {
+		dc.Descriptions = []string{work.Annotation}
+	}
+	if work.Language != "" {
+		dc.Languages = []string{work.Language}
+	}
+
+	// the access right is the one of the OpenAIRE vocabulary, the license is referred by its URL
+	dc.Rights = []string{"info:eu-repo/semantics/restrictedAccess"}
+	if work.OpenAccess {
+		dc.Rights[0] = "info:eu-repo/semantics/openAccess"
+	}
+	if license := storage.LicenseOf(work); license.URL != "" {
+		dc.Rights = append(dc.Rights, license.URL)
+	} else {
+		dc.Rights = append(dc.Rights, license.Notice())
+	}
+
+	return dc
+}
@@ -0,0 +1,94 @@
+package oaipmh
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DublinCore is the unqualified Dublin Core record
+type DublinCore struct {
+	XMLName        xml.Name `xml:"oai_dc:dc"`
+	XmlnsOAIDC     string   `xml:"xmlns:oai_dc,attr"`
+	XmlnsDC        string   `xml:"xmlns:dc,attr"`
+	XSI            string   `xml:"xmlns:xsi,attr"`
+	SchemaLocation string   `xml:"xsi:schemaLocation,attr"`
+	Titles         []string `xml:"dc:title"`
+	Creators       []string `xml:"dc:creator"`
+	Subjects       []string `xml:"dc:subject"`
+	Descriptions   []string `xml:"dc:description"`
+	Publishers     []string `xml:"dc:publisher"`
+	Contributors   []string `xml:"dc:contributor"`
+	Dates          []string `xml:"dc:date"`
+	Types          []string `xml:"dc:type"`
+	Formats        []string `xml:"dc:format"`
+	Identifiers    []string `xml:"dc:identifier"`
+	Sources        []string `xml:"dc:source"`
+	Languages      []string `xml:"dc:language"`
+	Relations      []string `xml:"dc:relation"`
+	Rights         []string `xml:"dc:rights"`
+}
+
+// Metadata returns the record as the metadata of the response
+func (dc *DublinCore) Metadata() (*Metadata, error) {
+	dc.XmlnsOAIDC = DublinCoreFormat.Namespace
+	dc.XmlnsDC = "http://purl.org/dc/elements/1.1/"
+	dc.XSI = xsiNamespace
+	dc.SchemaLocation = DublinCoreFormat.Namespace + " " + DublinCoreFormat.Schema
+
+	data, err := xml.MarshalIndent(dc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Metadata{Inner: data}, nil
+}
+
+// the kinds of the sets, the set spec of the kind lists all the items having the kind
+const (
+	ScienceSet = "science"
+	TagSet     = "tag"
+)
+
+// SetSpec returns the spec of the set of the kind and the value. The spec allows the unreserved
+// characters only, the rest bytes of the value are escaped as ~XX.
+func SetSpec(kind, value string) string {
+	var sb strings.Builder
+	sb.WriteString(kind + ":")
+	for _, b := range []byte(value) {
+		if b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' || b == '-' || b == '_' || b == '.' {
+			sb.WriteByte(b)
+		} else {
+			fmt.Fprintf(&sb, "~%02X", b)
+		}
+	}
+	return sb.String()
+}
+
+// ParseSetSpec returns the kind and the value of the set, the value is empty for the set of the kind
+func ParseSetSpec(spec string) (kind, value string, err error) {
+	kind, escaped, _ := strings.Cut(spec, ":")
+	if kind != ScienceSet && kind != TagSet {
+		return "", "", Errorf(BadArgument, "the set %q doesn't exist", spec)
+	}
+
+	var buf []byte
+	for i := 0; i < len(escaped); i++ {
+		if escaped[i] != '~' {
+			buf = append(buf, escaped[i])
+			continue
+		}
+		if i+2 >= len(escaped) {
+			return "", "", Errorf(BadArgument, "the set %q is malformed", spec)
+		}
+		b, err := strconv.ParseUint(escaped[i+1:i+3], 16, 8)
+		if err != nil {
+			return "", "", Errorf(BadArgument, "the set %q is malformed", spec)
+		}
+		buf = append(buf, byte(b))
+		i += 2
+	}
+
+	return kind, string(buf), nil
+}
@@ -0,0 +1,361 @@
+package oaipmh
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/jats"
+)
+
+// the provider implements the OAI-PMH 2.0 with the seconds granularity of the datestamps
+const (
+	ProtocolVersion = "2.0"
+	Granularity     = "YYYY-MM-DDThh:mm:ssZ"
+	ContentType     = "text/xml; charset=utf-8"
+	// DeletedRecord tells the harvesters the repository doesn't keep track of the deleted records
+	DeletedRecord = "no"
+
+	namespace      = "http://www.openarchives.org/OAI/2.0/"
+	xsiNamespace   = "http://www.w3.org/2001/XMLSchema-instance"
+	schemaLocation = namespace + " http://www.openarchives.org/OAI/2.0/OAI-PMH.xsd"
+	timeFormat     = "2006-01-02T15:04:05Z"
+	dateFormat     = "2006-01-02"
+)
+
+// the verbs of the protocol
+const (
+	IdentifyVerb            = "Identify"
+	ListMetadataFormatsVerb = "ListMetadataFormats"
+	ListSetsVerb            = "ListSets"
+	ListIdentifiersVerb     = "ListIdentifiers"
+	ListRecordsVerb         = "ListRecords"
+	GetRecordVerb           = "GetRecord"
+)
+
+// the arguments of the verbs, true marks the required ones
+var verbArguments = map[string]map[string]bool{
+	IdentifyVerb:            {},
+	ListMetadataFormatsVerb: {"identifier": false},
+	ListSetsVerb:            {"resumptionToken": false},
+	ListIdentifiersVerb:     {"metadataPrefix": true, "from": false, "until": false, "set": false, "resumptionToken": false},
+	ListRecordsVerb:         {"metadataPrefix": true, "from": false, "until": false, "set": false, "resumptionToken": false},
+	GetRecordVerb:           {"identifier": true, "metadataPrefix": true},
+}
+
+// the error codes of the protocol
+const (
+	BadArgument             = "badArgument"
+	BadResumptionToken      = "badResumptionToken"
+	BadVerb                 = "badVerb"
+	CannotDisseminateFormat = "cannotDisseminateFormat"
+	IDDoesNotExist          = "idDoesNotExist"
+	NoRecordsMatch          = "noRecordsMatch"
+	NoMetadataFormats       = "noMetadataFormats"
+	NoSetHierarchy          = "noSetHierarchy"
+)
+
+// Error is the error of the request reported to the harvester in the response
+type Error struct {
+	Code    string `xml:"code,attr"`
+	Message string `xml:",chardata"`
+}
+
+func (e *Error) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+// Errorf returns the error of the code
+func Errorf(code, format string, args ...interface{}) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// MetadataFormat is the format the records are disseminated in
+type MetadataFormat struct {
+	Prefix    string `xml:"metadataPrefix"`
+	Schema    string `xml:"schema"`
+	Namespace string `xml:"metadataNamespace"`
+}
+
+// the formats of the records: the unqualified Dublin Core required by the protocol
+// and the front matter of the JATS article
+var (
+	DublinCoreFormat = &MetadataFormat{
+		Prefix:    "oai_dc",
+		Schema:    "http://www.openarchives.org/OAI/2.0/oai_dc.xsd",
+		Namespace: "http://www.openarchives.org/OAI/2.0/oai_dc/",
+	}
+	JATSFormat = &MetadataFormat{
+		Prefix:    "jats",
+		Schema:    "https://jats.nlm.nih.gov/archiving/1.3/xsd/JATS-archivearticle1-3.xsd",
+		Namespace: jats.Namespace,
+	}
+)
+
+// Request is the request echoed in the response, the arguments are omitted
+// if the verb or the arguments are wrong
+type Request struct {
+	Verb            string `xml:"verb,attr,omitempty"`
+	Identifier      string `xml:"identifier,attr,omitempty"`
+	MetadataPrefix  string `xml:"metadataPrefix,attr,omitempty"`
+	From            string `xml:"from,attr,omitempty"`
+	Until           string `xml:"until,attr,omitempty"`
+	Set             string `xml:"set,attr,omitempty"`
+	ResumptionToken string `xml:"resumptionToken,attr,omitempty"`
+	BaseURL         string `xml:",chardata"`
+}
+
+// ParseRequest validates the verb and its arguments, the request is returned
+// with the base URL only if it's wrong
+func ParseRequest(baseURL string, values url.Values) (*Request, error) {
+	req := &Request{BaseURL: baseURL}
+
+	verbs := values["verb"]
+	if len(verbs) != 1 {
+		return req, Errorf(BadVerb, "the verb is missing or repeated")
+	}
+	arguments, ok := verbArguments[verbs[0]]
+	if !ok {
+		return req, Errorf(BadVerb, "the verb %q is illegal", verbs[0])
+	}
+
+	for name, value := range values {
+		if name == "verb" {
+			continue
+		}
+		if _, ok := arguments[name]; !ok {
+			return req, Errorf(BadArgument, "the argument %q is illegal for %s", name, verbs[0])
+		}
+		if len(value) != 1 {
+			return req, Errorf(BadArgument, "the argument %q is repeated", name)
+		}
+	}
+
+	// the resumption token is the exclusive argument
+	if values.Get("resumptionToken") != "" {
+		if len(values) != 2 {
+			return req, Errorf(BadArgument, "the resumption token is the exclusive argument")
+		}
+	} else {
+		for name, required := range arguments {
+			if required && values.Get(name) == "" {
+				return req, Errorf(BadArgument, "the argument %q is missing", name)
+			}
+		}
+	}
+
+	parsed := &Request{
+		Verb:            verbs[0],
+		Identifier:      values.Get("identifier"),
+		MetadataPrefix:  values.Get("metadataPrefix"),
+		From:            values.Get("from"),
+		Until:           values.Get("until"),
+		Set:             values.Get("set"),
+		ResumptionToken: values.Get("resumptionToken"),
+		BaseURL:         baseURL,
+	}
+	if _, _, err := parsed.Range(); err != nil {
+		return req, err
+	}
+
+	return parsed, nil
+}
+
+// parseDatestamp parses the date or the time of the seconds granularity, the date
+// is the start of the day, the granularity is returned to match the other bound
+func parseDatestamp(s string) (time.Time, string, error) {
+	if t, err := time.Parse(dateFormat, s); err == nil {
+		return t, dateFormat, nil
+	}
+	if t, err := time.Parse(timeFormat, s); err == nil {
+		return t, timeFormat, nil
+	}
+	return time.Time{}, "", Errorf(BadArgument, "the datestamp %q doesn't match the granularity", s)
+}
+
+// Range returns the bounds of the selective harvesting, the zero time is the open bound.
+// The until bound is exclusive: the date includes the whole day and the time includes its second.
+func (r *Request) Range() (from, until time.Time, err error) {
+	var fromLayout, untilLayout string
+	if r.From != "" {
+		if from, fromLayout, err = parseDatestamp(r.From); err != nil {
+			return
+		}
+	}
+	if r.Until != "" {
+		if until, untilLayout, err = parseDatestamp(r.Until); err != nil {
+			return
+		}
+		if untilLayout == dateFormat {
+			until = until.AddDate(0, 0, 1)
+		} else {
+			until = until.Add(time.Second)
+		}
+	}
+
+	if fromLayout != "" && untilLayout != "" && fromLayout != untilLayout {
+		err = Errorf(BadArgument, "the from and until arguments have the different granularities")
+		return
+	}
+	if !from.IsZero() && !until.IsZero() && !from.Before(until) {
+		err = Errorf(BadArgument, "the from argument is later than the until argument")
+	}
+
+	return
+}
+
+// Datestamp formats the time of the seconds granularity
+func Datestamp(t time.Time) string {
+	return t.UTC().Format(timeFormat)
+}
+
+// Identifier returns the OAI identifier of the item of the repository
+func Identifier(repositoryID, itemID string) string {
+	return "oai:" + repositoryID + ":" + itemID
+}
+
+// ItemID returns the item of the repository by its OAI identifier
+func ItemID(repositoryID, identifier string) (string, bool) {
+	itemID := strings.TrimPrefix(identifier, "oai:"+repositoryID+":")
+	return itemID, itemID != identifier && itemID != ""
+}
+
+// Identify describes the repository
+type Identify struct {
+	RepositoryName    string         `xml:"repositoryName"`
+	BaseURL           string         `xml:"baseURL"`
+	ProtocolVersion   string         `xml:"protocolVersion"`
+	AdminEmails       []string       `xml:"adminEmail"`
+	EarliestDatestamp string         `xml:"earliestDatestamp"`
+	DeletedRecord     string         `xml:"deletedRecord"`
+	Granularity       string         `xml:"granularity"`
+	Descriptions      []*Description `xml:"description"`
+}
+
+// Description is the description of the repository
+type Description struct {
+	OAIIdentifier *OAIIdentifier `xml:"oai-identifier"`
+}
+
+// OAIIdentifier describes the format of the identifiers of the repository
+type OAIIdentifier struct {
+	Xmlns                string `xml:"xmlns,attr"`
+	SchemaLocation       string `xml:"xsi:schemaLocation,attr"`
+	Scheme               string `xml:"scheme"`
+	RepositoryIdentifier string `xml:"repositoryIdentifier"`
+	Delimiter            string `xml:"delimiter"`
+	SampleIdentifier     string `xml:"sampleIdentifier"`
+}
+
+// IdentifierDescription describes the oai scheme of the identifiers
+func IdentifierDescription(repositoryID, sampleItemID string) *Description {
+	return &Description{OAIIdentifier: &OAIIdentifier{
+		Xmlns:                "http://www.openarchives.org/OAI/2.0/oai-identifier",
+		SchemaLocation:       "http://www.openarchives.org/OAI/2.0/oai-identifier http://www.openarchives.org/OAI/2.0/oai-identifier.xsd",
+		Scheme:               "oai",
+		RepositoryIdentifier: repositoryID,
+		Delimiter:            ":",
+		SampleIdentifier:     Identifier(repositoryID, sampleItemID),
+	}}
+}
+
+// Set is the set of the items for the selective harvesting
+type Set struct {
+	Spec string `xml:"setSpec"`
+	Name string `xml:"setName"`
+}
+
+// Header is the identifier, the datestamp and the sets of the record
+type Header struct {
+	Status     string   `xml:"status,attr,omitempty"`
+	Identifier string   `xml:"identifier"`
+	Datestamp  string   `xml:"datestamp"`
+	SetSpecs   []string `xml:"setSpec"`
+}
+
+// Metadata is the record in the requested format
+type Metadata struct {
+	Inner []byte `xml:",innerxml"`
+}
+
+// Record is the metadata of the item
+type Record struct {
+	Header   *Header   `xml:"header"`
+	Metadata *Metadata `xml:"metadata,omitempty"`
+}
+
+// ResumptionToken continues the incomplete list, the token is empty in the last part of the list
+type ResumptionToken struct {
+	Token            string `xml:",chardata"`
+	CompleteListSize int    `xml:"completeListSize,attr"`
+	Cursor           int    `xml:"cursor,attr"`
+}
+
+type ListMetadataFormats struct {
+	Formats []*MetadataFormat `xml:"metadataFormat"`
+}
+
+type ListSets struct {
+	Sets []*Set `xml:"set"`
+}
+
+type GetRecord struct {
+	Record *Record `xml:"record"`
+}
+
+type ListIdentifiers struct {
+	Headers         []*Header        `xml:"header"`
+	ResumptionToken *ResumptionToken `xml:"resumptionToken,omitempty"`
+}
+
+type ListRecords struct {
+	Records         []*Record        `xml:"record"`
+	ResumptionToken *ResumptionToken `xml:"resumptionToken,omitempty"`
+}
+
+// Response is the OAI-PMH document, it holds either the errors or the result of the verb
+type Response struct {
+	XMLName             xml.Name             `xml:"OAI-PMH"`
+	Xmlns               string               `xml:"xmlns,attr"`
+	XSI                 string               `xml:"xmlns:xsi,attr"`
+	SchemaLocation      string               `xml:"xsi:schemaLocation,attr"`
+	ResponseDate        string               `xml:"responseDate"`
+	Request             *Request             `xml:"request"`
+	Errors              []*Error             `xml:"error"`
+	Identify            *Identify            `xml:"Identify"`
+	ListMetadataFormats *ListMetadataFormats `xml:"ListMetadataFormats"`
+	ListSets            *ListSets            `xml:"ListSets"`
+	GetRecord           *GetRecord           `xml:"GetRecord"`
+	ListIdentifiers     *ListIdentifiers     `xml:"ListIdentifiers"`
+	ListRecords         *ListRecords         `xml:"ListRecords"`
+}
+
+// NewResponse returns the response to the request
+func NewResponse(req *Request) *Response {
+	return &Response{
+		Xmlns:          namespace,
+		XSI:            xsiNamespace,
+		SchemaLocation: schemaLocation,
+		ResponseDate:   Datestamp(time.Now()),
+		Request:        req,
+	}
+}
+
+// Fail replaces the result of the verb by the error
+func (r *Response) Fail(err *Error) *Response {
+	r.Identify, r.ListMetadataFormats, r.ListSets = nil, nil, nil
+	r.GetRecord, r.ListIdentifiers, r.ListRecords = nil, nil, nil
+	r.Errors = append(r.Errors, err)
+	return r
+}
+
+// Marshal writes the response with the XML declaration
+func Marshal(r *Response) ([]byte, error) {
+	data, err := xml.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
@@ -0,0 +1,50 @@
+package oaipmh
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+)
+
+// Token is the state of the incomplete list. The list is ordered by the datestamps
+// and the identifiers of the items, the next part starts after the last item of the previous one.
+type Token struct {
+	MetadataPrefix string    `json:"p"`
+	From           string    `json:"f,omitempty"`
+	Until          string    `json:"u,omitempty"`
+	Set            string    `json:"s,omitempty"`
+	AfterStamp     time.Time `json:"t"`
+	AfterID        string    `json:"i"`
+	Cursor         int       `json:"c"`
+}
+
+// Encode returns the opaque token
+func (t *Token) Encode() string {
+	data, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeToken restores the state of the list by the token
+func DecodeToken(s string) (*Token, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, Errorf(BadResumptionToken, "the resumption token is invalid")
+	}
+
+	token := new(Token)
+	if err := json.Unmarshal(data, token); err != nil || token.MetadataPrefix == "" || token.AfterID == "" {
+		return nil, Errorf(BadResumptionToken, "the resumption token is invalid")
+	}
+
+	return token, nil
+}
+
+// Resume restores the arguments of the request continued by the token
+func (r *Request) Resume(token *Token) *Request {
+	resumed := *r
+	resumed.MetadataPrefix = token.MetadataPrefix
+	resumed.From = token.From
+	resumed.Until = token.Until
+	resumed.Set = token.Set
+	return &resumed
+}
@@ -0,0 +1,164 @@
+package srv
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/blobstore"
+	"github.com/SeaOfWisdom/sow_library/src/service/citation"
+	"github.com/SeaOfWisdom/sow_library/src/service/envelope"
+	"github.com/SeaOfWisdom/sow_library/src/service/ingest"
+	"github.com/SeaOfWisdom/sow_library/src/service/publisher"
+	"github.com/SeaOfWisdom/sow_library/src/service/render"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+)
+
+// the rendering of the work PDF, its body is the key of the PDF sealed by the data key of the work in the blob store
+const pdfRendering = "pdf"
+
+// workCover collects the citation cover page of the work
+func (ls *LibrarySrv) workCover(workResp *storage.WorkResponse) *render.Cover {
+	work := workResp.Work
+	item := ls.citationItem(workResp)
+	cover := &render.Cover{
+		Title:       work.Name,
+		Annotation:  work.Annotation,
+		License:     storage.LicenseOf(work).Notice(),
+		ContentHash: contentHash(work.Content),
+		CID:         work.CID,
+		URL:         item.URL,
+		CiteAs:      citation.APA(item),
+		Date:        item.Issued,
+	}
+	for _, author := range item.Authors {
+		cover.Authors = append(cover.Authors, author.FullName())
+	}
+
+	return cover
+}
+
+// WorkPDF returns the PDF of the work with the citation cover page. The access rules are
+// the same as for the work by id, the PDF is cached per revision of the work and the copy
+// served to the reader is stamped with the reader's watermark.
+func (ls *LibrarySrv) WorkPDF(ctx context.Context, readerAddress, workID string) ([]byte, error) {
+	workResp, err := ls.getReadableWork(ctx, readerAddress, workID)
+	if err != nil {
+		return nil, err
+	}
+
+	content := workResp.Work.Content
+	cover := ls.workCover(workResp)
+	revision, err := publisher.Fingerprint(struct {
+		Cover   *render.Cover `json:"cover"`
+		Format  string        `json:"format"`
+		Content string        `json:"content"`
+	}{cover, content.Format, content.WorkData})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ls.cachedPDF(ctx, workResp.Work, revision)
+	if err != nil {
+		ls.log.Warnf("WorkPDF: cached PDF of work %s isn't read, err: %v", workID, err)
+	}
+
+	if data == nil {
+		doc, err := render.Parse(content.Format, content.WorkData)
+		if err != nil {
+			return nil, err
+		}
+
+		if data, err = doc.PDF(cover, ls.cfg.PDFFont); err != nil {
+			ls.log.Errorf("WorkPDF: error typeset work %s, err: %v", workID, err)
+
+			return nil, err
+		}
+
+		if err := ls.cachePDF(ctx, workResp.Work, revision, data); err != nil {
+			ls.log.Errorf("WorkPDF: error cache PDF of work %s, err: %v", workID, err)
+		}
+	}
+
+	// the authors get their own works unmarked
+	if isWorkAuthor(workResp, readerAddress) {
+		return data, nil
+	}
+
+	if ls.marker == nil {
+		return data, nil
+	}
+
+	mark, err := ls.readerMark(readerAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	// the PDF which can't be stamped isn't served
+	stamped, err := ls.marker.StampPDF(data, mark)
+	if err != nil {
+		ls.log.Errorf("WorkPDF: error stamp PDF of work %s, err: %v", workID, err)
+
+		return nil, fmt.Errorf("%w: %v", ErrWatermarkFailed, err)
+	}
+
+	return stamped, nil
+}
+
+// cachePDF stores the PDF of the revision sealed by the data key of the work
+func (ls *LibrarySrv) cachePDF(ctx context.Context, work *storage.Work, revision string, data []byte) error {
+	key, err := ls.storage.WorkDataKey(work)
+	if err != nil {
+		return err
+	}
+
+	sealed, err := envelope.Seal(key, data)
+	if err != nil {
+		return err
+	}
+
+	blobKey := blobstore.Key(sealed)
+	if err := ls.blobs.Put(ctx, blobKey, sealed, ingest.PDFContentType); err != nil {
+		return err
+	}
+
+	return ls.storage.SaveWorkRendering(work, &storage.WorkRendering{
+		WorkID: work.ID, ContentHash: revision, Output: pdfRendering, Body: blobKey,
+	})
+}
+
+// cachedPDF reads the PDF of the revision from the blob store, nil if it hasn't been typeset yet.
+// The PDF which can't be opened, as the one stored before the PDFs were sealed, is dropped
+// from the cache to be typeset again.
+func (ls *LibrarySrv) cachedPDF(ctx context.Context, work *storage.Work, revision string) ([]byte, error) {
+	rendering, err := ls.storage.GetWorkRendering(work, revision, pdfRendering)
+	if err != nil || rendering == nil {
+		return nil, err
+	}
+
+	reader, err := ls.blobs.Get(ctx, rendering.Body)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ls.storage.WorkDataKey(work)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := envelope.Open(key, sealed)
+	if err != nil {
+		if err := ls.blobs.Delete(ctx, rendering.Body); err != nil {
+			ls.log.Warnf("cachedPDF: PDF %s isn't deleted, err: %v", rendering.Body, err)
+		}
+
+		return nil, ls.storage.DeleteWorkRendering(rendering.ID)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,23 @@
+package pid
+
+import "context"
+
+// Registrations keeps the URLs the minted identifiers are registered to
+type Registrations interface {
+	// SetPersistentIDURL saves the URL the identifier resolves to
+	SetPersistentIDURL(pid, url string) error
+}
+
+// LibraryRegistrar registers the identifiers in the database of the library, they are resolved
+// by the library itself until the external registrar is configured
+type LibraryRegistrar struct {
+	registrations Registrations
+}
+
+func NewLibraryRegistrar(registrations Registrations) *LibraryRegistrar {
+	return &LibraryRegistrar{registrations: registrations}
+}
+
+func (lr *LibraryRegistrar) Register(_ context.Context, pid, url string) error {
+	return lr.registrations.SetPersistentIDURL(pid, url)
+}
@@ -0,0 +1,36 @@
+package pid
+
+import (
+	"context"
+	"sync"
+)
+
+// LocalRegistrar keeps the registrations in memory, it's the fake registrar of the tests,
+// the registrations are lost on restart
+type LocalRegistrar struct {
+	mu   sync.RWMutex
+	urls map[string]string
+}
+
+func NewLocalRegistrar() *LocalRegistrar {
+	return &LocalRegistrar{urls: make(map[string]string)}
+}
+
+func (lr *LocalRegistrar) Register(_ context.Context, pid, url string) error {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+
+	lr.urls[pid] = url
+
+	return nil
+}
+
+// Resolve returns the URL the identifier is registered to
+func (lr *LocalRegistrar) Resolve(pid string) (string, bool) {
+	lr.mu.RLock()
+	defer lr.mu.RUnlock()
+
+	url, ok := lr.urls[pid]
+
+	return url, ok
+}
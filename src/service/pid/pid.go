@@ -0,0 +1,65 @@
+package pid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/SeaOfWisdom/sow_library/src/config"
+)
+
+// types of the registrars
+const (
+	LibraryRegistrarType = "library"
+	// the former name of the library registrar
+	LocalRegistrarType = "local"
+)
+
+var ErrMalformed = errors.New("malformed persistent identifier")
+
+// Registrar registers the minted identifiers with the external resolver, e.g. the DOI registration agency
+type Registrar interface {
+	// Register makes the identifier resolve to the URL
+	Register(ctx context.Context, pid, url string) error
+}
+
+// NewRegistrar creates the registrar of the configured type, the library registrar
+// keeps the registrations in the registrations store
+func NewRegistrar(cfg *config.Config, registrations Registrations) (Registrar, error) {
+	switch cfg.PIDRegistrar {
+	case LibraryRegistrarType, LocalRegistrarType:
+		return NewLibraryRegistrar(registrations), nil
+	default:
+		return nil, fmt.Errorf("unknown PID registrar %q", cfg.PIDRegistrar)
+	}
+}
+
+// the suffix of the identifier is "sow.<year>.<sequence number in the year>"
+var suffix = regexp.MustCompile(`^sow\.(\d{4})\.(\d{5,})$`)
+
+// Format returns the identifier of the sequence number in the year under the prefix, e.g. 10.5555/sow.2026.00042
+func Format(prefix string, year, sequence int) string {
+	return fmt.Sprintf("%s/sow.%d.%05d", prefix, year, sequence)
+}
+
+// Parse returns the year and the sequence number of the identifier minted under the prefix
+func Parse(prefix, pid string) (year, sequence int, err error) {
+	if len(pid) <= len(prefix)+1 || pid[:len(prefix)] != prefix || pid[len(prefix)] != '/' {
+		return 0, 0, ErrMalformed
+	}
+
+	match := suffix.FindStringSubmatch(pid[len(prefix)+1:])
+	if match == nil {
+		return 0, 0, ErrMalformed
+	}
+
+	year, _ = strconv.Atoi(match[1])
+	sequence, err = strconv.Atoi(match[2])
+	if err != nil {
+		return 0, 0, ErrMalformed
+	}
+
+	return year, sequence, nil
+}
@@ -0,0 +1,110 @@
+package pid
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/SeaOfWisdom/sow_library/src/config"
+)
+
+const testPrefix = "10.5555"
+
+func TestFormatParse(t *testing.T) {
+	pid := Format(testPrefix, 2026, 42)
+	if pid != "10.5555/sow.2026.00042" {
+		t.Fatalf("Format = %q", pid)
+	}
+
+	year, sequence, err := Parse(testPrefix, pid)
+	if err != nil || year != 2026 || sequence != 42 {
+		t.Errorf("Parse(%q) = %d, %d, %v", pid, year, sequence, err)
+	}
+
+	if _, sequence, err := Parse(testPrefix, Format(testPrefix, 2026, 123456)); err != nil || sequence != 123456 {
+		t.Errorf("Parse of the 6 digits sequence = %d, %v", sequence, err)
+	}
+
+	for _, malformed := range []string{
+		"",
+		"10.5555",
+		"10.5555/",
+		"10.55551/sow.2026.00042",
+		"10.1234/sow.2026.00042",
+		"10.5555/sow.26.00042",
+		"10.5555/sow.2026.42",
+		"10.5555/work.2026.00042",
+		"10.5555/sow.2026.00042/extra",
+	} {
+		if _, _, err := Parse(testPrefix, malformed); !errors.Is(err, ErrMalformed) {
+			t.Errorf("Parse(%q) err = %v, want ErrMalformed", malformed, err)
+		}
+	}
+}
+
+// registrations is the registrations store keeping the minted identifiers only
+type registrations map[string]string
+
+func (r registrations) SetPersistentIDURL(pid, url string) error {
+	if _, ok := r[pid]; !ok {
+		return errors.New("the identifier isn't minted")
+	}
+	r[pid] = url
+
+	return nil
+}
+
+func TestNewRegistrar(t *testing.T) {
+	store := registrations{}
+	for _, registrarType := range []string{LibraryRegistrarType, LocalRegistrarType} {
+		registrar, err := NewRegistrar(&config.Config{PIDRegistrar: registrarType}, store)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, ok := registrar.(*LibraryRegistrar); !ok {
+			t.Errorf("the registrar of the type %q is %T, want the library registrar", registrarType, registrar)
+		}
+	}
+
+	if _, err := NewRegistrar(&config.Config{PIDRegistrar: "datacite"}, store); err == nil {
+		t.Error("the registrar of the unknown type is created")
+	}
+}
+
+func TestLibraryRegistrar(t *testing.T) {
+	pid := Format(testPrefix, 2026, 1)
+	store := registrations{pid: ""}
+	registrar := NewLibraryRegistrar(store)
+
+	if err := registrar.Register(context.Background(), pid, "https://seaofwisdom.io/works/1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if store[pid] != "https://seaofwisdom.io/works/1" {
+		t.Errorf("the registered URL = %q", store[pid])
+	}
+
+	if err := registrar.Register(context.Background(), Format(testPrefix, 2026, 2), "https://seaofwisdom.io/works/2"); err == nil {
+		t.Error("the identifier which isn't minted is registered")
+	}
+}
+
+func TestLocalRegistrar(t *testing.T) {
+	registrar := NewLocalRegistrar()
+	pid := Format(testPrefix, 2026, 1)
+
+	if _, ok := registrar.Resolve(pid); ok {
+		t.Error("the identifier is resolved before the registration")
+	}
+
+	for _, url := range []string{"https://seaofwisdom.io/works/1", "https://seaofwisdom.io/works/1?v=2"} {
+		if err := registrar.Register(context.Background(), pid, url); err != nil {
+			t.Fatal(err)
+		}
+
+		if resolved, ok := registrar.Resolve(pid); !ok || resolved != url {
+			t.Errorf("Resolve = %q, %v, want %q", resolved, ok, url)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package srv
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/pid"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+)
+
+// pidURL is the URL resolving the persistent identifier by the library
+func (ls *LibrarySrv) pidURL(persistentID string) string {
+	return strings.TrimSuffix(ls.cfg.LibraryURL, "/") + "/id/" + persistentID
+}
+
+// identifyOpenWorks mints the identifiers of the open works which haven't got them
+// and retries the registration of the identifiers the registrar failed to register
+func (ls *LibrarySrv) identifyOpenWorks(ctx context.Context) {
+	workIDs, err := ls.storage.GetUnidentifiedWorkIDs()
+	if err != nil {
+		ls.log.Errorf("identifyOpenWorks: error get unidentified works, err: %v", err)
+
+		return
+	}
+
+	for _, workID := range workIDs {
+		if err := ls.identifyWork(ctx, workID); err != nil {
+			ls.log.Errorf("identifyOpenWorks: error mint PID of work %s, err: %v", workID, err)
+		}
+	}
+
+	persistentIDs, err := ls.storage.GetUnregisteredPersistentIDs()
+	if err != nil {
+		ls.log.Errorf("identifyOpenWorks: error get unregistered PIDs, err: %v", err)
+
+		return
+	}
+
+	for _, persistentID := range persistentIDs {
+		if err := ls.registerPID(ctx, persistentID); err != nil {
+			ls.log.Errorf("identifyOpenWorks: error register PID %s, err: %v", persistentID.PID, err)
+		}
+	}
+}
+
+// identifyWork mints the persistent identifier of the open work once, the identifier
+// is kept across the revisions of the work. The failed registration is retried by the cron.
+func (ls *LibrarySrv) identifyWork(ctx context.Context, workID string) error {
+	participantsWork, err := ls.storage.GetParticipantWorkByID(workID)
+	if err != nil {
+		return err
+	}
+
+	if participantsWork.Status != storage.OpenWorkStatus {
+		return nil
+	}
+
+	persistentID, err := ls.storage.MintPersistentID(workID, time.Now().UTC().Year(), func(year, sequence int) string {
+		return pid.Format(ls.cfg.PIDPrefix, year, sequence)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := ls.storage.SetWorkPID(ctx, workID, persistentID.PID); err != nil {
+		return err
+	}
+
+	if persistentID.RegisteredAt == nil {
+		if err := ls.registerPID(ctx, persistentID); err != nil {
+			ls.log.Errorf("identifyWork: error register PID %s, err: %v", persistentID.PID, err)
+		}
+	}
+
+	ls.log.Infof("identifyWork: work %s is identified by %s", workID, persistentID.PID)
+
+	return nil
+}
+
+// registerPID registers the identifier to resolve to the page of the work
+func (ls *LibrarySrv) registerPID(ctx context.Context, persistentID *storage.PersistentID) error {
+	if err := ls.registrar.Register(ctx, persistentID.PID, ls.workURL(persistentID.WorkID)); err != nil {
+		return err
+	}
+
+	return ls.storage.SetPersistentIDRegistered(persistentID.ID)
+}
+
+// ResolvePID returns the URL the persistent identifier is registered to,
+// the identifier which hasn't been registered yet resolves to the page of the work
+func (ls *LibrarySrv) ResolvePID(persistentID string) (string, error) {
+	if _, _, err := pid.Parse(ls.cfg.PIDPrefix, persistentID); err != nil {
+		return "", err
+	}
+
+	minted, err := ls.storage.GetPersistentID(persistentID)
+	if err != nil {
+		return "", err
+	}
+
+	if minted.URL != "" {
+		return minted.URL, nil
+	}
+
+	return ls.workURL(minted.WorkID), nil
+}
@@ -1,63 +1,101 @@
 package pinata
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"io"
+	"mime/multipart"
 	"net/http"
+	"net/url"
 	"strings"
-
-	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+	"time"
 )
 
-// API Key: bdaf0e02c3e8ac255561
-//  API Secret: d8df802a359885a6e13a54114d6fa50049a4807ee6949586f312986accb13271
-//  JWT: eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.eyJ1c2VySW5mb3JtYXRpb24iOnsiaWQiOiI3OGQzZWUwNC04YmU3LTQ2NjQtYTQwMy1hYTEwYWZkYmQzODYiLCJlbWFpbCI6Imdvcm9ob3ZkYW5paWwxOUBnbWFpbC5jb20iLCJlbWFpbF92ZXJpZmllZCI6dHJ1ZSwicGluX3BvbGljeSI6eyJyZWdpb25zIjpbeyJpZCI6IkZSQTEiLCJkZXNpcmVkUmVwbGljYXRpb25Db3VudCI6MX0seyJpZCI6Ik5ZQzEiLCJkZXNpcmVkUmVwbGljYXRpb25Db3VudCI6MX1dLCJ2ZXJzaW9uIjoxfSwibWZhX2VuYWJsZWQiOmZhbHNlLCJzdGF0dXMiOiJBQ1RJVkUifSwiYXV0aGVudGljYXRpb25UeXBlIjoic2NvcGVkS2V5Iiwic2NvcGVkS2V5S2V5IjoiYmRhZjBlMDJjM2U4YWMyNTU1NjEiLCJzY29wZWRLZXlTZWNyZXQiOiJkOGRmODAyYTM1OTg4NWE2ZTEzYTU0MTE0ZDZmYTUwMDQ5YTQ4MDdlZTY5NDk1ODZmMzEyOTg2YWNjYjEzMjcxIiwiaWF0IjoxNjgyNzAzMDYwfQ.1koXoIVOYFjng6KI2WbmalJPPY2rrUqwtXoSoQj0Ry4
-
-const pinataJWT = "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.eyJ1c2VySW5mb3JtYXRpb24iOnsiaWQiOiI3OGQzZWUwNC04YmU3LTQ2NjQtYTQwMy1hYTEwYWZkYmQzODYiLCJlbWFpbCI6Imdvcm9ob3ZkYW5paWwxOUBnbWFpbC5jb20iLCJlbWFpbF92ZXJpZmllZCI6dHJ1ZSwicGluX3BvbGljeSI6eyJyZWdpb25zIjpbeyJpZCI6IkZSQTEiLCJkZXNpcmVkUmVwbGljYXRpb25Db3VudCI6MX0seyJpZCI6Ik5ZQzEiLCJkZXNpcmVkUmVwbGljYXRpb25Db3VudCI6MX1dLCJ2ZXJzaW9uIjoxfSwibWZhX2VuYWJsZWQiOmZhbHNlLCJzdGF0dXMiOiJBQ1RJVkUifSwiYXV0aGVudGljYXRpb25UeXBlIjoic2NvcGVkS2V5Iiwic2NvcGVkS2V5S2V5IjoiYmRhZjBlMDJjM2U4YWMyNTU1NjEiLCJzY29wZWRLZXlTZWNyZXQiOiJkOGRmODAyYTM1OTg4NWE2ZTEzYTU0MTE0ZDZmYTUwMDQ5YTQ4MDdlZTY5NDk1ODZmMzEyOTg2YWNjYjEzMjcxIiwiaWF0IjoxNjgyNzAzMDYwfQ.1koXoIVOYFjng6KI2WbmalJPPY2rrUqwtXoSoQj0Ry4"
-
-func PublishJson(work *storage.Work) string {
-
-	// 	payload := strings.NewReader(`{
-	//     "pinataOptions": {
-	//         "cidVersion": 1
-	//     },
-	//     "pinataMetadata": {
-	//         "name": "testing",
-	//         "keyvalues": {
-	//             "customKey": "customValue",
-	//             "customKey2": "customValue2"
-	//         }
-	//     },
-	//     "pinataContent": {
-	//         "somekey":"somevalue"
-	//     }
-	// }`)
-
-	req, err := http.NewRequest(
-		"POST",
-		"https://api.pinata.cloud/pinning/pinJSONToIPFS",
-		strings.NewReader(""),
-	)
+// Client pins the files via the Pinata API
+type Client struct {
+	url    string
+	jwt    string
+	client *http.Client
+}
+
+func NewClient(apiURL, jwt string) (*Client, error) {
+	u, err := url.Parse(apiURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("wrong Pinata URL %q", apiURL)
+	}
+
+	if jwt == "" {
+		return nil, fmt.Errorf("the Pinata JWT is null")
+	}
+
+	return &Client{
+		url:    strings.TrimSuffix(apiURL, "/"),
+		jwt:    jwt,
+		client: &http.Client{Timeout: time.Minute},
+	}, nil
+}
+
+type pinResponse struct {
+	IpfsHash  string `json:"IpfsHash"`
+	PinSize   int64  `json:"PinSize"`
+	Timestamp string `json:"Timestamp"`
+}
+
+// Publish pins the content as a file, so its CID is computed from the exact bytes
+func (c *Client) Publish(ctx context.Context, name string, content []byte) (string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	part, err := writer.CreateFormFile("file", name)
 	if err != nil {
-		fmt.Println(err)
-		return ""
+		return "", err
+	}
+	if _, err := part.Write(content); err != nil {
+		return "", err
 	}
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", pinataJWT))
 
-	client := &http.Client{}
-	res, err := client.Do(req)
+	metadata, err := json.Marshal(map[string]string{"name": name})
 	if err != nil {
-		fmt.Println(err)
-		return ""
+		return "", err
+	}
+	if err := writer.WriteField("pinataMetadata", string(metadata)); err != nil {
+		return "", err
+	}
+	if err := writer.WriteField("pinataOptions", `{"cidVersion":1}`); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
 	}
-	defer res.Body.Close()
 
-	body, err := ioutil.ReadAll(res.Body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+"/pinning/pinFileToIPFS", &body)
 	if err != nil {
-		fmt.Println(err)
-		return ""
+		return "", err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+c.jwt)
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return "", fmt.Errorf("pinata: unexpected status %s: %s", res.Status, strings.TrimSpace(string(msg)))
+	}
+
+	pin := new(pinResponse)
+	if err := json.NewDecoder(res.Body).Decode(pin); err != nil {
+		return "", fmt.Errorf("pinata: wrong response, err: %v", err)
 	}
-	fmt.Println(string(body))
-	return "ID"
+
+	if pin.IpfsHash == "" {
+		return "", fmt.Errorf("pinata: the response has no CID")
+	}
+
+	return pin.IpfsHash, nil
 }
@@ -0,0 +1,126 @@
+package pinata
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testJWT = "test-jwt"
+
+// pinataStandIn accepts the files pinned by the test JWT and returns
+// the CID of the file named in the metadata
+func pinataStandIn(t *testing.T, pinned map[string][]byte) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/pinning/pinFileToIPFS" {
+			http.NotFound(w, r)
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer "+testJWT {
+			http.Error(w, `{"error":"Invalid authentication"}`, http.StatusUnauthorized)
+			return
+		}
+
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+
+		content, err := io.ReadAll(file)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var metadata map[string]string
+		if err := json.Unmarshal([]byte(r.FormValue("pinataMetadata")), &metadata); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if r.FormValue("pinataOptions") != `{"cidVersion":1}` {
+			t.Errorf("pinataOptions = %q", r.FormValue("pinataOptions"))
+		}
+		if metadata["name"] != header.Filename {
+			t.Errorf("the metadata name %q isn't the file name %q", metadata["name"], header.Filename)
+		}
+		pinned[header.Filename] = content
+
+		json.NewEncoder(w).Encode(pinResponse{
+			IpfsHash:  "bafy-" + strings.TrimSuffix(header.Filename, ".json"),
+			PinSize:   int64(len(content)),
+			Timestamp: "2026-10-19T00:00:00Z",
+		})
+	})
+}
+
+func TestPublish(t *testing.T) {
+	pinned := map[string][]byte{}
+	server := httptest.NewServer(pinataStandIn(t, pinned))
+	defer server.Close()
+
+	client, err := NewClient(server.URL+"/", testJWT)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := []byte(`{"id":"work","content":"c2VhbGVk"}`)
+	cid, err := client.Publish(context.Background(), "work.json", content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cid != "bafy-work" {
+		t.Errorf("CID = %q, want bafy-work", cid)
+	}
+
+	if string(pinned["work.json"]) != string(content) {
+		t.Errorf("pinned %q, want %q", pinned["work.json"], content)
+	}
+}
+
+func TestPublishErrors(t *testing.T) {
+	server := httptest.NewServer(pinataStandIn(t, map[string][]byte{}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "wrong-jwt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Publish(context.Background(), "work.json", []byte("{}")); err == nil ||
+		!strings.Contains(err.Error(), "401") {
+		t.Errorf("Publish with the wrong JWT err = %v", err)
+	}
+
+	noCID := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"PinSize":2}`))
+	}))
+	defer noCID.Close()
+
+	client, err = NewClient(noCID.URL, testJWT)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Publish(context.Background(), "work.json", []byte("{}")); err == nil {
+		t.Error("Publish without the CID in the response succeeded")
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	if _, err := NewClient("https://api.pinata.cloud", ""); err == nil {
+		t.Error("the client without JWT is created")
+	}
+
+	if _, err := NewClient("api.pinata.cloud", testJWT); err == nil {
+		t.Error("the client with the URL without scheme is created")
+	}
+}
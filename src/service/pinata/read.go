@@ -1,35 +0,0 @@
-package pinata
-
-import (
-	"fmt"
-	"io/ioutil"
-	"net/http"
-)
-
-func Read() {
-	url := "https://api.pinata.cloud/data/pinList?status=pinned&pinSizeMin=100"
-	method := "GET"
-
-	client := &http.Client{}
-	req, err := http.NewRequest(method, url, nil)
-
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
-	req.Header.Add("Authorization", "Bearer PINATA JWT")
-
-	res, err := client.Do(req)
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
-	defer res.Body.Close()
-
-	body, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
-	fmt.Println(string(body))
-}
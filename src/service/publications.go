@@ -0,0 +1,441 @@
+package srv
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/envelope"
+	"github.com/SeaOfWisdom/sow_library/src/service/publisher"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+	contractor "github.com/SeaOfWisdom/sow_proto/contractor-srv"
+)
+
+// the address returned by the contract for the unknown paper
+const zeroAddress = "0x0000000000000000000000000000000000000000"
+
+// PublishedWork is the document of the approved work pinned to IPFS, the fingerprint is computed
+// over the document without it and without the content, the content is fingerprinted by its hash.
+// The encrypted content is sealed with the random nonce, so the hash is the one of the plain text.
+type PublishedWork struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Annotation  string            `json:"annotation"`
+	Authors     []string          `json:"authors"`
+	Tags        []string          `json:"tags"`
+	Language    string            `json:"language"`
+	Science     string            `json:"science"`
+	Sources     string            `json:"sources"`
+	Content     string            `json:"content"`
+	ContentHash string            `json:"content_hash,omitempty"`
+	Format      string            `json:"format,omitempty"`
+	Encryption  string            `json:"encryption,omitempty"` // the content is base64 encoded ciphertext if set
+	Files       []*PublishedFile  `json:"files"`
+	License     *PublishedLicense `json:"license,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	Fingerprint string            `json:"fingerprint,omitempty"`
+}
+
+// PublishedFile is the file attached to the work, referenced by the hash of its data
+type PublishedFile struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	SHA256      string `json:"sha256"`
+}
+
+// PublishedLicense is the license of the work, the reserved works are pinned without it,
+// so the fingerprints of the works published before the licensing are kept
+type PublishedLicense struct {
+	ID   storage.LicenseID `json:"id"`
+	URL  string            `json:"url,omitempty"`
+	Text string            `json:"text,omitempty"`
+}
+
+func newPublishedWork(workResp *storage.WorkResponse, records []*storage.BlobRecord) *PublishedWork {
+	work := workResp.Work
+	published := &PublishedWork{
+		ID:         work.ID,
+		Name:       work.Name,
+		Annotation: work.Annotation,
+		Authors:    []string{workResp.Author.BasicInfo.Web3Address},
+		Tags:       work.Tags,
+		Language:   work.Language,
+		Science:    work.Science,
+		Sources:    work.Sources,
+		Files:      make([]*PublishedFile, 0, len(records)),
+		CreatedAt:  work.CreatedAt.UTC(),
+	}
+	if work.Content != nil {
+		published.Format = work.Content.Format
+		published.ContentHash = contentHash(work.Content)
+	}
+	if license := storage.LicenseOf(work); license.ID != storage.AllRightsReservedLicense {
+		published.License = &PublishedLicense{ID: license.ID, URL: license.URL, Text: license.Text}
+	}
+	// the encrypted content is pinned as is, its key is delivered to the readers
+	switch {
+	case work.Content != nil && len(work.Content.Cipher) > 0:
+		published.Content = base64.StdEncoding.EncodeToString(work.Content.Cipher)
+		published.Encryption = envelope.Algorithm
+	case work.Content != nil:
+		published.Content = work.Content.WorkData
+	}
+
+	// the blobs are content addressed, the key is the hash of the data
+	for _, record := range records {
+		published.Files = append(published.Files, &PublishedFile{
+			Name:        record.Name,
+			ContentType: record.ContentType,
+			Size:        record.Size,
+			SHA256:      record.Key,
+		})
+	}
+
+	return published
+}
+
+// fingerprint computes the fingerprint of the document, the content is fingerprinted by its hash
+func (pw PublishedWork) fingerprint() (string, error) {
+	pw.Fingerprint, pw.Content = "", ""
+	return publisher.Fingerprint(pw)
+}
+
+// publishedWork builds the fingerprinted document of the current revision of the work
+func (ls *LibrarySrv) publishedWork(workResp *storage.WorkResponse) (*PublishedWork, error) {
+	records, err := ls.storage.GetWorkBlobRecords(workResp.Work.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	published := newPublishedWork(workResp, records)
+	if published.Fingerprint, err = published.fingerprint(); err != nil {
+		return nil, err
+	}
+
+	return published, nil
+}
+
+// PublishApprovedWorks retries the publication of the approved works which
+// failed to be pinned or published on-chain, then links the published ones opted in to
+// their NFTs, mints the persistent identifiers of the open works and deposits the identified ones
+func (ls *LibrarySrv) PublishApprovedWorks() {
+	if !ls.publishMu.TryLock() {
+		return
+	}
+	defer ls.publishMu.Unlock()
+
+	ctx := context.Background()
+	workIDs, err := ls.storage.GetUnpublishedWorkIDs(ctx)
+	if err != nil {
+		ls.log.Errorf("PublishApprovedWorks: error get unpublished works, err: %v", err)
+
+		return
+	}
+
+	for _, workID := range workIDs {
+		if err := ls.publishApprovedWork(ctx, workID); err != nil {
+			ls.log.Errorf("PublishApprovedWorks: error publish work %s, err: %v", workID, err)
+		}
+	}
+
+	ls.linkApprovedWorksNFT(ctx)
+	ls.identifyOpenWorks(ctx)
+	ls.depositIdentifiedWorks(ctx)
+}
+
+// revisionURI is the URI the revision is published on-chain with: the IPFS URI of the
+// pinned revision or the page of the work if the revision hasn't been pinned
+func (ls *LibrarySrv) revisionURI(revision *storage.WorkRevision) string {
+	if revision.CID != "" {
+		return publisher.FingerprintURI(publisher.URI(revision.CID), revision.Fingerprint)
+	}
+	return publisher.FingerprintURI(ls.workURL(revision.WorkID), revision.Fingerprint)
+}
+
+// publishApprovedWork pins the canonical JSON of the work to IPFS once per revision and
+// publishes the work on-chain with the URI carrying the fingerprint of the revision,
+// the works are published on-chain without pinning if the content publisher isn't configured
+func (ls *LibrarySrv) publishApprovedWork(ctx context.Context, workID string) error {
+	workResp, err := ls.storage.GetWorkByID(ctx, workID)
+	if err != nil {
+		return err
+	}
+
+	if workResp == nil {
+		return storage.ErrWorkNotExists
+	}
+
+	work := workResp.Work
+	published, err := ls.publishedWork(workResp)
+	if err != nil {
+		return err
+	}
+
+	revision, err := ls.storage.GetLastWorkRevision(work.ID)
+	if err != nil && !errors.Is(err, storage.ErrWorkRevisionNotExists) {
+		return err
+	}
+
+	// the revision is pinned unless the content publisher isn't configured
+	pinned := revision != nil && (revision.CID != "" || ls.publisher == nil)
+
+	// the work updated without the change of its content is still published
+	if work.Published && revision != nil && revision.Fingerprint == published.Fingerprint && pinned {
+		return ls.storage.SetWorkPublished(ctx, work.ID, work.PublishTxHash)
+	}
+
+	// the works published on-chain before the pinning was introduced can't be published
+	// again, their first revisions are pinned only
+	prepinned := false
+	if revision == nil {
+		paper, err := ls.contractorSrv.GetPaperById(ctx, &contractor.PaperByIdRequest{Id: uuidToUint256(work.ID)})
+		if err != nil {
+			return fmt.Errorf("while getting the paper from the contract, err: %v", err)
+		}
+		prepinned = paper.Address != zeroAddress
+	}
+
+	if revision == nil || revision.Fingerprint != published.Fingerprint || !pinned {
+		cid := ""
+		if ls.publisher != nil {
+			// IPFS is public, the content is pinned encrypted only
+			if published.Content != "" && published.Encryption == "" {
+				return ErrPlainContent
+			}
+
+			content, err := publisher.CanonicalJSON(published)
+			if err != nil {
+				return err
+			}
+
+			if cid, err = ls.publisher.Publish(ctx, work.ID+".json", content); err != nil {
+				return fmt.Errorf("while pinning the work, err: %v", err)
+			}
+		}
+
+		license := storage.LicenseOf(work)
+		revision = &storage.WorkRevision{
+			WorkID:      work.ID,
+			Fingerprint: published.Fingerprint,
+			CID:         cid,
+			License:     license.ID,
+			LicenseText: license.Text,
+		}
+		if err := ls.storage.AddWorkRevision(revision); err != nil {
+			return err
+		}
+
+		if err := ls.storage.SetWorkCID(ctx, work.ID, cid, published.Fingerprint); err != nil {
+			return err
+		}
+	}
+
+	if prepinned {
+		ls.log.Infof("publishApprovedWork: work %s published before the pinning is pinned with CID %q", work.ID, revision.CID)
+
+		return ls.storage.SetWorkPublished(ctx, work.ID, work.PublishTxHash)
+	}
+
+	// the revision pinned again has been published on-chain already
+	if revision.TxHash != "" {
+		return ls.storage.SetWorkPublished(ctx, work.ID, revision.TxHash)
+	}
+
+	txHash, err := ls.contractorSrv.PublishWork(ctx, &contractor.PublishWorkRequest{
+		Authors: []string{workResp.Author.BasicInfo.Web3Address},
+		Name:    work.Name,
+		Uri:     ls.revisionURI(revision),
+		WorkId:  uuidToUint256(work.ID),
+		Price:   faucetCount,
+	})
+	if err != nil {
+		return fmt.Errorf("while publishing the work via contractor, err: %v", err)
+	}
+
+	if txHash.ErrorMsg != "" {
+		return fmt.Errorf("while publishing the work via contractor, err: %s", txHash.ErrorMsg)
+	}
+
+	ls.log.Infof("publishApprovedWork: work %s revision %d is published with CID %q, tx hash - %s",
+		work.ID, revision.Revision, revision.CID, txHash.TxHash)
+
+	if err := ls.storage.SetWorkRevisionTx(revision.ID, txHash.TxHash); err != nil {
+		return err
+	}
+
+	return ls.storage.SetWorkPublished(ctx, work.ID, txHash.TxHash)
+}
+
+// VerificationCheck is the comparison of the recomputed fingerprint with the one of the source
+type VerificationCheck struct {
+	Fingerprint string `json:"fingerprint,omitempty"`
+	Match       bool   `json:"match"`
+	Error       string `json:"error,omitempty"`
+}
+
+// WorkVerification is the result of the integrity check of the published work
+type WorkVerification struct {
+	WorkID      string `json:"work_id"`
+	Revision    int    `json:"revision"`
+	Fingerprint string `json:"fingerprint"`
+	CID         string `json:"cid,omitempty"`
+	TxHash      string `json:"tx_hash,omitempty"`
+	// the fingerprint saved when the revision was approved
+	Stored *VerificationCheck `json:"stored"`
+	// the fingerprint of the document pinned to IPFS
+	IPFS *VerificationCheck `json:"ipfs"`
+	// the fingerprint in the URI of the on-chain publication
+	OnChain  *VerificationCheck `json:"on_chain"`
+	Verified bool               `json:"verified"`
+}
+
+// VerifyWork recomputes the fingerprint of the published work and compares it with
+// the fingerprints of its last revision saved to the library, IPFS and the chain
+func (ls *LibrarySrv) VerifyWork(ctx context.Context, workID string) (*WorkVerification, error) {
+	workResp, err := ls.storage.GetWorkByID(ctx, workID)
+	if err != nil {
+		ls.log.Errorf("VerifyWork: error get work %s, err: %v", workID, err)
+
+		return nil, err
+	}
+
+	if workResp == nil {
+		return nil, storage.ErrWorkNotExists
+	}
+
+	if workResp.Work.Status != storage.OpenWorkStatus {
+		return nil, ErrWorkNotPublished
+	}
+
+	revision, err := ls.storage.GetLastWorkRevision(workID)
+	if err != nil {
+		if errors.Is(err, storage.ErrWorkRevisionNotExists) {
+			return nil, ErrWorkNotPublished
+		}
+		ls.log.Errorf("VerifyWork: error get revision of work %s, err: %v", workID, err)
+
+		return nil, err
+	}
+
+	published, err := ls.publishedWork(workResp)
+	if err != nil {
+		ls.log.Errorf("VerifyWork: error fingerprint work %s, err: %v", workID, err)
+
+		return nil, err
+	}
+
+	verification := &WorkVerification{
+		WorkID:      workID,
+		Revision:    revision.Revision,
+		Fingerprint: published.Fingerprint,
+		CID:         revision.CID,
+		TxHash:      revision.TxHash,
+		Stored: &VerificationCheck{
+			Fingerprint: revision.Fingerprint,
+			Match:       revision.Fingerprint == published.Fingerprint,
+		},
+		IPFS:    ls.verifyPinnedWork(ctx, revision, published.Fingerprint),
+		OnChain: ls.verifyOnChainWork(ctx, revision, published.Fingerprint),
+	}
+	// the revision published without pinning is verified on-chain only
+	verification.Verified = verification.Stored.Match && (verification.IPFS.Match || revision.CID == "") && verification.OnChain.Match
+
+	return verification, nil
+}
+
+// verifyPinnedWork checks the fingerprint of the pinned document, the fingerprint
+// declared by the document must match its content
+func (ls *LibrarySrv) verifyPinnedWork(ctx context.Context, revision *storage.WorkRevision, fingerprint string) *VerificationCheck {
+	check := new(VerificationCheck)
+	if revision.CID == "" {
+		check.Error = "the work hasn't been pinned to IPFS"
+
+		return check
+	}
+
+	content, err := ls.gateway.Fetch(ctx, revision.CID)
+	if err != nil {
+		check.Error = fmt.Sprintf("while fetching the pinned work, err: %v", err)
+
+		return check
+	}
+
+	pinned := new(PublishedWork)
+	if err := json.NewDecoder(bytes.NewReader(content)).Decode(pinned); err != nil {
+		check.Error = fmt.Sprintf("the pinned work is malformed, err: %v", err)
+
+		return check
+	}
+
+	actual, err := pinned.fingerprint()
+	if err != nil {
+		check.Error = err.Error()
+
+		return check
+	}
+
+	check.Fingerprint = pinned.Fingerprint
+	if actual != pinned.Fingerprint {
+		check.Error = "the pinned work doesn't match its fingerprint"
+
+		return check
+	}
+	// the plain content is checked against its hash, the encrypted one is checked when it's read
+	if pinned.Encryption == "" && pinned.ContentHash != "" && pinned.ContentHash != contentHash(&storage.WorkContent{Format: pinned.Format, WorkData: pinned.Content}) {
+		check.Error = "the pinned work doesn't match its content hash"
+
+		return check
+	}
+	check.Match = pinned.Fingerprint == fingerprint
+
+	return check
+}
+
+// verifyOnChainWork checks the paper exists in the contract and the publication tx
+// carries the URI of the revision with the fingerprint
+func (ls *LibrarySrv) verifyOnChainWork(ctx context.Context, revision *storage.WorkRevision, fingerprint string) *VerificationCheck {
+	check := new(VerificationCheck)
+	paper, err := ls.contractorSrv.GetPaperById(ctx, &contractor.PaperByIdRequest{Id: uuidToUint256(revision.WorkID)})
+	if err != nil {
+		check.Error = fmt.Sprintf("while getting the paper from the contract, err: %v", err)
+
+		return check
+	}
+
+	if paper.Address == zeroAddress || revision.TxHash == "" {
+		check.Error = "the revision hasn't been published on-chain"
+
+		return check
+	}
+
+	if ls.chain == nil {
+		check.Error = "the chain RPC isn't configured"
+
+		return check
+	}
+
+	input, err := ls.chain.TransactionInput(ctx, revision.TxHash)
+	if err != nil {
+		check.Error = fmt.Sprintf("while getting the publication tx, err: %v", err)
+
+		return check
+	}
+
+	// the URI is ABI encoded as is, the fragment is the fingerprint of the revision. The revision
+	// published without pinning and pinned later is published with the page of the work.
+	uris := []string{ls.revisionURI(revision), publisher.FingerprintURI(ls.workURL(revision.WorkID), revision.Fingerprint)}
+	if !bytes.Contains(input, []byte(uris[0])) && !bytes.Contains(input, []byte(uris[1])) {
+		check.Error = "the publication tx doesn't carry the URI of the revision"
+
+		return check
+	}
+	check.Fingerprint = revision.Fingerprint
+	check.Match = revision.Fingerprint == fingerprint
+
+	return check
+}
@@ -0,0 +1,61 @@
+package srv
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/envelope"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+)
+
+func sealedWork(t *testing.T, key []byte, data string) *storage.WorkResponse {
+	t.Helper()
+
+	cipher, err := envelope.Seal(key, []byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &storage.WorkResponse{
+		Work: &storage.Work{
+			ID:      "work",
+			Name:    "On the Sea of Wisdom",
+			Content: &storage.WorkContent{Format: "markdown", WorkData: data, Cipher: cipher},
+		},
+		Author: &storage.AuthorResponse{BasicInfo: &storage.Participant{Web3Address: "0xauthor"}},
+	}
+}
+
+// TestFingerprintResealed checks the fingerprint of the encrypted work is kept
+// when the content is sealed again and changed when the content is changed
+func TestFingerprintResealed(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+
+	first := newPublishedWork(sealedWork(t, key, "# The work"), nil)
+	resealed := newPublishedWork(sealedWork(t, key, "# The work"), nil)
+	changed := newPublishedWork(sealedWork(t, key, "# The changed work"), nil)
+
+	if first.Content == resealed.Content {
+		t.Fatal("the content is sealed with the same nonce")
+	}
+
+	firstPrint, err := first.fingerprint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resealedPrint, err := resealed.fingerprint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	changedPrint, err := changed.fingerprint()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if firstPrint != resealedPrint {
+		t.Errorf("the fingerprint of the resealed work %s differs from %s", resealedPrint, firstPrint)
+	}
+	if firstPrint == changedPrint {
+		t.Error("the fingerprint of the changed work is kept")
+	}
+}
@@ -0,0 +1,61 @@
+package publisher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// the pinned works are small JSON documents
+const maxFetchSize = 64 << 20
+
+// Gateway reads the pinned content via the IPFS HTTP gateway
+type Gateway struct {
+	url    string
+	client *http.Client
+}
+
+func NewGateway(gatewayURL string) (*Gateway, error) {
+	u, err := url.Parse(gatewayURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("wrong IPFS gateway URL %q", gatewayURL)
+	}
+
+	return &Gateway{
+		url:    strings.TrimSuffix(gatewayURL, "/"),
+		client: &http.Client{Timeout: time.Minute},
+	}, nil
+}
+
+// Fetch returns the content of the CID
+func (g *Gateway) Fetch(ctx context.Context, cid string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.url+"/ipfs/"+url.PathEscape(cid), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := g.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("IPFS gateway: unexpected status %s", res.Status)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(res.Body, maxFetchSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(content) > maxFetchSize {
+		return nil, fmt.Errorf("IPFS gateway: the content of %s is too large", cid)
+	}
+
+	return content, nil
+}
@@ -0,0 +1,91 @@
+package publisher
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/SeaOfWisdom/sow_library/src/config"
+	"github.com/SeaOfWisdom/sow_library/src/service/pinata"
+	w3scli "github.com/SeaOfWisdom/sow_library/src/service/w3s-client"
+)
+
+// types of the pinning services
+const (
+	PinataPublisherType = "pinata"
+	W3SPublisherType    = "w3s"
+)
+
+// ContentPublisher pins the content to IPFS
+type ContentPublisher interface {
+	// Publish pins the content under the name and returns its CID
+	Publish(ctx context.Context, name string, content []byte) (string, error)
+}
+
+// NewContentPublisher creates the publisher of the configured pinning service, the pinning
+// is opt-in, so there is no publisher if the service isn't configured
+func NewContentPublisher(cfg *config.Config) (ContentPublisher, error) {
+	switch cfg.ContentPublisher {
+	case "":
+		return nil, nil
+	case PinataPublisherType:
+		return pinata.NewClient(cfg.PinataURL, cfg.PinataJWT)
+	case W3SPublisherType:
+		return w3scli.NewClient(cfg.W3SURL, cfg.W3SToken)
+	default:
+		return nil, fmt.Errorf("unknown content publisher %q", cfg.ContentPublisher)
+	}
+}
+
+// URI returns the IPFS URI of the content
+func URI(cid string) string {
+	return "ipfs://" + cid
+}
+
+// FingerprintURI returns the URI of the content with its fingerprint in the fragment,
+// the URI is stored on-chain, so the fingerprint can be read from the publication tx
+func FingerprintURI(uri, fingerprint string) string {
+	return uri + "#sha256=" + fingerprint
+}
+
+// Fingerprint returns the hex SHA-256 of the canonical JSON of the value
+func Fingerprint(v interface{}) (string, error) {
+	content, err := CanonicalJSON(v)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// CanonicalJSON serializes the value with the sorted object keys and without
+// insignificant whitespaces, so the same value is always pinned to the same CID
+func CanonicalJSON(v interface{}) ([]byte, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	// the maps are marshaled with the sorted keys
+	var generic interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(generic); err != nil {
+		return nil, err
+	}
+
+	// the encoder terminates the value with a newline
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,174 @@
+package srv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+)
+
+// SaveQuestionnaireTemplate creates a new template or the next version of the existing one
+func (ls *LibrarySrv) SaveQuestionnaireTemplate(ctx context.Context, template *storage.QuestionnaireTemplate) (*storage.QuestionnaireTemplate, error) {
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := ls.storage.PutQuestionnaireTemplate(ctx, template); err != nil {
+		ls.log.Errorf("SaveQuestionnaireTemplate: error put template %s, err: %v", template.ID, err)
+
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (ls *LibrarySrv) GetQuestionnaireTemplates(ctx context.Context, science string) ([]*storage.QuestionnaireTemplate, error) {
+	templates, err := ls.storage.GetLatestQuestionnaireTemplates(ctx, science)
+	if err != nil {
+		ls.log.Errorf("GetQuestionnaireTemplates: error get templates for science %s, err: %v", science, err)
+
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func (ls *LibrarySrv) GetQuestionnaireTemplate(ctx context.Context, id string, version int64) (*storage.QuestionnaireTemplate, error) {
+	return ls.storage.GetQuestionnaireTemplate(ctx, id, version)
+}
+
+// setWorkScience takes the science of the work from the author's profile if the author hasn't
+// chosen it, the questionnaire templates of the reviews are matched by the science. The work
+// without the science is accepted, any template can be used for its reviews.
+func (ls *LibrarySrv) setWorkScience(ctx context.Context, authorID string, work *storage.Work) {
+	if work.Science != "" {
+		return
+	}
+
+	if author, err := ls.storage.GetAuthorById(ctx, authorID); err == nil && author != nil && len(author.Sciences) > 0 {
+		work.Science = author.Sciences[0]
+	}
+}
+
+// checkQuestionnaire validates the review's questionnaire against the referenced template version,
+// complete demands all required questions to be answered.
+func (ls *LibrarySrv) checkQuestionnaire(ctx context.Context, work *storage.Work, questionnaire *storage.WorkReviewQuestionnaire, complete bool) error {
+	if questionnaire == nil || questionnaire.TemplateID == "" || questionnaire.TemplateVersion == 0 {
+		return fmt.Errorf("the questionnaire doesn't reference a template version")
+	}
+
+	template, err := ls.storage.GetQuestionnaireTemplate(ctx, questionnaire.TemplateID, questionnaire.TemplateVersion)
+	if err != nil {
+		return fmt.Errorf("while getting the questionnaire template, err: %w", err)
+	}
+
+	if work.Science != "" && template.Science != work.Science {
+		return fmt.Errorf("the template is for %s, but the work is for %s", template.Science, work.Science)
+	}
+
+	return template.Check(questionnaire, complete)
+}
+
+// GetWorkScore aggregates the questionnaire scores of the finished reviews of the work.
+// It is available for the author of the work and validators.
+func (ls *LibrarySrv) GetWorkScore(ctx context.Context, participantAddress, workID string) (*storage.WorkScore, error) {
+	participant, err := ls.storage.GetParticipantByAddress(participantAddress)
+	if err != nil {
+		ls.log.Errorf("GetWorkScore: error get participant with address %s, err: %v", participantAddress, err)
+
+		return nil, err
+	}
+
+	work, err := ls.storage.GetParticipantWorkByID(workID)
+	if err != nil {
+		ls.log.Errorf("GetWorkScore: error get participant work with id %s, err: %v", workID, err)
+
+		return nil, err
+	}
+
+	if work.ParticipantID != participant.ID && participant.Role < storage.ValidatorRole {
+		return nil, fmt.Errorf("the participant is neither author of the work nor validator")
+	}
+
+	reviews, err := ls.storage.GetReviewByAuthorAndWorkID(ctx, work.ParticipantID, workID)
+	if err != nil {
+		ls.log.Errorf("GetWorkScore: error get reviews of work %s, err: %v", workID, err)
+
+		return nil, err
+	}
+
+	var (
+		score  = &storage.WorkScore{WorkID: workID, Questions: map[string]float64{}}
+		counts = map[string]int{}
+		// templates are immutable, so cache them by id and version
+		templates = map[string]*storage.QuestionnaireTemplate{}
+	)
+	for _, review := range reviews {
+		if review == nil || review.Body == nil || review.Body.Questionnaire == nil {
+			continue
+		}
+
+		switch review.Status {
+		case storage.WorkReviewSubmitted, storage.WorkReviewRejected, storage.WorkReviewAccepted:
+		default:
+			continue
+		}
+
+		questionnaire := review.Body.Questionnaire
+		key := fmt.Sprintf("%s/%d", questionnaire.TemplateID, questionnaire.TemplateVersion)
+		template, ok := templates[key]
+		if !ok {
+			if template, err = ls.storage.GetQuestionnaireTemplate(ctx, questionnaire.TemplateID, questionnaire.TemplateVersion); err != nil {
+				ls.log.Warnf("GetWorkScore: review %s references unknown template %s, err: %v", review.ID, key, err)
+
+				continue
+			}
+			templates[key] = template
+		}
+
+		reviewScore, ok := template.Score(questionnaire)
+		if !ok {
+			continue
+		}
+
+		score.Score += reviewScore
+		score.Reviews++
+		for id, value := range questionnaire.Questions {
+			score.Questions[id] += float64(value)
+			counts[id]++
+		}
+	}
+
+	if score.Reviews > 0 {
+		score.Score /= float64(score.Reviews)
+	}
+
+	for id, count := range counts {
+		score.Questions[id] /= float64(count)
+	}
+
+	return score, nil
+}
+
+func (ls *LibrarySrv) checkSubmittedQuestionnaire(ctx context.Context, participantsReview *storage.ParticipantsWorkReview) error {
+	review, err := ls.storage.GetWorkReviewByID(ctx, participantsReview.ID)
+	if err != nil {
+		return err
+	}
+
+	work, err := ls.storage.GetWorkByID(ctx, participantsReview.WorkID)
+	if err != nil {
+		return err
+	}
+
+	if work == nil {
+		return storage.ErrWorkNotExists
+	}
+
+	var questionnaire *storage.WorkReviewQuestionnaire
+	if review != nil && review.Body != nil {
+		questionnaire = review.Body.Questionnaire
+	}
+
+	return ls.checkQuestionnaire(ctx, work.Work, questionnaire, true)
+}
@@ -0,0 +1,392 @@
+package srv
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/refparser"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+)
+
+var ErrWrongReference = errors.New("the reference must refer to another work of the library, a DOI, a URL or a text")
+
+var doiPattern = regexp.MustCompile(`^10\.\d{4,9}/\S+$`)
+
+// WorkReferenceResponse is the reference with the cited work of the library
+type WorkReferenceResponse struct {
+	*storage.WorkReference
+	CitedWork *storage.WorkResponse `json:"cited_work,omitempty"`
+}
+
+// AuthorMetrics are the citation metrics of the author's open works
+type AuthorMetrics struct {
+	Works     int `json:"works"`
+	Citations int `json:"citations"`
+	// HIndex is the max h such that h of the works are cited at least h times each
+	HIndex int `json:"h_index"`
+}
+
+// ProposedReference is the reference parsed from the bibliography of the work content,
+// the author confirms the proposals by setting the reference list
+type ProposedReference struct {
+	*refparser.Entry
+	// WorkID is the work of the library the reference is matched to
+	WorkID string  `json:"work_id,omitempty"`
+	Score  float64 `json:"score,omitempty"`
+}
+
+// the min similarity of the titles of the reference and the work of the library to match them
+const minTitleSimilarity = 0.8
+
+// normalizeDOI strips the resolver of the DOI, the DOI is case insensitive
+func normalizeDOI(doi string) string {
+	doi = strings.TrimSpace(doi)
+	for _, resolver := range []string{"https://doi.org/", "http://doi.org/", "https://dx.doi.org/", "http://dx.doi.org/", "doi:"} {
+		if len(doi) >= len(resolver) && strings.EqualFold(doi[:len(resolver)], resolver) {
+			doi = doi[len(resolver):]
+			break
+		}
+	}
+	return strings.ToLower(doi)
+}
+
+// checkReference normalizes the reference and checks the cited work of the library exists
+func (ls *LibrarySrv) checkReference(workID string, reference *storage.WorkReference) error {
+	reference.CitedWorkID = strings.TrimSpace(reference.CitedWorkID)
+	reference.URL = strings.TrimSpace(reference.URL)
+	reference.Text = strings.TrimSpace(reference.Text)
+	if reference.DOI != "" {
+		if reference.DOI = normalizeDOI(reference.DOI); !doiPattern.MatchString(reference.DOI) {
+			return ErrWrongReference
+		}
+	}
+	if reference.URL != "" && !strings.HasPrefix(reference.URL, "http://") && !strings.HasPrefix(reference.URL, "https://") {
+		return ErrWrongReference
+	}
+
+	if reference.CitedWorkID == "" {
+		if reference.DOI == "" && reference.URL == "" && reference.Text == "" {
+			return ErrWrongReference
+		}
+		return nil
+	}
+
+	if reference.CitedWorkID == workID {
+		return ErrWrongReference
+	}
+
+	cited, err := ls.storage.GetParticipantWorkByID(reference.CitedWorkID)
+	if err != nil {
+		return err
+	}
+
+	// the drafts can't be cited
+	if cited.Status == storage.DraftWorkStatus {
+		return storage.ErrWorkNotExists
+	}
+
+	return nil
+}
+
+// SetWorkReferences replaces the reference list of the author's work
+func (ls *LibrarySrv) SetWorkReferences(ctx context.Context, authorAddress, workID string, references []*storage.WorkReference) ([]*storage.WorkReference, error) {
+	participantsWork, err := ls.storage.GetParticipantWorkByID(workID)
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := ls.storage.GetParticipantByAddress(authorAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if participantsWork.ParticipantID != author.ID {
+		return nil, ErrNotAuthor
+	}
+
+	for _, reference := range references {
+		if err := ls.checkReference(workID, reference); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ls.storage.SetWorkReferences(workID, references); err != nil {
+		ls.log.Errorf("SetWorkReferences: error set references of work %s, err: %v", workID, err)
+
+		return nil, err
+	}
+
+	return references, nil
+}
+
+// citedWork returns the work without the content, nil if it's not visible to the reader
+func (ls *LibrarySrv) citedWork(ctx context.Context, readerAddress, workID string) *storage.WorkResponse {
+	workResp, err := ls.storage.GetWorkByID(ctx, workID)
+	if err != nil || workResp == nil {
+		return nil
+	}
+
+	if workResp.Work.Status == storage.DraftWorkStatus && !isWorkAuthor(workResp, readerAddress) {
+		return nil
+	}
+	workResp.Work.Content = nil
+
+	return workResp
+}
+
+// GetWorkReferences returns the reference list of the work with the cited works of the library
+func (ls *LibrarySrv) GetWorkReferences(ctx context.Context, readerAddress, workID string) ([]*WorkReferenceResponse, error) {
+	if ls.citedWork(ctx, readerAddress, workID) == nil {
+		return nil, storage.ErrWorkNotExists
+	}
+
+	references, err := ls.storage.GetWorkReferences(workID)
+	if err != nil {
+		ls.log.Errorf("GetWorkReferences: error get references of work %s, err: %v", workID, err)
+
+		return nil, err
+	}
+
+	responses := make([]*WorkReferenceResponse, 0, len(references))
+	for _, reference := range references {
+		response := &WorkReferenceResponse{WorkReference: reference}
+		if reference.CitedWorkID != "" {
+			response.CitedWork = ls.citedWork(ctx, readerAddress, reference.CitedWorkID)
+		}
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
+
+// GetCitingWorks returns the open works citing the work
+func (ls *LibrarySrv) GetCitingWorks(ctx context.Context, readerAddress, workID string) ([]*storage.WorkResponse, error) {
+	if ls.citedWork(ctx, readerAddress, workID) == nil {
+		return nil, storage.ErrWorkNotExists
+	}
+
+	workIDs, err := ls.storage.GetCitingWorkIDs(workID)
+	if err != nil {
+		ls.log.Errorf("GetCitingWorks: error get works citing work %s, err: %v", workID, err)
+
+		return nil, err
+	}
+
+	works := make([]*storage.WorkResponse, 0, len(workIDs))
+	for _, citingID := range workIDs {
+		if work := ls.citedWork(ctx, readerAddress, citingID); work != nil {
+			works = append(works, work)
+		}
+	}
+
+	return works, nil
+}
+
+// hIndex returns the max h such that h of the counts are at least h
+func hIndex(counts []int) int {
+	sort.Sort(sort.Reverse(sort.IntSlice(counts)))
+	h := 0
+	for h < len(counts) && counts[h] > h {
+		h++
+	}
+	return h
+}
+
+// GetAuthorMetrics returns the citation metrics of the author's open works
+func (ls *LibrarySrv) GetAuthorMetrics(ctx context.Context, authorAddress string) (*AuthorMetrics, error) {
+	author, err := ls.storage.GetParticipantByAddress(authorAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	workIDs, err := ls.storage.GetOpenWorkIDsOfParticipant(author.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	citations, err := ls.storage.CountCitations(workIDs...)
+	if err != nil {
+		ls.log.Errorf("GetAuthorMetrics: error count citations of %s, err: %v", authorAddress, err)
+
+		return nil, err
+	}
+
+	metrics := &AuthorMetrics{Works: len(workIDs)}
+	counts := make([]int, 0, len(workIDs))
+	for _, workID := range workIDs {
+		metrics.Citations += citations[workID]
+		counts = append(counts, citations[workID])
+	}
+	metrics.HIndex = hIndex(counts)
+
+	return metrics, nil
+}
+
+// parseReferences parses the bibliographies of the texts, the reference found in several texts is proposed once
+func parseReferences(texts ...string) []*refparser.Entry {
+	var entries []*refparser.Entry
+	parsed := make(map[string]bool)
+	for _, text := range texts {
+		for _, entry := range refparser.Parse(text) {
+			key := strings.ToLower(strings.Join(strings.Fields(entry.Text), " "))
+			if parsed[key] {
+				continue
+			}
+			parsed[key] = true
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
+// referenceQuery selects the works the references may refer to, by their identifiers,
+// the URLs of their pages or the words of their titles
+func referenceQuery(entries []*refparser.Entry) *storage.ReferenceQuery {
+	query := new(storage.ReferenceQuery)
+	for _, entry := range entries {
+		if entry.DOI != "" {
+			query.PIDs = append(query.PIDs, strings.ToLower(entry.DOI))
+		}
+		if _, pid, ok := strings.Cut(entry.URL, "/id/"); ok {
+			query.PIDs = append(query.PIDs, strings.ToLower(pid))
+		}
+		if _, workID, ok := strings.Cut(entry.URL, "/works/"); ok {
+			if end := strings.IndexAny(workID, "/?#"); end >= 0 {
+				workID = workID[:end]
+			}
+			query.WorkIDs = append(query.WorkIDs, workID)
+		}
+
+		words := make([]string, 0, len(entry.Title))
+		for word := range titleWords(entry.Title) {
+			words = append(words, word)
+		}
+		if len(words) > 0 {
+			sort.Strings(words)
+			query.Titles = append(query.Titles, strings.Join(words, " "))
+		}
+	}
+
+	return query
+}
+
+// titleWords returns the set of the lowercased words of the title
+func titleWords(title string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		words[word] = true
+	}
+	return words
+}
+
+// titleSimilarity is the Jaccard index of the words of the titles
+func titleSimilarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	common := 0
+	for word := range a {
+		if b[word] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(a)+len(b)-common)
+}
+
+// matchReference finds the work of the library the reference refers to by the identifier,
+// the URL or the title, the title match of the other year is scored lower
+func (ls *LibrarySrv) matchReference(entry *refparser.Entry, works []*storage.Work) (string, float64) {
+	var (
+		bestID    string
+		bestScore float64
+	)
+	entryWords := titleWords(entry.Title)
+	for _, work := range works {
+		if work.PID != "" && (strings.EqualFold(entry.DOI, work.PID) || strings.Contains(entry.URL, "/id/"+work.PID)) ||
+			strings.Contains(entry.URL, "/works/"+work.ID) {
+			return work.ID, 1
+		}
+
+		score := titleSimilarity(entryWords, titleWords(work.Name))
+		if entry.Year != 0 && entry.Year != work.CreatedAt.Year() {
+			score -= 0.1
+		}
+		if score >= minTitleSimilarity && score > bestScore {
+			bestID, bestScore = work.ID, score
+		}
+	}
+
+	return bestID, bestScore
+}
+
+// ProposeWorkReferences parses the bibliography of the author's work and matches the references
+// to the open works of the library. The proposals aren't saved until the author sets the reference list.
+func (ls *LibrarySrv) ProposeWorkReferences(ctx context.Context, authorAddress, workID string) ([]*ProposedReference, error) {
+	participantsWork, err := ls.storage.GetParticipantWorkByID(workID)
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := ls.storage.GetParticipantByAddress(authorAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if participantsWork.ParticipantID != author.ID {
+		return nil, ErrNotAuthor
+	}
+
+	workResp, err := ls.storage.GetWorkByID(ctx, workID)
+	if err != nil {
+		return nil, err
+	}
+	if workResp == nil {
+		return nil, storage.ErrWorkNotExists
+	}
+
+	// the bibliography is parsed from the content and from the text recognized from the paper
+	// the draft was created from, the author may have edited the bibliography out of the content
+	var texts []string
+	if workResp.Work.Content != nil && strings.TrimSpace(workResp.Work.Content.WorkData) != "" {
+		texts = append(texts, workResp.Work.Content.WorkData)
+	}
+	extracted, err := ls.storage.GetExtractionText(workResp.Work)
+	if err != nil {
+		ls.log.Errorf("ProposeWorkReferences: error get recognized text of work %s, err: %v", workID, err)
+	} else if strings.TrimSpace(extracted) != "" {
+		texts = append(texts, extracted)
+	}
+	if len(texts) == 0 {
+		return nil, ErrNoContent
+	}
+
+	entries := parseReferences(texts...)
+	if len(entries) == 0 {
+		return []*ProposedReference{}, nil
+	}
+
+	works, err := ls.storage.GetReferenceCandidates(ctx, referenceQuery(entries))
+	if err != nil {
+		ls.log.Errorf("ProposeWorkReferences: error get open works, err: %v", err)
+
+		return nil, err
+	}
+
+	proposals := make([]*ProposedReference, 0, len(entries))
+	for _, entry := range entries {
+		proposal := &ProposedReference{Entry: entry}
+		if matchedID, score := ls.matchReference(entry, works); matchedID != "" && matchedID != workID {
+			proposal.WorkID, proposal.Score = matchedID, score
+		}
+		proposals = append(proposals, proposal)
+	}
+
+	return proposals, nil
+}
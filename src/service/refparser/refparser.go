@@ -0,0 +1,294 @@
+package refparser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Entry is the reference parsed from the bibliography
+type Entry struct {
+	Text    string   `json:"text"` // the reference as it's written
+	Authors []string `json:"authors,omitempty"`
+	Title   string   `json:"title,omitempty"`
+	Year    int      `json:"year,omitempty"`
+	DOI     string   `json:"doi,omitempty"`
+	URL     string   `json:"url,omitempty"`
+}
+
+// the headings of the bibliography section, the heading may be numbered
+var bibliographyHeadings = map[string]bool{
+	"references":        true,
+	"reference list":    true,
+	"bibliography":      true,
+	"literature":        true,
+	"literature cited":  true,
+	"works cited":       true,
+	"список литературы": true,
+	"литература":        true,
+	"библиография":      true,
+	"библиографический список":         true,
+	"список использованной литературы": true,
+	"список использованных источников": true,
+	"список источников":                true,
+}
+
+// the headings of the sections following the bibliography
+var trailingHeadings = map[string]bool{
+	"appendix":   true,
+	"appendices": true,
+	"приложение": true,
+	"приложения": true,
+}
+
+var (
+	headingNumber = regexp.MustCompile(`^(?:\d{1,2}(?:\.\d{1,2})*|[IVXLC]{1,5})\.?\s+`)
+	latexHeading  = regexp.MustCompile(`^\\(?:sub)*section\*?\{(.*)\}$`)
+)
+
+// headingName returns the lowercased heading without the Markdown or LaTeX markup, the numbering and the colon
+func headingName(line string) string {
+	line = strings.TrimSpace(line)
+	if match := latexHeading.FindStringSubmatch(line); match != nil {
+		line = match[1]
+	}
+	line = strings.Trim(strings.TrimLeft(line, "# "), "*_ ")
+	line = headingNumber.ReplaceAllString(line, "")
+	line = strings.TrimRight(line, ": ")
+	return strings.ToLower(strings.Join(strings.Fields(line), " "))
+}
+
+// Section returns the text of the last bibliography section, empty if there is no such section
+func Section(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	start := -1
+	for i, line := range lines {
+		if bibliographyHeadings[headingName(line)] || strings.HasPrefix(strings.TrimSpace(line), `\begin{thebibliography}`) {
+			start = i + 1
+		}
+	}
+	if start < 0 {
+		return ""
+	}
+
+	end := len(lines)
+	for i := start; i < len(lines); i++ {
+		name := headingName(lines[i])
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), `\end{thebibliography}`) ||
+			trailingHeadings[name] || strings.HasPrefix(name, "appendix ") || strings.HasPrefix(name, "приложение ") {
+			end = i
+			break
+		}
+	}
+
+	return strings.TrimSpace(strings.Join(lines[start:end], "\n"))
+}
+
+var (
+	entryNumber = regexp.MustCompile(`^\s*(?:(?:\[\d{1,4}\]|\d{1,4}[.)])\s+|\\bibitem(?:\[[^\]]*\])?\{[^}]*\}\s*)`)
+	entryAuthor = regexp.MustCompile(`^[\p{Lu}][\p{L}'’-]+,?\s+[\p{Lu}]\.`)
+)
+
+// Split splits the bibliography into the entries. The entries are numbered ([1], 1., 1) or \bibitem),
+// separated by the blank lines or start with the author's name, the wrapped lines are joined.
+func Split(section string) []string {
+	lines := strings.Split(strings.ReplaceAll(section, "\r\n", "\n"), "\n")
+
+	numbered := 0
+	for _, line := range lines {
+		if entryNumber.MatchString(line) {
+			numbered++
+		}
+	}
+
+	var (
+		entries []string
+		current []string
+	)
+	flush := func() {
+		if entry := joinLines(current); entry != "" {
+			entries = append(entries, entry)
+		}
+		current = nil
+	}
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case numbered >= 2:
+			if entryNumber.MatchString(line) {
+				flush()
+				trimmed = entryNumber.ReplaceAllString(line, "")
+			}
+		case trimmed == "":
+			flush()
+			continue
+		case entryAuthor.MatchString(trimmed):
+			flush()
+		}
+		if trimmed != "" {
+			current = append(current, strings.TrimSpace(trimmed))
+		}
+	}
+	flush()
+
+	return entries
+}
+
+// joinLines joins the wrapped lines of the entry, the word broken by the hyphen is restored
+func joinLines(lines []string) string {
+	var sb strings.Builder
+	for _, line := range lines {
+		if sb.Len() == 0 {
+			sb.WriteString(line)
+			continue
+		}
+
+		prev := sb.String()
+		first := []rune(line)[0]
+		if strings.HasSuffix(prev, "-") && !strings.HasSuffix(prev, "--") && unicode.IsLower(first) {
+			sb.Reset()
+			sb.WriteString(strings.TrimSuffix(prev, "-"))
+		} else {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(line)
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// Parse finds the bibliography in the text and parses its entries
+func Parse(text string) []*Entry {
+	section := Section(text)
+	if section == "" {
+		return nil
+	}
+
+	var entries []*Entry
+	for _, raw := range Split(section) {
+		entries = append(entries, ParseEntry(raw))
+	}
+
+	return entries
+}
+
+var (
+	doiPattern     = regexp.MustCompile(`(?i)\b(10\.\d{4,9}/[^\s"<>]+)`)
+	urlPattern     = regexp.MustCompile(`https?://[^\s<>"]+`)
+	labelPattern   = regexp.MustCompile(`(?i)(?:\bdoi:?|\burl:?|\[электронный ресурс\]|\(дата обращения:[^)]*\)|\(accessed[^)]*\)|\bretrieved from)`)
+	apaYear        = regexp.MustCompile(`\((1[5-9]\d{2}|20\d{2})[a-z]?\)\.?`)
+	anyYear        = regexp.MustCompile(`\b(1[5-9]\d{2}|20\d{2})\b`)
+	quotedTitle    = regexp.MustCompile(`[“"«]([^”"»]{4,})[”"»]`)
+	surnameFirst   = `[\p{Lu}][\p{L}'’-]+,?\s+(?:[\p{Lu}]\.\s?(?:-\s?[\p{Lu}]\.\s?)?){1,3}`
+	initialsFirst  = `(?:[\p{Lu}]\.\s?(?:-\s?[\p{Lu}]\.\s?)?){1,3}\s?[\p{Lu}][\p{L}'’-]+`
+	authorPattern  = regexp.MustCompile(surnameFirst + `|` + initialsFirst)
+	leadingAuthors = regexp.MustCompile(`^(?:(?:` + surnameFirst + `|` + initialsFirst + `)(?:\s*,\s*|\s*;\s*|\s+and\s+|\s*&\s*|\s+и\s+|\s+)?(?:et al\.?,?\s*|и др\.?,?\s*)?)+`)
+)
+
+// trimPunct trims the punctuation following the identifier or the title
+func trimPunct(s string) string {
+	return strings.TrimRight(strings.TrimSpace(s), ".,;:)]/ ")
+}
+
+// ParseEntry extracts the authors, the title, the year, the DOI and the URL of the entry by the
+// heuristics for the APA, GOST, IEEE and similar styles. The fields are left empty if they aren't found.
+func ParseEntry(raw string) *Entry {
+	raw = strings.Join(strings.Fields(raw), " ")
+	entry := &Entry{Text: raw}
+	rest := raw
+
+	if match := doiPattern.FindStringSubmatch(rest); match != nil {
+		entry.DOI = strings.ToLower(trimPunct(match[1]))
+	}
+	for _, url := range urlPattern.FindAllString(rest, -1) {
+		if !strings.Contains(strings.ToLower(url), "doi.org/") && entry.URL == "" {
+			entry.URL = trimPunct(url)
+		}
+		rest = strings.Replace(rest, url, "", 1)
+	}
+	rest = doiPattern.ReplaceAllString(rest, "")
+	rest = strings.Join(strings.Fields(labelPattern.ReplaceAllString(rest, "")), " ")
+
+	// APA: Authors (2020). Title. Journal
+	if loc := apaYear.FindStringSubmatchIndex(rest); loc != nil {
+		entry.Year, _ = strconv.Atoi(rest[loc[2]:loc[3]])
+		entry.Authors = parseAuthors(rest[:loc[0]])
+		if title := quotedTitle.FindStringSubmatch(rest[loc[1]:]); title != nil {
+			entry.Title = trimPunct(title[1])
+		} else {
+			entry.Title = firstSentence(rest[loc[1]:])
+		}
+		return entry
+	}
+
+	if year := anyYear.FindStringSubmatch(rest); year != nil {
+		entry.Year, _ = strconv.Atoi(year[1])
+	}
+
+	// IEEE: Authors, "Title," Journal, 2020
+	if loc := quotedTitle.FindStringSubmatchIndex(rest); loc != nil {
+		entry.Authors = parseAuthors(rest[:loc[0]])
+		entry.Title = trimPunct(rest[loc[2]:loc[3]])
+		return entry
+	}
+
+	// GOST: Surname I. I. Title / I. I. Surname // Journal. — 2020. — No. 1.
+	head := rest
+	if at := strings.Index(head, " // "); at >= 0 {
+		head = head[:at]
+	}
+	responsibility := ""
+	if at := strings.Index(head, " / "); at >= 0 {
+		head, responsibility = head[:at], head[at+3:]
+	}
+
+	authors := leadingAuthors.FindString(head)
+	entry.Authors = parseAuthors(authors)
+	if len(entry.Authors) == 0 && responsibility != "" {
+		entry.Authors = parseAuthors(responsibility)
+	}
+
+	if strings.Contains(rest, " / ") || strings.Contains(rest, " // ") {
+		entry.Title = trimPunct(strings.TrimPrefix(head, authors))
+	} else {
+		entry.Title = firstSentence(strings.TrimPrefix(head, authors))
+	}
+
+	return entry
+}
+
+// parseAuthors finds the names in the authors' part of the entry, the initials keep their periods
+func parseAuthors(part string) []string {
+	var authors []string
+	for _, name := range authorPattern.FindAllString(part, -1) {
+		if name = strings.Join(strings.Fields(strings.TrimRight(name, ",;& ")), " "); name != "" {
+			authors = append(authors, name)
+		}
+	}
+	return authors
+}
+
+// firstSentence returns the text up to the period which doesn't follow the initial
+func firstSentence(text string) string {
+	text = strings.TrimSpace(text)
+	runes := []rune(text)
+	for i, r := range runes {
+		if r != '.' && r != '?' && r != '!' {
+			continue
+		}
+		if i+1 < len(runes) && runes[i+1] != ' ' {
+			continue
+		}
+		// the initial or the abbreviation of the single letter
+		if r == '.' && i >= 1 && unicode.IsUpper(runes[i-1]) && (i == 1 || !unicode.IsLetter(runes[i-2])) {
+			continue
+		}
+		if r == '.' {
+			return trimPunct(string(runes[:i]))
+		}
+		return strings.TrimSpace(string(runes[:i+1]))
+	}
+	return trimPunct(text)
+}
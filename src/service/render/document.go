@@ -0,0 +1,161 @@
+package render
+
+import (
+	"errors"
+	"strings"
+)
+
+// source formats of the works content
+const (
+	PlainFormat    = "plain"
+	MarkdownFormat = "markdown"
+	LaTeXFormat    = "latex"
+)
+
+// output formats
+const (
+	HTMLOutput = "html"
+	TextOutput = "txt"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown source format")
+	ErrUnknownOutput = errors.New("unknown output format")
+)
+
+// ValidFormat checks the source format, the empty one is plain text
+func ValidFormat(format string) bool {
+	switch format {
+	case "", PlainFormat, MarkdownFormat, LaTeXFormat:
+		return true
+	default:
+		return false
+	}
+}
+
+type BlockKind int
+
+const (
+	ParagraphBlock BlockKind = iota
+	HeadingBlock
+	ListBlock
+	CodeBlock
+	MathBlock
+	QuoteBlock
+)
+
+// Block is the block of the parsed content
+type Block struct {
+	Kind    BlockKind
+	Level   int         // the heading level from 1
+	Ordered bool        // the list is numbered
+	Items   [][]*Inline // the list items
+	Inlines []*Inline   // the paragraph, the heading or the quote
+	Text    string      // the code or the math
+}
+
+type InlineKind int
+
+const (
+	TextInline InlineKind = iota
+	StrongInline
+	EmphasisInline
+	CodeInline
+	MathInline
+	LinkInline
+	BreakInline
+)
+
+// Inline is the span of the block text
+type Inline struct {
+	Kind     InlineKind
+	Text     string    // the text, the code or the math
+	Href     string    // the link target
+	Children []*Inline // the content of the strong, the emphasis or the link
+}
+
+// Document is the content parsed from the source format
+type Document struct {
+	Blocks []*Block
+}
+
+// Parse parses the content of the format
+func Parse(format, source string) (*Document, error) {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	switch format {
+	case "", PlainFormat:
+		return parsePlain(source), nil
+	case MarkdownFormat:
+		return parseMarkdown(source), nil
+	case LaTeXFormat:
+		return parseLaTeX(source), nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// Render parses the content and renders it to the output format
+func Render(format, source, output string) (string, error) {
+	doc, err := Parse(format, source)
+	if err != nil {
+		return "", err
+	}
+
+	switch output {
+	case HTMLOutput:
+		return doc.HTML(), nil
+	case TextOutput:
+		return doc.Text(), nil
+	default:
+		return "", ErrUnknownOutput
+	}
+}
+
+func parsePlain(source string) *Document {
+	doc := new(Document)
+	for _, paragraph := range splitParagraphs(source) {
+		var inlines []*Inline
+		for i, line := range strings.Split(paragraph, "\n") {
+			if i > 0 {
+				inlines = append(inlines, &Inline{Kind: BreakInline})
+			}
+			inlines = append(inlines, &Inline{Kind: TextInline, Text: line})
+		}
+		doc.Blocks = append(doc.Blocks, &Block{Kind: ParagraphBlock, Inlines: inlines})
+	}
+
+	return doc
+}
+
+// splitParagraphs splits the text by the blank lines
+func splitParagraphs(text string) (paragraphs []string) {
+	var lines []string
+	flush := func() {
+		if len(lines) > 0 {
+			paragraphs = append(paragraphs, strings.Join(lines, "\n"))
+			lines = nil
+		}
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, " \t"))
+	}
+	flush()
+
+	return
+}
+
+// safeHref allows the web and mail links only
+func safeHref(href string) bool {
+	lower := strings.ToLower(strings.TrimSpace(href))
+	for _, scheme := range []string{"http://", "https://", "mailto:"} {
+		if strings.HasPrefix(lower, scheme) {
+			return true
+		}
+	}
+	return false
+}
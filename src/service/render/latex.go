@@ -0,0 +1,345 @@
+package render
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	texSection = regexp.MustCompile(`^\\(section|subsection|subsubsection|paragraph)\*?\{(.*)\}\s*$`)
+	texBegin   = regexp.MustCompile(`^\\begin\{([a-zA-Z*]+)\}`)
+	// the preamble and the title commands aren't rendered
+	texSkipped = regexp.MustCompile(`^\\(documentclass|usepackage|maketitle|title|author|date|label|newcommand|renewcommand|bibliographystyle|bibliography|tableofcontents)\b|^\\(begin|end)\{document\}`)
+)
+
+var texHeadingLevels = map[string]int{
+	"section":       2,
+	"subsection":    3,
+	"subsubsection": 4,
+	"paragraph":     5,
+}
+
+var texMathEnvs = map[string]bool{
+	"equation": true, "equation*": true, "align": true, "align*": true,
+	"gather": true, "gather*": true, "multline": true, "multline*": true,
+	"displaymath": true, "eqnarray": true, "eqnarray*": true,
+}
+
+// parseLaTeX parses the LaTeX subset: the sections, the itemize and enumerate lists,
+// the display math environments, verbatim, quote and abstract, the inline math and
+// the text formatting commands. The unknown commands are dropped keeping their arguments.
+func parseLaTeX(source string) *Document {
+	doc := new(Document)
+	lines := strings.Split(stripTeXComments(source), "\n")
+
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			if inlines := parseTeXInlines(strings.Join(paragraph, "\n")); len(inlines) > 0 {
+				doc.Blocks = append(doc.Blocks, &Block{Kind: ParagraphBlock, Inlines: inlines})
+			}
+			paragraph = nil
+		}
+	}
+
+	// collect returns the lines until the end of the environment
+	collect := func(i int, first, env string) ([]string, int) {
+		end := `\end{` + env + `}`
+		var body []string
+		if first != "" {
+			if at := strings.Index(first, end); at >= 0 {
+				return []string{first[:at]}, i
+			}
+			body = append(body, first)
+		}
+		for i++; i < len(lines); i++ {
+			if at := strings.Index(lines[i], end); at >= 0 {
+				body = append(body, lines[i][:at])
+				break
+			}
+			body = append(body, lines[i])
+		}
+		return body, i
+	}
+
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+
+		switch {
+		case trimmed == "":
+			flush()
+
+		case texSkipped.MatchString(trimmed):
+			flush()
+
+		case texSection.MatchString(trimmed):
+			flush()
+			section := texSection.FindStringSubmatch(trimmed)
+			doc.Blocks = append(doc.Blocks, &Block{
+				Kind:    HeadingBlock,
+				Level:   texHeadingLevels[section[1]],
+				Inlines: parseTeXInlines(section[2]),
+			})
+
+		case strings.HasPrefix(trimmed, `\[`):
+			flush()
+			first := strings.TrimPrefix(trimmed, `\[`)
+			var body []string
+			if at := strings.Index(first, `\]`); at >= 0 {
+				body = []string{first[:at]}
+			} else {
+				body = append(body, first)
+				for i++; i < len(lines); i++ {
+					if at := strings.Index(lines[i], `\]`); at >= 0 {
+						body = append(body, lines[i][:at])
+						break
+					}
+					body = append(body, lines[i])
+				}
+			}
+			doc.Blocks = append(doc.Blocks, &Block{Kind: MathBlock, Text: strings.TrimSpace(strings.Join(body, "\n"))})
+
+		case texBegin.MatchString(trimmed):
+			flush()
+			env := texBegin.FindStringSubmatch(trimmed)[1]
+			var body []string
+			body, i = collect(i, strings.TrimPrefix(trimmed, `\begin{`+env+`}`), env)
+			doc.Blocks = append(doc.Blocks, texEnvironment(env, body)...)
+
+		default:
+			paragraph = append(paragraph, lines[i])
+		}
+	}
+	flush()
+
+	return doc
+}
+
+// texEnvironment converts the body of the environment to the blocks
+func texEnvironment(env string, body []string) []*Block {
+	text := strings.Join(body, "\n")
+	switch {
+	case texMathEnvs[env]:
+		return []*Block{{Kind: MathBlock, Text: strings.TrimSpace(text)}}
+	case env == "verbatim" || env == "lstlisting":
+		return []*Block{{Kind: CodeBlock, Text: strings.Trim(text, "\n")}}
+	case env == "itemize" || env == "enumerate":
+		list := &Block{Kind: ListBlock, Ordered: env == "enumerate"}
+		for _, item := range strings.Split(text, `\item`)[1:] {
+			list.Items = append(list.Items, parseTeXInlines(strings.TrimSpace(item)))
+		}
+		return []*Block{list}
+	case env == "quote" || env == "quotation" || env == "abstract":
+		return []*Block{{Kind: QuoteBlock, Inlines: parseTeXInlines(strings.TrimSpace(text))}}
+	default:
+		// the unknown environment is rendered as the paragraphs
+		var blocks []*Block
+		for _, paragraph := range splitParagraphs(text) {
+			if inlines := parseTeXInlines(paragraph); len(inlines) > 0 {
+				blocks = append(blocks, &Block{Kind: ParagraphBlock, Inlines: inlines})
+			}
+		}
+		return blocks
+	}
+}
+
+// stripTeXComments removes the text after the unescaped percent sign
+func stripTeXComments(source string) string {
+	lines := strings.Split(source, "\n")
+	for i, line := range lines {
+		for at := 0; at < len(line); at++ {
+			if line[at] == '\\' {
+				at++
+				continue
+			}
+			if line[at] == '%' {
+				lines[i] = line[:at]
+				break
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// the commands replaced by the text
+var texSymbols = map[string]string{
+	"%": "%", "$": "$", "&": "&", "_": "_", "#": "#", "{": "{", "}": "}",
+	" ": " ", ",": " ", "ldots": "…", "dots": "…", "LaTeX": "LaTeX", "TeX": "TeX",
+	"textbackslash": `\`, "S": "§", "copyright": "©", "quad": " ", "qquad": " ",
+}
+
+// readTeXGroup returns the content of the braced group at the position and the position after it
+func readTeXGroup(text string, at int) (string, int, bool) {
+	for at < len(text) && (text[at] == ' ' || text[at] == '\n') {
+		at++
+	}
+	if at >= len(text) || text[at] != '{' {
+		return "", at, false
+	}
+
+	depth := 0
+	for i := at; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			if depth--; depth == 0 {
+				return text[at+1 : i], i + 1, true
+			}
+		}
+	}
+	return text[at+1:], len(text), true
+}
+
+// skipTeXOption skips the optional [argument] of the command
+func skipTeXOption(text string, at int) int {
+	if at < len(text) && text[at] == '[' {
+		if end := strings.IndexByte(text[at:], ']'); end >= 0 {
+			return at + end + 1
+		}
+	}
+	return at
+}
+
+func parseTeXInlines(text string) []*Inline {
+	var (
+		inlines []*Inline
+		sb      strings.Builder
+	)
+	flushText := func() {
+		if sb.Len() > 0 {
+			inlines = append(inlines, &Inline{Kind: TextInline, Text: sb.String()})
+			sb.Reset()
+		}
+	}
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '$':
+			if end := strings.IndexByte(text[i+1:], '$'); end > 0 {
+				flushText()
+				inlines = append(inlines, &Inline{Kind: MathInline, Text: text[i+1 : i+1+end]})
+				i += end + 2
+				continue
+			}
+			sb.WriteByte(c)
+			i++
+
+		case c == '{' || c == '}':
+			i++
+
+		case c == '~':
+			sb.WriteByte(' ')
+			i++
+
+		case c == '\n':
+			sb.WriteByte(' ')
+			i++
+
+		case c == '\\':
+			i = parseTeXCommand(text, i, &sb, &inlines, flushText)
+
+		default:
+			sb.WriteByte(c)
+			i++
+		}
+	}
+	flushText()
+
+	// the leading and trailing spaces of the paragraph
+	if len(inlines) > 0 && inlines[0].Kind == TextInline {
+		inlines[0].Text = strings.TrimLeft(inlines[0].Text, " ")
+	}
+	if last := len(inlines) - 1; last >= 0 && inlines[last].Kind == TextInline {
+		inlines[last].Text = strings.TrimRight(inlines[last].Text, " ")
+	}
+
+	return inlines
+}
+
+// parseTeXCommand parses the command at the backslash and returns the position after it
+func parseTeXCommand(text string, i int, sb *strings.Builder, inlines *[]*Inline, flushText func()) int {
+	if i+1 >= len(text) {
+		return i + 1
+	}
+
+	// the line break, the inline math and the symbols
+	switch next := text[i+1]; {
+	case next == '\\':
+		flushText()
+		*inlines = append(*inlines, &Inline{Kind: BreakInline})
+		return i + 2
+	case next == '(':
+		if end := strings.Index(text[i+2:], `\)`); end >= 0 {
+			flushText()
+			*inlines = append(*inlines, &Inline{Kind: MathInline, Text: text[i+2 : i+2+end]})
+			return i + 2 + end + 2
+		}
+	case !isLetter(next):
+		if symbol, ok := texSymbols[string(next)]; ok {
+			sb.WriteString(symbol)
+		}
+		return i + 2
+	}
+
+	end := i + 1
+	for end < len(text) && isLetter(text[end]) {
+		end++
+	}
+	name := text[i+1 : end]
+	if symbol, ok := texSymbols[name]; ok {
+		sb.WriteString(symbol)
+		return end
+	}
+
+	at := skipTeXOption(text, end)
+	arg, after, ok := readTeXGroup(text, at)
+
+	switch name {
+	case "textbf", "textsc":
+		flushText()
+		*inlines = append(*inlines, &Inline{Kind: StrongInline, Children: parseTeXInlines(arg)})
+	case "textit", "emph", "textsl":
+		flushText()
+		*inlines = append(*inlines, &Inline{Kind: EmphasisInline, Children: parseTeXInlines(arg)})
+	case "texttt", "verb":
+		flushText()
+		*inlines = append(*inlines, &Inline{Kind: CodeInline, Text: arg})
+	case "url":
+		flushText()
+		*inlines = append(*inlines, &Inline{Kind: LinkInline, Href: arg, Children: []*Inline{{Kind: TextInline, Text: arg}}})
+	case "href":
+		label, afterLabel, _ := readTeXGroup(text, after)
+		flushText()
+		*inlines = append(*inlines, &Inline{Kind: LinkInline, Href: arg, Children: parseTeXInlines(label)})
+		return afterLabel
+	case "cite", "citep", "citet":
+		sb.WriteString("[" + arg + "]")
+	case "ref", "eqref":
+		sb.WriteString(arg)
+	case "footnote":
+		sb.WriteString(" (")
+		flushText()
+		*inlines = append(*inlines, parseTeXInlines(arg)...)
+		sb.WriteString(")")
+	case "label", "index", "vspace", "hspace":
+		// not rendered
+	default:
+		if ok {
+			flushText()
+			*inlines = append(*inlines, parseTeXInlines(arg)...)
+		}
+	}
+
+	if ok {
+		return after
+	}
+	return end
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
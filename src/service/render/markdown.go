@@ -0,0 +1,222 @@
+package render
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	mdHeading   = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdBullet    = regexp.MustCompile(`^\s{0,3}[-*+]\s+(.*)$`)
+	mdNumbered  = regexp.MustCompile(`^\s{0,3}\d{1,9}[.)]\s+(.*)$`)
+	mdQuote     = regexp.MustCompile(`^\s{0,3}>\s?(.*)$`)
+	mdFence     = regexp.MustCompile("^\\s{0,3}(```|~~~)")
+	mdRule      = regexp.MustCompile(`^\s{0,3}([-*_])(\s*([-*_])){2,}\s*$`)
+	mdLinkStart = regexp.MustCompile(`^\[([^\]]*)\]\(([^)\s]*)\)`)
+)
+
+// parseMarkdown parses the CommonMark subset: the headings, the paragraphs, the lists,
+// the quotes, the fenced code, the $$ math blocks and the inline markup
+func parseMarkdown(source string) *Document {
+	doc := new(Document)
+	lines := strings.Split(source, "\n")
+
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			doc.Blocks = append(doc.Blocks, &Block{
+				Kind:    ParagraphBlock,
+				Inlines: parseMarkdownInlines(strings.Join(paragraph, "\n")),
+			})
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			flush()
+
+		case mdFence.MatchString(line):
+			flush()
+			fence := mdFence.FindStringSubmatch(line)[1]
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, lines[i])
+			}
+			doc.Blocks = append(doc.Blocks, &Block{Kind: CodeBlock, Text: strings.Join(code, "\n")})
+
+		case strings.HasPrefix(trimmed, "$$"):
+			flush()
+			// the single line $$...$$ or the lines until the closing $$
+			if rest := strings.TrimPrefix(trimmed, "$$"); strings.HasSuffix(rest, "$$") {
+				doc.Blocks = append(doc.Blocks, &Block{Kind: MathBlock, Text: strings.TrimSpace(strings.TrimSuffix(rest, "$$"))})
+				continue
+			}
+			math := []string{strings.TrimPrefix(trimmed, "$$")}
+			for i++; i < len(lines); i++ {
+				if end := strings.Index(lines[i], "$$"); end >= 0 {
+					math = append(math, lines[i][:end])
+					break
+				}
+				math = append(math, lines[i])
+			}
+			doc.Blocks = append(doc.Blocks, &Block{Kind: MathBlock, Text: strings.TrimSpace(strings.Join(math, "\n"))})
+
+		case mdHeading.MatchString(line):
+			flush()
+			heading := mdHeading.FindStringSubmatch(line)
+			doc.Blocks = append(doc.Blocks, &Block{
+				Kind:    HeadingBlock,
+				Level:   len(heading[1]),
+				Inlines: parseMarkdownInlines(heading[2]),
+			})
+
+		case mdRule.MatchString(line):
+			flush()
+
+		case mdQuote.MatchString(line):
+			flush()
+			var quote []string
+			for ; i < len(lines) && mdQuote.MatchString(lines[i]); i++ {
+				quote = append(quote, mdQuote.FindStringSubmatch(lines[i])[1])
+			}
+			i--
+			doc.Blocks = append(doc.Blocks, &Block{Kind: QuoteBlock, Inlines: parseMarkdownInlines(strings.Join(quote, "\n"))})
+
+		case mdBullet.MatchString(line), mdNumbered.MatchString(line):
+			flush()
+			list := &Block{Kind: ListBlock, Ordered: mdNumbered.MatchString(line)}
+			item := mdListPattern(list.Ordered)
+			var current []string
+			for ; i < len(lines); i++ {
+				if match := item.FindStringSubmatch(lines[i]); match != nil {
+					if current != nil {
+						list.Items = append(list.Items, parseMarkdownInlines(strings.Join(current, "\n")))
+					}
+					current = []string{match[1]}
+					continue
+				}
+				// the indented line continues the item
+				if strings.TrimSpace(lines[i]) != "" && (strings.HasPrefix(lines[i], " ") || strings.HasPrefix(lines[i], "\t")) {
+					current = append(current, strings.TrimSpace(lines[i]))
+					continue
+				}
+				break
+			}
+			i--
+			list.Items = append(list.Items, parseMarkdownInlines(strings.Join(current, "\n")))
+			doc.Blocks = append(doc.Blocks, list)
+
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+	flush()
+
+	return doc
+}
+
+func mdListPattern(ordered bool) *regexp.Regexp {
+	if ordered {
+		return mdNumbered
+	}
+	return mdBullet
+}
+
+// parseMarkdownInlines parses the emphasis, the code, the math, the links and the escapes
+func parseMarkdownInlines(text string) []*Inline {
+	var (
+		inlines []*Inline
+		sb      strings.Builder
+	)
+	flushText := func() {
+		if sb.Len() > 0 {
+			inlines = append(inlines, &Inline{Kind: TextInline, Text: sb.String()})
+			sb.Reset()
+		}
+	}
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '\\' && i+1 < len(text) && strings.IndexByte("\\`*_{}[]()#+-.!$>", text[i+1]) >= 0:
+			sb.WriteByte(text[i+1])
+			i += 2
+
+		case c == '\n':
+			// two trailing spaces make the line break, otherwise the lines are joined
+			if strings.HasSuffix(sb.String(), "  ") {
+				trimmed := strings.TrimRight(sb.String(), " ")
+				sb.Reset()
+				sb.WriteString(trimmed)
+				flushText()
+				inlines = append(inlines, &Inline{Kind: BreakInline})
+			} else {
+				sb.WriteByte(' ')
+			}
+			i++
+
+		case c == '`':
+			if end := strings.IndexByte(text[i+1:], '`'); end >= 0 {
+				flushText()
+				inlines = append(inlines, &Inline{Kind: CodeInline, Text: text[i+1 : i+1+end]})
+				i += end + 2
+				continue
+			}
+			sb.WriteByte(c)
+			i++
+
+		case c == '$':
+			if end := strings.IndexByte(text[i+1:], '$'); end > 0 {
+				flushText()
+				inlines = append(inlines, &Inline{Kind: MathInline, Text: text[i+1 : i+1+end]})
+				i += end + 2
+				continue
+			}
+			sb.WriteByte(c)
+			i++
+
+		case c == '[':
+			if match := mdLinkStart.FindStringSubmatch(text[i:]); match != nil {
+				flushText()
+				inlines = append(inlines, &Inline{
+					Kind:     LinkInline,
+					Href:     match[2],
+					Children: parseMarkdownInlines(match[1]),
+				})
+				i += len(match[0])
+				continue
+			}
+			sb.WriteByte(c)
+			i++
+
+		case c == '*' || c == '_':
+			delim := string(c)
+			kind := EmphasisInline
+			if strings.HasPrefix(text[i:], delim+delim) {
+				delim += delim
+				kind = StrongInline
+			}
+			rest := text[i+len(delim):]
+			if end := strings.Index(rest, delim); end > 0 && !strings.HasPrefix(rest, " ") {
+				flushText()
+				inlines = append(inlines, &Inline{Kind: kind, Children: parseMarkdownInlines(rest[:end])})
+				i += len(delim)*2 + end
+				continue
+			}
+			sb.WriteString(delim)
+			i += len(delim)
+
+		default:
+			sb.WriteByte(c)
+			i++
+		}
+	}
+	flushText()
+
+	return inlines
+}
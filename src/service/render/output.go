@@ -0,0 +1,220 @@
+package render
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// HTML renders the document to the HTML fragment. All the text is escaped and only
+// the fixed set of tags is produced, so the output is safe to embed. The math is left
+// in the \( \) and \[ \] delimiters with the "math" class to be typeset by the client.
+func (d *Document) HTML() string {
+	parts := make([]string, 0, len(d.Blocks))
+	for _, block := range d.Blocks {
+		var sb strings.Builder
+		switch block.Kind {
+		case ParagraphBlock:
+			sb.WriteString("<p>")
+			writeHTMLInlines(&sb, block.Inlines)
+			sb.WriteString("</p>")
+		case HeadingBlock:
+			level := strconv.Itoa(clampLevel(block.Level))
+			sb.WriteString("<h" + level + ">")
+			writeHTMLInlines(&sb, block.Inlines)
+			sb.WriteString("</h" + level + ">")
+		case ListBlock:
+			tag := "ul"
+			if block.Ordered {
+				tag = "ol"
+			}
+			sb.WriteString("<" + tag + ">\n")
+			for _, item := range block.Items {
+				sb.WriteString("<li>")
+				writeHTMLInlines(&sb, item)
+				sb.WriteString("</li>\n")
+			}
+			sb.WriteString("</" + tag + ">")
+		case CodeBlock:
+			sb.WriteString("<pre><code>" + html.EscapeString(block.Text) + "</code></pre>")
+		case MathBlock:
+			sb.WriteString(`<div class="math display">\[` + html.EscapeString(block.Text) + `\]</div>`)
+		case QuoteBlock:
+			sb.WriteString("<blockquote><p>")
+			writeHTMLInlines(&sb, block.Inlines)
+			sb.WriteString("</p></blockquote>")
+		}
+		parts = append(parts, sb.String())
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+func writeHTMLInlines(sb *strings.Builder, inlines []*Inline) {
+	for _, inline := range inlines {
+		switch inline.Kind {
+		case TextInline:
+			sb.WriteString(html.EscapeString(inline.Text))
+		case StrongInline:
+			sb.WriteString("<strong>")
+			writeHTMLInlines(sb, inline.Children)
+			sb.WriteString("</strong>")
+		case EmphasisInline:
+			sb.WriteString("<em>")
+			writeHTMLInlines(sb, inline.Children)
+			sb.WriteString("</em>")
+		case CodeInline:
+			sb.WriteString("<code>" + html.EscapeString(inline.Text) + "</code>")
+		case MathInline:
+			sb.WriteString(`<span class="math inline">\(` + html.EscapeString(inline.Text) + `\)</span>`)
+		case LinkInline:
+			// the unsafe link is rendered as its text
+			if !safeHref(inline.Href) {
+				writeHTMLInlines(sb, inline.Children)
+				continue
+			}
+			sb.WriteString(`<a href="` + html.EscapeString(strings.TrimSpace(inline.Href)) + `" rel="nofollow noopener noreferrer">`)
+			writeHTMLInlines(sb, inline.Children)
+			sb.WriteString("</a>")
+		case BreakInline:
+			sb.WriteString("<br>")
+		}
+	}
+}
+
+func clampLevel(level int) int {
+	switch {
+	case level < 1:
+		return 1
+	case level > 6:
+		return 6
+	default:
+		return level
+	}
+}
+
+// Text renders the document to the plain text, the paragraphs are separated by the blank lines
+func (d *Document) Text() string {
+	parts := make([]string, 0, len(d.Blocks))
+	for _, block := range d.Blocks {
+		switch block.Kind {
+		case ParagraphBlock, HeadingBlock:
+			parts = append(parts, InlineText(block.Inlines))
+		case QuoteBlock:
+			parts = append(parts, "> "+strings.ReplaceAll(InlineText(block.Inlines), "\n", "\n> "))
+		case ListBlock:
+			items := make([]string, 0, len(block.Items))
+			for i, item := range block.Items {
+				marker := "- "
+				if block.Ordered {
+					marker = fmt.Sprintf("%d. ", i+1)
+				}
+				items = append(items, marker+InlineText(item))
+			}
+			parts = append(parts, strings.Join(items, "\n"))
+		case CodeBlock:
+			parts = append(parts, block.Text)
+		case MathBlock:
+			parts = append(parts, "$$"+block.Text+"$$")
+		}
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// InlineText returns the text of the inlines, the math is kept in the $ delimiters
+// and the links are followed by their targets
+func InlineText(inlines []*Inline) string {
+	var sb strings.Builder
+	for _, inline := range inlines {
+		switch inline.Kind {
+		case TextInline, CodeInline:
+			sb.WriteString(inline.Text)
+		case StrongInline, EmphasisInline:
+			sb.WriteString(InlineText(inline.Children))
+		case MathInline:
+			sb.WriteString("$" + inline.Text + "$")
+		case LinkInline:
+			text := InlineText(inline.Children)
+			sb.WriteString(text)
+			if href := strings.TrimSpace(inline.Href); href != "" && href != text {
+				sb.WriteString(" (" + href + ")")
+			}
+		case BreakInline:
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String()
+}
+
+var (
+	mdEscaper     = strings.NewReplacer(`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "$", `\$`)
+	mdBlockMarker = regexp.MustCompile(`^(?:[#>+-]|\d{1,9}[.)])`)
+)
+
+// Markdown renders the document to the Markdown source parsed back into the same document
+func (d *Document) Markdown() string {
+	parts := make([]string, 0, len(d.Blocks))
+	for _, block := range d.Blocks {
+		switch block.Kind {
+		case ParagraphBlock:
+			parts = append(parts, escapeBlockMarker(markdownInlines(block.Inlines)))
+		case HeadingBlock:
+			parts = append(parts, strings.Repeat("#", clampLevel(block.Level))+" "+markdownInlines(block.Inlines))
+		case QuoteBlock:
+			parts = append(parts, "> "+strings.ReplaceAll(markdownInlines(block.Inlines), "\n", "\n> "))
+		case ListBlock:
+			items := make([]string, 0, len(block.Items))
+			for i, item := range block.Items {
+				marker := "- "
+				if block.Ordered {
+					marker = fmt.Sprintf("%d. ", i+1)
+				}
+				items = append(items, marker+strings.ReplaceAll(markdownInlines(item), "\n", "\n"+strings.Repeat(" ", len(marker))))
+			}
+			parts = append(parts, strings.Join(items, "\n"))
+		case CodeBlock:
+			parts = append(parts, "```\n"+block.Text+"\n```")
+		case MathBlock:
+			parts = append(parts, "$$\n"+block.Text+"\n$$")
+		}
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// escapeBlockMarker escapes the start of the paragraph which would be parsed as another block
+func escapeBlockMarker(text string) string {
+	marker := mdBlockMarker.FindString(text)
+	if marker == "" {
+		return text
+	}
+	return marker[:len(marker)-1] + `\` + text[len(marker)-1:]
+}
+
+func markdownInlines(inlines []*Inline) string {
+	var sb strings.Builder
+	for _, inline := range inlines {
+		switch inline.Kind {
+		case TextInline:
+			sb.WriteString(mdEscaper.Replace(inline.Text))
+		case StrongInline:
+			sb.WriteString("**" + markdownInlines(inline.Children) + "**")
+		case EmphasisInline:
+			sb.WriteString("*" + markdownInlines(inline.Children) + "*")
+		case CodeInline:
+			sb.WriteString("`" + inline.Text + "`")
+		case MathInline:
+			sb.WriteString("$" + inline.Text + "$")
+		case LinkInline:
+			sb.WriteString("[" + markdownInlines(inline.Children) + "](" + strings.TrimSpace(inline.Href) + ")")
+		case BreakInline:
+			sb.WriteString("  \n")
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,272 @@
+package render
+
+import (
+	"bytes"
+	"embed"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// Cover is the citation cover page of the work PDF
+type Cover struct {
+	Title       string
+	Authors     []string
+	Annotation  string
+	License     string
+	ContentHash string // the SHA-256 of the content
+	CID         string // the IPFS CID of the published work
+	URL         string
+	CiteAs      string
+	Date        time.Time // the date of the PDF, the same revision is typeset to the same bytes
+}
+
+const (
+	pdfFontSize   = 11
+	pdfLineHeight = 5.5
+	pdfIndent     = 8
+	pdfCodeFamily = "Courier"
+	pdfFontFamily = "Work"
+)
+
+// the bundled DejaVu Sans Condensed covers the Latin, Greek and Cyrillic text,
+// see fonts/README.md for its license
+//
+//go:embed fonts/*.ttf
+var bundledFonts embed.FS
+
+var bundledFontStyles = map[string]string{
+	"":   "fonts/DejaVuSansCondensed.ttf",
+	"B":  "fonts/DejaVuSansCondensed-Bold.ttf",
+	"I":  "fonts/DejaVuSansCondensed-Oblique.ttf",
+	"BI": "fonts/DejaVuSansCondensed-BoldOblique.ttf",
+}
+
+// pdfWriter typesets the document with the Unicode TrueType font, the code is typeset
+// with the core Courier, so its text is translated to the Latin-1 code page
+type pdfWriter struct {
+	pdf    *fpdf.Fpdf
+	family string
+	latin  func(string) string
+}
+
+// PDF typesets the cover page followed by the document. The text is typeset with the bundled
+// DejaVu Sans, the TrueType font file is used for all the styles instead if it's given.
+func (d *Document) PDF(cover *Cover, fontFile string) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(20, 20, 20)
+	pdf.SetAutoPageBreak(true, 20)
+
+	w := &pdfWriter{pdf: pdf, family: pdfFontFamily}
+	w.latin = pdf.UnicodeTranslatorFromDescriptor("")
+	if err := addFonts(pdf, fontFile); err != nil {
+		return nil, err
+	}
+
+	pdf.SetTitle(cover.Title, true)
+	pdf.SetAuthor(strings.Join(cover.Authors, ", "), true)
+	pdf.SetSubject(cover.Annotation, true)
+	pdf.SetCreator("Sea of Wisdom", true)
+	pdf.SetCreationDate(cover.Date)
+	pdf.SetModificationDate(cover.Date)
+	pdf.SetCatalogSort(true)
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		pdf.SetFont(w.family, "", 8)
+		pdf.SetTextColor(128, 128, 128)
+		pdf.CellFormat(0, 10, strconv.Itoa(pdf.PageNo()), "", 0, "C", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	})
+
+	w.cover(cover)
+
+	pdf.AddPage()
+	for _, block := range d.Blocks {
+		w.block(block)
+	}
+
+	if err := pdf.Error(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// addFonts adds the styles of the text font, the bundled one or the font file
+func addFonts(pdf *fpdf.Fpdf, fontFile string) error {
+	var font []byte
+	if fontFile != "" {
+		var err error
+		if font, err = os.ReadFile(fontFile); err != nil {
+			return err
+		}
+	}
+
+	for style, name := range bundledFontStyles {
+		data := font
+		if data == nil {
+			var err error
+			if data, err = bundledFonts.ReadFile(name); err != nil {
+				return err
+			}
+		}
+		pdf.AddUTF8FontFromBytes(pdfFontFamily, style, data)
+	}
+
+	return pdf.Error()
+}
+
+func (w *pdfWriter) cover(cover *Cover) {
+	pdf := w.pdf
+	pdf.AddPage()
+
+	pdf.SetFont(w.family, "B", 20)
+	pdf.MultiCell(0, 9, cover.Title, "", "L", false)
+	pdf.Ln(3)
+
+	pdf.SetFont(w.family, "", 13)
+	pdf.MultiCell(0, 7, strings.Join(cover.Authors, ", "), "", "L", false)
+	pdf.Ln(6)
+
+	if cover.Annotation != "" {
+		w.heading("Abstract", 12)
+		pdf.SetFont(w.family, "", pdfFontSize)
+		pdf.MultiCell(0, pdfLineHeight, cover.Annotation, "", "J", false)
+		pdf.Ln(6)
+	}
+
+	left, _, right, _ := pdf.GetMargins()
+	width, _ := pdf.GetPageSize()
+	pdf.SetDrawColor(160, 160, 160)
+	pdf.Line(left, pdf.GetY(), width-right, pdf.GetY())
+	pdf.Ln(4)
+
+	field := func(name, value string, code bool) {
+		if value == "" {
+			return
+		}
+		pdf.SetFont(w.family, "B", 9)
+		pdf.CellFormat(35, pdfLineHeight, name, "", 0, "L", false, 0, "")
+		if code {
+			pdf.SetFont(pdfCodeFamily, "", 9)
+			value = w.latin(value)
+		} else {
+			pdf.SetFont(w.family, "", 9)
+		}
+		pdf.MultiCell(0, pdfLineHeight, value, "", "L", false)
+	}
+	field("License", cover.License, false)
+	field("Content SHA-256", cover.ContentHash, true)
+	field("IPFS CID", cover.CID, true)
+	field("URL", cover.URL, false)
+
+	if cover.CiteAs != "" {
+		pdf.Ln(6)
+		w.heading("Cite as", 12)
+		pdf.SetFont(w.family, "", 10)
+		pdf.SetFillColor(240, 240, 240)
+		pdf.MultiCell(0, pdfLineHeight, cover.CiteAs, "", "L", true)
+	}
+}
+
+func (w *pdfWriter) heading(text string, size float64) {
+	w.pdf.SetFont(w.family, "B", size)
+	w.pdf.MultiCell(0, size*0.5, text, "", "L", false)
+	w.pdf.Ln(2)
+}
+
+func (w *pdfWriter) block(block *Block) {
+	pdf := w.pdf
+	switch block.Kind {
+	case ParagraphBlock:
+		w.inlines(block.Inlines, "")
+		pdf.Ln(pdfLineHeight * 1.5)
+	case HeadingBlock:
+		pdf.Ln(2)
+		w.heading(InlineText(block.Inlines), float64(20-2*clampLevel(block.Level)))
+	case ListBlock:
+		left, _, _, _ := pdf.GetMargins()
+		for i, item := range block.Items {
+			marker := "•"
+			if block.Ordered {
+				marker = strconv.Itoa(i+1) + "."
+			}
+			pdf.SetFont(w.family, "", pdfFontSize)
+			pdf.SetX(left)
+			pdf.CellFormat(pdfIndent, pdfLineHeight, marker, "", 0, "L", false, 0, "")
+			pdf.SetLeftMargin(left + pdfIndent)
+			w.inlines(item, "")
+			pdf.SetLeftMargin(left)
+			pdf.Ln(pdfLineHeight)
+		}
+		pdf.Ln(pdfLineHeight * 0.5)
+	case CodeBlock:
+		pdf.SetFont(pdfCodeFamily, "", 9)
+		pdf.SetFillColor(245, 245, 245)
+		pdf.MultiCell(0, 4.5, w.latin(block.Text), "", "L", true)
+		pdf.Ln(pdfLineHeight)
+	case MathBlock:
+		pdf.SetFont(w.family, "I", pdfFontSize)
+		pdf.MultiCell(0, pdfLineHeight, block.Text, "", "C", false)
+		pdf.Ln(pdfLineHeight)
+	case QuoteBlock:
+		left, _, _, _ := pdf.GetMargins()
+		pdf.SetLeftMargin(left + pdfIndent)
+		pdf.SetX(left + pdfIndent)
+		w.inlines(block.Inlines, "I")
+		pdf.SetLeftMargin(left)
+		pdf.Ln(pdfLineHeight * 1.5)
+	}
+}
+
+// inlines writes the flowing text of the inlines in the style
+func (w *pdfWriter) inlines(inlines []*Inline, style string) {
+	pdf := w.pdf
+	for _, inline := range inlines {
+		pdf.SetFont(w.family, style, pdfFontSize)
+		switch inline.Kind {
+		case TextInline:
+			pdf.Write(pdfLineHeight, inline.Text)
+		case StrongInline:
+			w.inlines(inline.Children, addStyle(style, "B"))
+		case EmphasisInline:
+			w.inlines(inline.Children, addStyle(style, "I"))
+		case CodeInline:
+			pdf.SetFont(pdfCodeFamily, "", pdfFontSize-1)
+			pdf.Write(pdfLineHeight, w.latin(inline.Text))
+		case MathInline:
+			pdf.SetFont(w.family, addStyle(style, "I"), pdfFontSize)
+			pdf.Write(pdfLineHeight, inline.Text)
+		case LinkInline:
+			text := InlineText(inline.Children)
+			if safeHref(inline.Href) {
+				pdf.SetTextColor(0, 70, 160)
+				pdf.WriteLinkString(pdfLineHeight, text, strings.TrimSpace(inline.Href))
+				pdf.SetTextColor(0, 0, 0)
+				continue
+			}
+			pdf.Write(pdfLineHeight, text)
+		case BreakInline:
+			pdf.Ln(pdfLineHeight)
+		}
+	}
+}
+
+func addStyle(style, add string) string {
+	if strings.Contains(style, add) {
+		return style
+	}
+	// the core fonts are named by the "BI" style
+	if add == "B" {
+		return add + style
+	}
+	return style + add
+}
@@ -0,0 +1,75 @@
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/ledongthuc/pdf"
+)
+
+func TestPDFUnicode(t *testing.T) {
+	doc, err := Parse("markdown", "# Введение\n\nТекст работы на **русском** языке.")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := doc.PDF(&Cover{
+		Title:   "Море мудрости",
+		Authors: []string{"Иван Петров"},
+		Date:    time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the text is shown by the embedded font in UTF-16BE
+	var shown bytes.Buffer
+	for i := 1; i <= reader.NumPage(); i++ {
+		page := reader.Page(i)
+		for _, font := range page.Fonts() {
+			if name := page.Font(font).BaseFont(); strings.HasSuffix(name, "Helvetica") {
+				t.Errorf("the PDF uses the core font %s", name)
+			}
+		}
+
+		content := page.V.Key("Contents").Reader()
+		if _, err := shown.ReadFrom(content); err != nil {
+			t.Fatal(err)
+		}
+		content.Close()
+	}
+
+	for _, want := range []string{"Море", "Иван Петров", "Введение", "русском"} {
+		if !bytes.Contains(shown.Bytes(), utf16BE(want)) {
+			t.Errorf("the PDF doesn't show %q", want)
+		}
+	}
+}
+
+func utf16BE(s string) []byte {
+	var b []byte
+	for _, r := range utf16.Encode([]rune(s)) {
+		b = append(b, byte(r>>8), byte(r))
+	}
+
+	return b
+}
+
+func TestPDFFontFile(t *testing.T) {
+	doc, err := Parse("plain", "text")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := doc.PDF(&Cover{Title: "Work"}, "testdata/missing.ttf"); err == nil {
+		t.Error("the PDF is typeset with the missing font file")
+	}
+}
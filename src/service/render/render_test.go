@@ -0,0 +1,161 @@
+package render
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRenderHTML(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		source string
+		want   string
+	}{
+		// the plain text
+		{"plain", PlainFormat, "a < b\nc", `<p>a &lt; b<br>c</p>`},
+		{"plain script", "", `<script>alert("x")</script>`, `<p>&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;</p>`},
+
+		// the Markdown subset
+		{"md heading", MarkdownFormat, "## The <title>", `<h2>The &lt;title&gt;</h2>`},
+		{"md emphasis", MarkdownFormat, "Text *em*, _em_ and **strong**", `<p>Text <em>em</em>, <em>em</em> and <strong>strong</strong></p>`},
+		{"md bullets", MarkdownFormat, "- one\n* two", "<ul>\n<li>one</li>\n<li>two</li>\n</ul>"},
+		{"md numbered", MarkdownFormat, "1. one\n2. two", "<ol>\n<li>one</li>\n<li>two</li>\n</ol>"},
+		{"md quote", MarkdownFormat, "> quoted\n> text", `<blockquote><p>quoted text</p></blockquote>`},
+		{"md fence", MarkdownFormat, "```\nif x < y {}\n```", `<pre><code>if x &lt; y {}</code></pre>`},
+		{"md code", MarkdownFormat, "`a<b`", `<p><code>a&lt;b</code></p>`},
+		{"md break", MarkdownFormat, "line  \nbreak", `<p>line<br>break</p>`},
+		{"md escapes", MarkdownFormat, `\*not em\* and \$5`, `<p>*not em* and $5</p>`},
+		{"md link", MarkdownFormat, "[site](https://example.org)", `<p><a href="https://example.org" rel="nofollow noopener noreferrer">site</a></p>`},
+		{"md link attribute", MarkdownFormat, `[q](https://example.org/?a=1&b="2")`, `<p><a href="https://example.org/?a=1&amp;b=&#34;2&#34;" rel="nofollow noopener noreferrer">q</a></p>`},
+		{"md raw html", MarkdownFormat, `<img src=x onerror="alert(1)">`, `<p>&lt;img src=x onerror=&#34;alert(1)&#34;&gt;</p>`},
+
+		// the math
+		{"md inline math", MarkdownFormat, "$a<b$", `<p><span class="math inline">\(a&lt;b\)</span></p>`},
+		{"md display math", MarkdownFormat, "$$\na < b\n$$", `<div class="math display">\[a &lt; b\]</div>`},
+		{"md single line math", MarkdownFormat, "$$x^2$$", `<div class="math display">\[x^2\]</div>`},
+		{"tex inline math", LaTeXFormat, `$x$ and \(y\)`, `<p><span class="math inline">\(x\)</span> and <span class="math inline">\(y\)</span></p>`},
+		{"tex display math", LaTeXFormat, `\[ a < b \]`, `<div class="math display">\[a &lt; b\]</div>`},
+		{"tex equation", LaTeXFormat, "\\begin{equation}\nE = mc^2\n\\end{equation}", `<div class="math display">\[E = mc^2\]</div>`},
+		{"tex align", LaTeXFormat, "\\begin{align*}\na &= b\n\\end{align*}", `<div class="math display">\[a &amp;= b\]</div>`},
+
+		// the LaTeX subset
+		{"tex preamble", LaTeXFormat, "\\documentclass{article}\n\\usepackage{amsmath}\n\\begin{document}\nHello\n\\end{document}", `<p>Hello</p>`},
+		{"tex section", LaTeXFormat, `\section{Intro}`, `<h2>Intro</h2>`},
+		{"tex subsection", LaTeXFormat, `\subsection*{More}`, `<h3>More</h3>`},
+		{"tex formatting", LaTeXFormat, `\emph{a}, \textit{b} and \textbf{c}`, `<p><em>a</em>, <em>b</em> and <strong>c</strong></p>`},
+		{"tex code", LaTeXFormat, `\texttt{x<y}`, `<p><code>x&lt;y</code></p>`},
+		{"tex itemize", LaTeXFormat, "\\begin{itemize}\n\\item one\n\\item two\n\\end{itemize}", "<ul>\n<li>one</li>\n<li>two</li>\n</ul>"},
+		{"tex enumerate", LaTeXFormat, "\\begin{enumerate}\n\\item one\n\\item two\n\\end{enumerate}", "<ol>\n<li>one</li>\n<li>two</li>\n</ol>"},
+		{"tex verbatim", LaTeXFormat, "\\begin{verbatim}\n<b>\n\\end{verbatim}", `<pre><code>&lt;b&gt;</code></pre>`},
+		{"tex abstract", LaTeXFormat, "\\begin{abstract}\nThe work.\n\\end{abstract}", `<blockquote><p>The work.</p></blockquote>`},
+		{"tex comments", LaTeXFormat, "Text% hidden\nmore", `<p>Text more</p>`},
+		{"tex escaped percent", LaTeXFormat, `100\% sure`, `<p>100% sure</p>`},
+		{"tex symbols", LaTeXFormat, `a \& b~c \ldots`, `<p>a &amp; b c …</p>`},
+		{"tex unknown command", LaTeXFormat, `\foo{bar} baz`, `<p>bar baz</p>`},
+		{"tex cite", LaTeXFormat, `see \cite{knuth}`, `<p>see [knuth]</p>`},
+		{"tex link", LaTeXFormat, `\href{https://example.org}{site}`, `<p><a href="https://example.org" rel="nofollow noopener noreferrer">site</a></p>`},
+		{"tex raw html", LaTeXFormat, `<b>bold</b>`, `<p>&lt;b&gt;bold&lt;/b&gt;</p>`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Render(test.format, test.source, HTMLOutput)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("Render(%q) =\n%s\nwant\n%s", test.source, got, test.want)
+			}
+		})
+	}
+}
+
+func TestSafeHref(t *testing.T) {
+	tests := []struct {
+		href string
+		safe bool
+	}{
+		{"https://example.org", true},
+		{"http://example.org", true},
+		{"HTTPS://EXAMPLE.ORG", true},
+		{"  https://example.org", true},
+		{"mailto:editor@example.org", true},
+		{"javascript:alert(1)", false},
+		{"JavaScript:alert(1)", false},
+		{" javascript:alert(1)", false},
+		{"java\tscript:alert(1)", false},
+		{"data:text/html;base64,PHNjcmlwdD4=", false},
+		{"vbscript:msgbox(1)", false},
+		{"//example.org", false},
+		{"/works/1", false},
+		{"", false},
+	}
+
+	for _, test := range tests {
+		if got := safeHref(test.href); got != test.safe {
+			t.Errorf("safeHref(%q) = %v, want %v", test.href, got, test.safe)
+		}
+	}
+}
+
+// TestRenderUnsafeLinks checks the unsafe links are rendered as their text
+func TestRenderUnsafeLinks(t *testing.T) {
+	tests := []struct {
+		format string
+		source string
+		want   string
+	}{
+		{MarkdownFormat, "[click](javascript:alert%281%29)", `<p>click</p>`},
+		{MarkdownFormat, "[click](JAVASCRIPT:alert%281%29)", `<p>click</p>`},
+		{MarkdownFormat, "[click](data:text/html;base64,PHNjcmlwdD4=)", `<p>click</p>`},
+		{MarkdownFormat, "[click](vbscript:msgbox)", `<p>click</p>`},
+		{LaTeXFormat, `\href{javascript:alert(1)}{click}`, `<p>click</p>`},
+		{LaTeXFormat, `\url{javascript:alert(1)}`, `<p>javascript:alert(1)</p>`},
+	}
+
+	for _, test := range tests {
+		got, err := Render(test.format, test.source, HTMLOutput)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.want {
+			t.Errorf("Render(%q) = %s, want %s", test.source, got, test.want)
+		}
+		if strings.Contains(got, "<a") {
+			t.Errorf("the unsafe link of %q is rendered", test.source)
+		}
+	}
+}
+
+func TestRenderText(t *testing.T) {
+	tests := []struct {
+		format string
+		source string
+		want   string
+	}{
+		{MarkdownFormat, "# Title\n\nThe $x$ of [site](https://example.org).", "Title\n\nThe $x$ of site (https://example.org)."},
+		{MarkdownFormat, "1. one\n2. two\n\n> quoted", "1. one\n2. two\n\n> quoted"},
+		{LaTeXFormat, "\\section{Intro}\n\\[x^2\\]", "Intro\n\n$$x^2$$"},
+	}
+
+	for _, test := range tests {
+		got, err := Render(test.format, test.source, TextOutput)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.want {
+			t.Errorf("Render(%q) =\n%s\nwant\n%s", test.source, got, test.want)
+		}
+	}
+}
+
+func TestRenderUnknown(t *testing.T) {
+	if _, err := Render("rtf", "text", HTMLOutput); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("err = %v, want %v", err, ErrUnknownFormat)
+	}
+	if _, err := Render(MarkdownFormat, "text", "doc"); !errors.Is(err, ErrUnknownOutput) {
+		t.Errorf("err = %v, want %v", err, ErrUnknownOutput)
+	}
+}
@@ -0,0 +1,128 @@
+package srv
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/render"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+)
+
+// getReadableWork returns the work with the content if the reader has access to it
+func (ls *LibrarySrv) getReadableWork(ctx context.Context, readerAddress, workID string) (*storage.WorkResponse, error) {
+	workResp, err := ls.storage.GetWorkByID(ctx, workID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the draft is shown to its author only
+	if workResp == nil || workResp.Work.Status == storage.DraftWorkStatus && !isWorkAuthor(workResp, readerAddress) {
+		return nil, storage.ErrWorkNotExists
+	}
+
+	if !ls.hasContentAccess(readerAddress, workID) {
+		return nil, ErrContentAccessDenied
+	}
+
+	if workResp.Work.Content == nil || workResp.Work.Content.WorkData == "" {
+		return nil, ErrNoContent
+	}
+
+	return workResp, nil
+}
+
+// getOpenWork returns the open work, the status is checked by the participant's work
+// as the work keeps the status it has been submitted with
+func (ls *LibrarySrv) getOpenWork(ctx context.Context, workID string) (*storage.WorkResponse, error) {
+	participantsWork, err := ls.storage.GetParticipantWorkByID(workID)
+	if err != nil {
+		return nil, err
+	}
+
+	if participantsWork.Status != storage.OpenWorkStatus {
+		return nil, storage.ErrWorkNotExists
+	}
+
+	workResp, err := ls.storage.GetWorkByID(ctx, workID)
+	if err != nil {
+		return nil, err
+	}
+
+	if workResp == nil {
+		return nil, storage.ErrWorkNotExists
+	}
+	workResp.Work.Status = participantsWork.Status
+
+	return workResp, nil
+}
+
+// contentHash identifies the revision of the content
+func contentHash(content *storage.WorkContent) string {
+	sum := sha256.Sum256([]byte(content.Format + "\x00" + content.WorkData))
+	return hex.EncodeToString(sum[:])
+}
+
+// RenderWork renders the content of the work to HTML or plain text, the rendering is cached
+// per revision of the content and the reader's watermark is added to the served copy
+func (ls *LibrarySrv) RenderWork(ctx context.Context, readerAddress, workID, output string) (string, error) {
+	if output != render.HTMLOutput && output != render.TextOutput {
+		return "", render.ErrUnknownOutput
+	}
+
+	workResp, err := ls.getReadableWork(ctx, readerAddress, workID)
+	if err != nil {
+		return "", err
+	}
+
+	content := workResp.Work.Content
+	hash := contentHash(content)
+	rendering, err := ls.storage.GetWorkRendering(workResp.Work, hash, output)
+	if err != nil {
+		ls.log.Errorf("RenderWork: error get rendering of work %s, err: %v", workID, err)
+
+		return "", err
+	}
+
+	if rendering == nil {
+		body, err := render.Render(content.Format, content.WorkData, output)
+		if err != nil {
+			return "", err
+		}
+
+		rendering = &storage.WorkRendering{WorkID: workID, ContentHash: hash, Output: output, Body: body}
+		if err := ls.storage.SaveWorkRendering(workResp.Work, rendering); err != nil {
+			ls.log.Errorf("RenderWork: error cache rendering of work %s, err: %v", workID, err)
+		}
+	}
+
+	// the authors get their own works unmarked
+	if isWorkAuthor(workResp, readerAddress) {
+		return rendering.Body, nil
+	}
+
+	if ls.marker == nil {
+		return rendering.Body, nil
+	}
+
+	mark, err := ls.readerMark(readerAddress)
+	if err != nil {
+		return "", err
+	}
+
+	// the rendering which can't be marked isn't served
+	embed := ls.marker.EmbedText
+	if output == render.HTMLOutput {
+		embed = ls.marker.EmbedHTML
+	}
+
+	marked, err := embed(rendering.Body, mark)
+	if err != nil {
+		ls.log.Errorf("RenderWork: error mark rendering of work %s, err: %v", workID, err)
+
+		return "", fmt.Errorf("%w: %v", ErrWatermarkFailed, err)
+	}
+
+	return marked, nil
+}
@@ -0,0 +1,241 @@
+package srv
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+)
+
+const (
+	// the review of this length(in words) gets the full text depth
+	reviewDepthWords = 300
+	// the number of finished reviews giving ~63% of the history component
+	reputationHistoryReviews = 10
+	// the value of a component without any data
+	neutralComponent = 0.5
+
+	minReviewRating = 1
+	maxReviewRating = 5
+)
+
+// weights of the reputation components, the sum is 1
+const (
+	timelinessWeight  = 0.25
+	agreementWeight   = 0.25
+	depthWeight       = 0.2
+	helpfulnessWeight = 0.2
+	historyWeight     = 0.1
+)
+
+// RateWorkReview saves the helpfulness rating of the review given by the work's author or an admin
+func (ls *LibrarySrv) RateWorkReview(ctx context.Context, raterAddress, reviewID string, rating int64) error {
+	if rating < minReviewRating || rating > maxReviewRating {
+		return fmt.Errorf("%w, the rating must be in [%d, %d]", ErrInvalidRating, minReviewRating, maxReviewRating)
+	}
+
+	rater, err := ls.storage.GetParticipantByAddress(raterAddress)
+	if err != nil {
+		ls.log.Errorf("RateWorkReview: error get participant with address %s, err: %v", raterAddress, err)
+
+		return err
+	}
+
+	review, err := ls.storage.GetParticipantsWorkReviewByID(reviewID)
+	if err != nil {
+		ls.log.Errorf("RateWorkReview: error get review %s, err: %v", reviewID, err)
+
+		return err
+	}
+
+	work, err := ls.storage.GetParticipantWorkByID(review.WorkID)
+	if err != nil {
+		ls.log.Errorf("RateWorkReview: error get participant work %s, err: %v", review.WorkID, err)
+
+		return err
+	}
+
+	if work.ParticipantID != rater.ID && rater.Role < storage.AdminRole {
+		return ErrRatingNotAllowed
+	}
+
+	switch review.Status {
+	case storage.WorkReviewSubmitted, storage.WorkReviewRejected, storage.WorkReviewAccepted:
+	default:
+		return ErrReviewNotFinished
+	}
+
+	return ls.storage.RateReview(reviewID, rater.ID, rating)
+}
+
+// UpdateValidatorsReputation recalculates the reputation of all validators
+func (ls *LibrarySrv) UpdateValidatorsReputation() {
+	ctx := context.Background()
+	validators := append(ls.storage.GetParticipantsByRole(storage.ValidatorRole),
+		ls.storage.GetParticipantsByRole(storage.AdminRole)...)
+
+	for _, validator := range validators {
+		reputation, err := ls.CalculateValidatorReputation(ctx, validator.ID)
+		if err != nil {
+			ls.log.Errorf("UpdateValidatorsReputation: error calculate reputation of %s, err: %v", validator.Web3Address, err)
+
+			continue
+		}
+
+		if err := ls.storage.SaveValidatorReputation(reputation); err != nil {
+			ls.log.Errorf("UpdateValidatorsReputation: error save reputation of %s, err: %v", validator.Web3Address, err)
+		}
+	}
+}
+
+// CalculateValidatorReputation scores the validator by timeliness, agreement with the final
+// decisions, depth of the reviews, their helpfulness ratings and the review history.
+func (ls *LibrarySrv) CalculateValidatorReputation(ctx context.Context, validatorID string) (*storage.ValidatorReputation, error) {
+	reviews, err := ls.storage.GetParticipantsWorkReviewsByValidator(validatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		onTime, finished, agreed, decided int
+		depth                             float64
+		reviewIDs                         []string
+	)
+	for _, review := range reviews {
+		if review.Status == storage.WorkReviewInProgress {
+			continue
+		}
+
+		// overdue and reassigned reviews are late
+		if review.Status == storage.WorkReviewOverdue || review.Status == storage.WorkReviewReassigned {
+			finished++
+
+			continue
+		}
+
+		if review.Status == storage.WorkReviewSkipped {
+			continue
+		}
+
+		finished++
+		if review.OverdueAt == nil {
+			onTime++
+		}
+		reviewIDs = append(reviewIDs, review.ID)
+
+		if work, err := ls.storage.GetParticipantWorkByID(review.WorkID); err == nil {
+			switch work.Status {
+			case storage.OpenWorkStatus:
+				decided++
+				if review.Status != storage.WorkReviewRejected {
+					agreed++
+				}
+			case storage.DeclinedWorkStatus:
+				decided++
+				if review.Status == storage.WorkReviewRejected {
+					agreed++
+				}
+			}
+		}
+
+		workReview, err := ls.storage.GetWorkReviewByID(ctx, review.ID)
+		if err != nil {
+			return nil, err
+		}
+		depth += ls.reviewDepth(ctx, workReview)
+	}
+
+	reputation := &storage.ValidatorReputation{
+		ParticipantID: validatorID,
+		Timeliness:    ratio(onTime, finished),
+		Agreement:     ratio(agreed, decided),
+		Depth:         neutralComponent,
+		Helpfulness:   neutralComponent,
+		History:       1 - math.Exp(-float64(len(reviewIDs))/reputationHistoryReviews),
+		Reviews:       int64(len(reviewIDs)),
+		UpdatedAt:     time.Now().UTC(),
+	}
+
+	if len(reviewIDs) > 0 {
+		reputation.Depth = depth / float64(len(reviewIDs))
+
+		helpfulness, err := ls.reviewsHelpfulness(reviewIDs)
+		if err != nil {
+			return nil, err
+		}
+		reputation.Helpfulness = helpfulness
+	}
+
+	reputation.Score = 100 * (timelinessWeight*reputation.Timeliness +
+		agreementWeight*reputation.Agreement +
+		depthWeight*reputation.Depth +
+		helpfulnessWeight*reputation.Helpfulness +
+		historyWeight*reputation.History)
+
+	return reputation, nil
+}
+
+// reviewDepth scores the review(0-1) by the length of its text and the questionnaire coverage
+func (ls *LibrarySrv) reviewDepth(ctx context.Context, review *storage.WorkReview) float64 {
+	if review == nil || review.Body == nil {
+		return 0
+	}
+
+	text := math.Min(float64(len(strings.Fields(review.Body.Review)))/reviewDepthWords, 1)
+
+	var coverage float64
+	if questionnaire := review.Body.Questionnaire; questionnaire != nil {
+		template, err := ls.storage.GetQuestionnaireTemplate(ctx, questionnaire.TemplateID, questionnaire.TemplateVersion)
+		if err == nil && len(template.Questions) > 0 {
+			var answered int
+			for _, question := range template.Questions {
+				if _, ok := questionnaire.Questions[question.ID]; ok {
+					answered++
+				}
+			}
+			coverage = float64(answered) / float64(len(template.Questions))
+		}
+	}
+
+	return 0.6*text + 0.4*coverage
+}
+
+// reviewsHelpfulness returns the average normalized rating of the reviews
+func (ls *LibrarySrv) reviewsHelpfulness(reviewIDs []string) (float64, error) {
+	ratings, err := ls.storage.GetReviewRatings(reviewIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(ratings) == 0 {
+		return neutralComponent, nil
+	}
+
+	var sum float64
+	for _, rating := range ratings {
+		sum += float64(rating.Rating-minReviewRating) / (maxReviewRating - minReviewRating)
+	}
+
+	return sum / float64(len(ratings)), nil
+}
+
+// validatorReputationScore returns the stored reputation score or the neutral one
+func (ls *LibrarySrv) validatorReputationScore(validatorID string) float64 {
+	reputation, err := ls.storage.GetValidatorReputation(validatorID)
+	if err != nil || reputation == nil {
+		return 100 * neutralComponent
+	}
+
+	return reputation.Score
+}
+
+func ratio(part, total int) float64 {
+	if total == 0 {
+		return neutralComponent
+	}
+
+	return float64(part) / float64(total)
+}
@@ -0,0 +1,190 @@
+package srv
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+)
+
+// GetWorkReviewHistory returns all versions of the review to the work's author,
+// the review's validator or an admin, with the version the work was decided with.
+func (ls *LibrarySrv) GetWorkReviewHistory(ctx context.Context, participantAddress, reviewID string) (*storage.WorkReviewHistory, error) {
+	participant, err := ls.storage.GetParticipantByAddress(participantAddress)
+	if err != nil {
+		ls.log.Errorf("GetWorkReviewHistory: error get participant with address %s, err: %v", participantAddress, err)
+
+		return nil, err
+	}
+
+	review, err := ls.storage.GetParticipantsWorkReviewByID(reviewID)
+	if err != nil {
+		ls.log.Errorf("GetWorkReviewHistory: error get review %s, err: %v", reviewID, err)
+
+		return nil, err
+	}
+
+	work, err := ls.storage.GetParticipantWorkByID(review.WorkID)
+	if err != nil {
+		ls.log.Errorf("GetWorkReviewHistory: error get participant work %s, err: %v", review.WorkID, err)
+
+		return nil, err
+	}
+
+	if participant.Role < storage.AdminRole &&
+		participant.ID != work.ParticipantID && participant.ID != review.ParticipantID {
+		return nil, ErrReviewHistoryNotAllowed
+	}
+
+	versions, err := ls.storage.GetWorkReviewVersions(ctx, reviewID)
+	if err != nil {
+		ls.log.Errorf("GetWorkReviewHistory: error get versions of review %s, err: %v", reviewID, err)
+
+		return nil, err
+	}
+
+	history := &storage.WorkReviewHistory{
+		ReviewID:  reviewID,
+		WorkID:    review.WorkID,
+		DecidedAt: work.DecidedAt,
+		Versions:  versions,
+	}
+
+	// the last version saved before the decision
+	if work.DecidedAt != nil {
+		for _, version := range versions {
+			if version.CreatedAt.After(*work.DecidedAt) {
+				break
+			}
+			history.DecisionVersion = version
+		}
+	}
+
+	return history, nil
+}
+
+// DiffWorkReviewVersions compares two versions of the review line by line
+func (ls *LibrarySrv) DiffWorkReviewVersions(ctx context.Context, reviewID string, from, to int64) (*storage.WorkReviewDiff, error) {
+	fromVersion, err := ls.storage.GetWorkReviewVersion(ctx, reviewID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	toVersion, err := ls.storage.GetWorkReviewVersion(ctx, reviewID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var fromText, toText string
+	var fromAnswers, toAnswers map[string]int64
+	if fromVersion.Body != nil {
+		fromText = fromVersion.Body.Review
+		if fromVersion.Body.Questionnaire != nil {
+			fromAnswers = fromVersion.Body.Questionnaire.Questions
+		}
+	}
+	if toVersion.Body != nil {
+		toText = toVersion.Body.Review
+		if toVersion.Body.Questionnaire != nil {
+			toAnswers = toVersion.Body.Questionnaire.Questions
+		}
+	}
+
+	return &storage.WorkReviewDiff{
+		ReviewID:      reviewID,
+		From:          fromVersion,
+		To:            toVersion,
+		StatusChanged: fromVersion.Status != toVersion.Status,
+		Review:        diffLines(splitLines(fromText), splitLines(toText)),
+		Questionnaire: diffAnswers(fromAnswers, toAnswers),
+	}, nil
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
+
+// diffLines builds the line diff from the longest common subsequence of the texts
+func diffLines(from, to []string) []*storage.DiffLine {
+	// lcs[i][j] is the LCS length of from[i:] and to[j:]
+	lcs := make([][]int, len(from)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var (
+		diff []*storage.DiffLine
+		i, j int
+	)
+	for i < len(from) && j < len(to) {
+		switch {
+		case from[i] == to[j]:
+			diff = append(diff, &storage.DiffLine{Op: "=", Text: from[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			diff = append(diff, &storage.DiffLine{Op: "-", Text: from[i]})
+			i++
+		default:
+			diff = append(diff, &storage.DiffLine{Op: "+", Text: to[j]})
+			j++
+		}
+	}
+	for ; i < len(from); i++ {
+		diff = append(diff, &storage.DiffLine{Op: "-", Text: from[i]})
+	}
+	for ; j < len(to); j++ {
+		diff = append(diff, &storage.DiffLine{Op: "+", Text: to[j]})
+	}
+
+	return diff
+}
+
+// diffAnswers returns the changed answers of the questionnaire sorted by the question id
+func diffAnswers(from, to map[string]int64) []*storage.QuestionAnswerDiff {
+	ids := make(map[string]bool)
+	for id := range from {
+		ids[id] = true
+	}
+	for id := range to {
+		ids[id] = true
+	}
+
+	var diff []*storage.QuestionAnswerDiff
+	for id := range ids {
+		fromValue, fromOk := from[id]
+		toValue, toOk := to[id]
+		if fromOk == toOk && fromValue == toValue {
+			continue
+		}
+
+		answer := &storage.QuestionAnswerDiff{QuestionID: id}
+		if fromOk {
+			answer.From = &fromValue
+		}
+		if toOk {
+			answer.To = &toValue
+		}
+		diff = append(diff, answer)
+	}
+
+	sort.Slice(diff, func(i, j int) bool { return diff[i].QuestionID < diff[j].QuestionID })
+
+	return diff
+}
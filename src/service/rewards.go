@@ -0,0 +1,315 @@
+package srv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+	contractor "github.com/SeaOfWisdom/sow_proto/contractor-srv"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// policies of the review rewards
+const (
+	// every review gets the reward amount
+	FlatRewardsPolicy = "flat"
+	// the reward amount is scaled by the questionnaire score of the review
+	PerScoreRewardsPolicy = "per_score"
+	// the reward amount is scaled by the validator's reputation
+	PerReputationRewardsPolicy = "per_reputation"
+)
+
+const (
+	// the deadline of the transfer of the rewards
+	rewardPayoutTimeout = time.Minute
+	// the header of the idempotency key of the transfer
+	idempotencyKeyHeader = "idempotency-key"
+)
+
+// the reviews which are rewarded once the work is decided
+var rewardedReviewStatuses = []storage.WorkReviewStatus{storage.WorkReviewSubmitted, storage.WorkReviewAccepted}
+
+// checkRewardsConfig verifies the rewards policy and the reward amount
+func (ls *LibrarySrv) checkRewardsConfig() error {
+	switch ls.cfg.RewardsPolicy {
+	case FlatRewardsPolicy, PerScoreRewardsPolicy, PerReputationRewardsPolicy:
+	default:
+		return fmt.Errorf("unknown rewards policy %q", ls.cfg.RewardsPolicy)
+	}
+
+	if amount, ok := new(big.Int).SetString(ls.cfg.RewardAmount, 10); !ok || amount.Sign() < 0 {
+		return fmt.Errorf("wrong reward amount %q", ls.cfg.RewardAmount)
+	}
+
+	if ls.cfg.RewardsBatchSize <= 0 {
+		return fmt.Errorf("the rewards batch size must be positive")
+	}
+
+	return nil
+}
+
+// AddReviewRewards writes the rewards of the finished reviews of the decided works in the ledger
+func (ls *LibrarySrv) AddReviewRewards() {
+	if !ls.addRewardsMu.TryLock() {
+		return
+	}
+	defer ls.addRewardsMu.Unlock()
+
+	reviews, err := ls.storage.GetUnrewardedReviews(rewardedReviewStatuses)
+	if err != nil {
+		ls.log.Errorf("AddReviewRewards: error get unrewarded reviews, err: %v", err)
+
+		return
+	}
+
+	ctx := context.Background()
+	for _, review := range reviews {
+		work, err := ls.storage.GetParticipantWorkByID(review.WorkID)
+		if err != nil {
+			ls.log.Errorf("AddReviewRewards: error get participant work %s, err: %v", review.WorkID, err)
+
+			continue
+		}
+
+		// the review is final only when the work is decided
+		if work.Status != storage.OpenWorkStatus && work.Status != storage.DeclinedWorkStatus {
+			continue
+		}
+
+		amount, err := ls.reviewReward(ctx, review)
+		if err != nil {
+			ls.log.Errorf("AddReviewRewards: error calculate reward of review %s, err: %v", review.ID, err)
+
+			continue
+		}
+
+		if err := ls.storage.CreateReward(&storage.RewardLedger{
+			ID:            uuid.New().String(),
+			ParticipantID: review.ParticipantID,
+			ReviewID:      review.ID,
+			WorkID:        review.WorkID,
+			Policy:        ls.cfg.RewardsPolicy,
+			Amount:        amount.String(),
+			Status:        storage.RewardPending,
+		}); err != nil {
+			ls.log.Errorf("AddReviewRewards: error add reward of review %s, err: %v", review.ID, err)
+		}
+	}
+}
+
+// reviewReward calculates the reward(in wei) of the review according to the rewards policy
+func (ls *LibrarySrv) reviewReward(ctx context.Context, review *storage.ParticipantsWorkReview) (*big.Int, error) {
+	amount, ok := new(big.Int).SetString(ls.cfg.RewardAmount, 10)
+	if !ok {
+		return nil, fmt.Errorf("wrong reward amount %q", ls.cfg.RewardAmount)
+	}
+
+	var factor float64
+	switch ls.cfg.RewardsPolicy {
+	case FlatRewardsPolicy:
+		return amount, nil
+
+	case PerScoreRewardsPolicy:
+		workReview, err := ls.storage.GetWorkReviewByID(ctx, review.ID)
+		if err != nil {
+			return nil, err
+		}
+		// the review without a questionnaire gets the neutral score
+		factor = neutralComponent
+		if workReview != nil && workReview.Body != nil && workReview.Body.Questionnaire != nil {
+			questionnaire := workReview.Body.Questionnaire
+			template, err := ls.storage.GetQuestionnaireTemplate(ctx, questionnaire.TemplateID, questionnaire.TemplateVersion)
+			if err == nil {
+				if score, ok := template.Score(questionnaire); ok {
+					factor = score
+				}
+			}
+		}
+
+	case PerReputationRewardsPolicy:
+		factor = ls.validatorReputationScore(review.ParticipantID) / 100
+
+	default:
+		return nil, fmt.Errorf("unknown rewards policy %q", ls.cfg.RewardsPolicy)
+	}
+
+	reward, _ := new(big.Float).Mul(new(big.Float).SetInt(amount), big.NewFloat(factor)).Int(nil)
+
+	return reward, nil
+}
+
+// PayReviewRewards pays out a batch of the unpaid rewards, the rewards of a validator
+// are summed up into a single transfer. The rewards are marked paying before the transfer,
+// so the transfer whose result hasn't been saved is sent again with the same idempotency key
+// instead of paying the rewards once more.
+func (ls *LibrarySrv) PayReviewRewards() {
+	if !ls.payRewardsMu.TryLock() {
+		return
+	}
+	defer ls.payRewardsMu.Unlock()
+
+	paying, err := ls.storage.GetPayingRewards()
+	if err != nil {
+		ls.log.Errorf("PayReviewRewards: error get paying rewards, err: %v", err)
+
+		return
+	}
+
+	for _, payout := range ls.groupRewards(paying, func(reward *storage.RewardLedger) string { return reward.PayoutID }) {
+		ls.payOutRewards(payout)
+	}
+
+	rewards, err := ls.storage.GetUnpaidRewards(ls.cfg.RewardsMaxAttempts, ls.cfg.RewardsBatchSize)
+	if err != nil {
+		ls.log.Errorf("PayReviewRewards: error get unpaid rewards, err: %v", err)
+
+		return
+	}
+
+	for _, payout := range ls.groupRewards(rewards, func(reward *storage.RewardLedger) string { return reward.ParticipantID }) {
+		payout.id = uuid.New().String()
+		started, err := ls.storage.StartRewardsPayout(payout.ids, payout.id)
+		if err != nil {
+			ls.log.Errorf("PayReviewRewards: error start payout of %s, err: %v", payout.participantID, err)
+
+			continue
+		}
+		if started {
+			ls.payOutRewards(payout)
+		}
+	}
+}
+
+// rewardsPayout is the single transfer of the rewards of the validator
+type rewardsPayout struct {
+	id            string
+	participantID string
+	ids           []string
+	amount        *big.Int
+}
+
+// groupRewards sums up the rewards by the key, the key is the participant or the payout
+func (ls *LibrarySrv) groupRewards(rewards []*storage.RewardLedger, key func(*storage.RewardLedger) string) []*rewardsPayout {
+	var (
+		payouts []*rewardsPayout
+		byKey   = make(map[string]*rewardsPayout)
+	)
+	for _, reward := range rewards {
+		amount, ok := new(big.Int).SetString(reward.Amount, 10)
+		if !ok {
+			ls.log.Errorf("PayReviewRewards: wrong amount %q of reward %s", reward.Amount, reward.ID)
+
+			continue
+		}
+
+		payout, ok := byKey[key(reward)]
+		if !ok {
+			payout = &rewardsPayout{id: reward.PayoutID, participantID: reward.ParticipantID, amount: new(big.Int)}
+			byKey[key(reward)] = payout
+			payouts = append(payouts, payout)
+		}
+		payout.amount.Add(payout.amount, amount)
+		payout.ids = append(payout.ids, reward.ID)
+	}
+
+	return payouts
+}
+
+// payOutRewards transfers the rewards marked paying and saves the result
+func (ls *LibrarySrv) payOutRewards(payout *rewardsPayout) {
+	participant := ls.storage.GetParticipantById(payout.participantID)
+	if participant == nil {
+		ls.log.Errorf("PayReviewRewards: participant %s doesn't exist", payout.participantID)
+		if err := ls.storage.UpdateRewardsPayout(payout.ids, storage.RewardFailed, "", "participant doesn't exist"); err != nil {
+			ls.log.Errorf("PayReviewRewards: error update rewards of %s, err: %v", payout.participantID, err)
+		}
+
+		return
+	}
+
+	// nothing to transfer, e.g. the zero reputation
+	if payout.amount.Sign() == 0 {
+		if err := ls.storage.UpdateRewardsPayout(payout.ids, storage.RewardPaid, "", ""); err != nil {
+			ls.log.Errorf("PayReviewRewards: error update rewards of %s, err: %v", participant.Web3Address, err)
+		}
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), rewardPayoutTimeout)
+	defer cancel()
+
+	// the contractor returns the earlier transfer of the same key instead of sending it again
+	ctx = metadata.AppendToOutgoingContext(ctx, idempotencyKeyHeader, payout.id)
+	resp, err := ls.contractorSrv.Faucet(ctx, &contractor.FaucetRequest{
+		Address: participant.Web3Address,
+		Amount:  payout.amount.String(),
+	})
+	switch status.Code(err) {
+	case codes.DeadlineExceeded, codes.Unavailable, codes.Canceled:
+		// the transfer may have been sent, it stays paying and is sent again with the same key
+		ls.log.Warnf("PayReviewRewards: payout %s to %s is unknown, err: %v", payout.id, participant.Web3Address, err)
+
+		return
+	}
+	if err == nil && resp.ErrorMsg != "" {
+		err = errors.New(resp.ErrorMsg)
+	}
+	if err != nil {
+		ls.log.Errorf("PayReviewRewards: error pay out %s to %s, err: %v", payout.amount, participant.Web3Address, err)
+		if err := ls.storage.UpdateRewardsPayout(payout.ids, storage.RewardFailed, "", err.Error()); err != nil {
+			ls.log.Errorf("PayReviewRewards: error update rewards of %s, err: %v", participant.Web3Address, err)
+		}
+
+		return
+	}
+
+	ls.log.Infof("PayReviewRewards: %s was paid out to %s, tx hash: %s", payout.amount, participant.Web3Address, resp.TxHash)
+	if err := ls.storage.UpdateRewardsPayout(payout.ids, storage.RewardPaid, resp.TxHash, ""); err != nil {
+		ls.log.Errorf("PayReviewRewards: error update rewards of %s, err: %v", participant.Web3Address, err)
+	}
+}
+
+// GetRewardsHistory returns the validator's rewards with the paid and pending totals
+func (ls *LibrarySrv) GetRewardsHistory(web3Address string) (*storage.RewardsHistory, error) {
+	participant, err := ls.storage.GetParticipantByAddress(web3Address)
+	if err != nil {
+		ls.log.Errorf("GetRewardsHistory: error get participant with address %s, err: %v", web3Address, err)
+
+		return nil, err
+	}
+
+	rewards, err := ls.storage.GetParticipantRewards(participant.ID)
+	if err != nil {
+		ls.log.Errorf("GetRewardsHistory: error get rewards of %s, err: %v", web3Address, err)
+
+		return nil, err
+	}
+
+	paid, pending := new(big.Int), new(big.Int)
+	for _, reward := range rewards {
+		amount, ok := new(big.Int).SetString(reward.Amount, 10)
+		if !ok {
+			continue
+		}
+
+		if reward.Status == storage.RewardPaid {
+			paid.Add(paid, amount)
+		} else {
+			pending.Add(pending, amount)
+		}
+	}
+
+	return &storage.RewardsHistory{
+		Rewards: rewards,
+		Paid:    paid.String(),
+		Pending: pending.String(),
+	}, nil
+}
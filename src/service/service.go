@@ -5,10 +5,23 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
+	"github.com/SeaOfWisdom/sow_library/src/config"
 	"github.com/SeaOfWisdom/sow_library/src/log"
+	"github.com/SeaOfWisdom/sow_library/src/service/blobstore"
+	"github.com/SeaOfWisdom/sow_library/src/service/crossref"
+	"github.com/SeaOfWisdom/sow_library/src/service/ethrpc"
+	"github.com/SeaOfWisdom/sow_library/src/service/ingest"
+	"github.com/SeaOfWisdom/sow_library/src/service/pid"
+	"github.com/SeaOfWisdom/sow_library/src/service/publisher"
 	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+	"github.com/SeaOfWisdom/sow_library/src/service/watermark"
 	contractor "github.com/SeaOfWisdom/sow_proto/contractor-srv"
+	ocr "github.com/SeaOfWisdom/sow_proto/ocr-srv"
+
+	"github.com/olebedev/emitter"
+	"github.com/robfig/cron/v3"
 )
 
 const (
@@ -17,29 +30,166 @@ const (
 )
 
 var (
-	ErrNoReviews            = errors.New("there are no reviews")
-	ErrValidationNotAllowed = errors.New("validation now allowed")
+	ErrNoReviews                  = errors.New("there are no reviews")
+	ErrValidationNotAllowed       = errors.New("validation now allowed")
+	ErrInvalidQuestionnaire       = errors.New("invalid questionnaire")
+	ErrReviewReassigned           = errors.New("the review has been reassigned to another validator")
+	ErrInvalidRating              = errors.New("invalid review rating")
+	ErrRatingNotAllowed           = errors.New("only the author of the work or an admin can rate the review")
+	ErrReviewNotFinished          = errors.New("the review hasn't been finished yet")
+	ErrReviewHistoryNotAllowed    = errors.New("only the author of the work, the validator or an admin can see the review history")
+	ErrValidatorApplicationExists = errors.New("the validator application already exists")
+	ErrNoValidatorApplication     = errors.New("there is no validator application")
+	ErrWrongApplicationStatus     = errors.New("the action isn't allowed in the current application status")
+	ErrBlobAccessDenied           = errors.New("access to the document is denied")
+	ErrNotAuthor                  = errors.New("the participant isn't an author")
+	ErrNotDraft                   = errors.New("the work isn't a draft")
+	ErrDraftAccessDenied          = errors.New("only the author of the draft can change it")
+	ErrIncompleteDraft            = errors.New("the draft must have the name, the annotation and the content")
+	ErrNoPagesExtracted           = errors.New("none of the pages has been extracted")
+	ErrWorkNotPublished           = errors.New("the work hasn't been published yet")
+	ErrNFTAccessDenied            = errors.New("only the author of the work can opt it in to be minted")
+	ErrNFTNotAllowed              = errors.New("the declined work can't be minted")
+	ErrContentAccessDenied        = errors.New("access to the content of the work is denied")
+	ErrWrongPublicKey             = errors.New("the public key doesn't belong to the participant")
+	ErrNoContent                  = errors.New("the work has no content")
+	ErrNoDOI                      = errors.New("the work has no DOI")
+	ErrDepositsDisabled           = errors.New("the deposit API isn't configured")
+	ErrWrongLicense               = errors.New("wrong license, only the custom license has the text")
+	ErrLicensePaywalled           = errors.New("the work under the CC BY or CC BY-SA license must be open access")
+	ErrOpenAccessWork             = errors.New("the open access work is free to read")
+	ErrServiceStopped             = errors.New("the service is stopping")
+	ErrPlainContent               = errors.New("the content of the work isn't encrypted, it can't be pinned to IPFS")
+	ErrWatermarkFailed            = errors.New("the content can't be marked for the reader, it isn't served unmarked")
 )
 
 type LibrarySrv struct {
+	cfg     *config.Config
 	log     *log.Logger
 	storage *storage.StorageSrv
 	//works   []*storage.WorkResponse
 
+	/* uploaded documents */
+	blobs      blobstore.BlobStore
+	blobLimits *blobstore.Limits
+	ingest     *ingest.Pipeline
+	/* IPFS pinning of the approved works and its verification */
+	publisher publisher.ContentPublisher
+	gateway   *publisher.Gateway
+	chain     *ethrpc.Client
+	/* watermarks of the content served to the readers */
+	marker *watermark.Marker
+	/* persistent identifiers of the open works and the deposits of their metadata */
+	registrar pid.Registrar
+	crossref  *crossref.Client
+
+	/* scheduled jobs */
+	cron         *cron.Cron
+	addRewardsMu sync.Mutex
+	payRewardsMu sync.Mutex
+	publishMu    sync.Mutex
+	/* internal events(notifications) */
+	events *emitter.Emitter
+	/* background extractions of the uploaded papers, they are waited for on the stop */
+	extractions sync.WaitGroup
+	stopMu      sync.Mutex
+	stopCtx     context.Context
+	stop        context.CancelFunc
+
 	contractorSrv contractor.ContractorServiceClient
+	ocrSrv        ocr.OCRClient
 }
 
 // create
 
-func NewLibrarySrv(log *log.Logger, str *storage.StorageSrv, contractorSrv contractor.ContractorServiceClient) *LibrarySrv {
+func NewLibrarySrv(
+	cfg *config.Config,
+	log *log.Logger,
+	str *storage.StorageSrv,
+	blobs blobstore.BlobStore,
+	contentPublisher publisher.ContentPublisher,
+	registrar pid.Registrar,
+	events *emitter.Emitter,
+	contractorSrv contractor.ContractorServiceClient,
+	ocrSrv ocr.OCRClient,
+) *LibrarySrv {
+	gateway, err := publisher.NewGateway(cfg.IPFSGatewayURL)
+	if err != nil {
+		panic(err)
+	}
+
+	// the on-chain publications aren't verified without RPC
+	var chain *ethrpc.Client
+	if cfg.EthRPCURL != "" {
+		if chain, err = ethrpc.NewClient(cfg.EthRPCURL); err != nil {
+			panic(err)
+		}
+	}
+
+	// the deposit XML is only generated on request without the deposit API
+	var crossrefClient *crossref.Client
+	if cfg.CrossrefURL != "" {
+		if crossrefClient, err = crossref.NewClient(cfg.CrossrefURL, cfg.CrossrefLogin, cfg.CrossrefPassword); err != nil {
+			panic(err)
+		}
+	}
+
+	// there is no content to mark without the master key
+	var marker *watermark.Marker
+	if secret := watermarkSecret(cfg); secret != nil {
+		if marker, err = watermark.NewMarker(secret); err != nil {
+			panic(err)
+		}
+	}
+
+	stopCtx, stop := context.WithCancel(context.Background())
+
 	return &LibrarySrv{
+		cfg:           cfg,
 		log:           log,
 		storage:       str,
+		blobs:         blobs,
+		blobLimits:    blobstore.NewLimits(cfg.BlobMaxSize, cfg.BlobAllowedTypes),
+		ingest:        ingest.NewPipeline(ocrRecognizer{ocrSrv}, cfg.IngestWorkers),
+		publisher:     contentPublisher,
+		gateway:       gateway,
+		chain:         chain,
+		registrar:     registrar,
+		crossref:      crossrefClient,
+		marker:        marker,
+		cron:          cron.New(),
+		events:        events,
 		contractorSrv: contractorSrv,
+		ocrSrv:        ocrSrv,
+		stopCtx:       stopCtx,
+		stop:          stop,
 	}
 }
 
 func (ls *LibrarySrv) Start() {
+	if _, err := ls.cron.AddFunc(ls.cfg.ReviewDeadlineCron, ls.CheckReviewDeadlines); err != nil {
+		panic(fmt.Errorf("while scheduling the review deadlines check, err: %v", err))
+	}
+	if _, err := ls.cron.AddFunc(ls.cfg.ReputationCron, ls.UpdateValidatorsReputation); err != nil {
+		panic(fmt.Errorf("while scheduling the reputation recalculation, err: %v", err))
+	}
+	if err := ls.checkRewardsConfig(); err != nil {
+		panic(fmt.Errorf("while checking the rewards config, err: %v", err))
+	}
+	if _, err := ls.cron.AddFunc(ls.cfg.AddRewardsCron, ls.AddReviewRewards); err != nil {
+		panic(fmt.Errorf("while scheduling the review rewards, err: %v", err))
+	}
+	if _, err := ls.cron.AddFunc(ls.cfg.UpdateRewadsCron, ls.PayReviewRewards); err != nil {
+		panic(fmt.Errorf("while scheduling the rewards payout, err: %v", err))
+	}
+	if ls.publisher == nil {
+		ls.log.Warnf("the content publisher isn't configured, the approved works are published on-chain without pinning")
+	}
+	if _, err := ls.cron.AddFunc(ls.cfg.PublishWorksCron, ls.PublishApprovedWorks); err != nil {
+		panic(fmt.Errorf("while scheduling the works publication, err: %v", err))
+	}
+	ls.cron.Start()
+
 	ls.MigrateFromMongo()
 	// // get all works from the library
 	// works, err := lb.GetAllWorks(config.AdminAddresses["chillhacker"])
@@ -190,9 +340,25 @@ func (ls *LibrarySrv) GetValidator(ctx context.Context, web3Address string) (*st
 		return nil, err
 	}
 
+	stats, err := ls.storage.GetValidatorReviewStats(participant.ID)
+	if err != nil {
+		ls.log.Errorf("GetValidator: error get the validator's review stats, err: %v", err)
+
+		return nil, err
+	}
+
+	reputation, err := ls.storage.GetValidatorReputation(participant.ID)
+	if err != nil {
+		ls.log.Errorf("GetValidator: error get the validator's reputation, err: %v", err)
+
+		return nil, err
+	}
+
 	return &storage.ValidatorResponse{
 		BasicInfo:     participant,
 		ValidatorInfo: validator,
+		ReviewStats:   stats,
+		Reputation:    reputation,
 	}, nil
 }
 
@@ -259,6 +425,8 @@ func (ls *LibrarySrv) UpdateAuthorInfo(
 
 /// Validator
 
+// BecomeValidator creates the validator's application, the role is granted once
+// the uploaded documents are verified by an admin.
 func (ls *LibrarySrv) BecomeValidator(ctx context.Context, web3Address, emailAddress, name, surname string) (*storage.Participant, error) {
 	// get the current participant and vefiry his role, status
 	participant, err := ls.storage.GetParticipantByAddress(web3Address)
@@ -268,32 +436,45 @@ func (ls *LibrarySrv) BecomeValidator(ctx context.Context, web3Address, emailAdd
 		return nil, err
 	}
 
-	participant.Role = storage.ValidatorRole
+	if participant.Role >= storage.ValidatorRole {
+		return nil, ErrValidatorApplicationExists
+	}
 
-	if err = ls.storage.UpdateParticipantRole(participant.ID, storage.ValidatorRole); err != nil {
-		ls.log.Errorf("BecomeValidator: error update participant role, err: %v", err)
+	validator, err := ls.storage.GetValidatorById(ctx, participant.ID)
+	if err != nil {
+		ls.log.Errorf("BecomeValidator: error get the validator by id, err: %v", err)
 
-		return nil, fmt.Errorf("while updating the participant's role, err: %s", err)
+		return nil, err
 	}
 
-	// create a new record for the validator
-	if err := ls.storage.CreateValidator(ctx, participant.ID, emailAddress, name, surname); err != nil {
-		ls.log.Errorf("BecomeValidator: error create validator, err: %v", err)
+	if validator == nil {
+		// create a new record for the validator
+		if err := ls.storage.CreateValidator(ctx, participant.ID, emailAddress, name, surname); err != nil {
+			ls.log.Errorf("BecomeValidator: error create validator, err: %v", err)
 
-		return nil, fmt.Errorf("while creating validator, err: %v", err)
-	}
+			return nil, fmt.Errorf("while creating validator, err: %v", err)
+		}
 
-	// let's add the new reviewer into SowLibrary
-	txHash, err := ls.contractorSrv.MakeReviewer(ctx, &contractor.AccountRequest{
-		Address: web3Address,
-	})
-	if err != nil {
-		ls.log.Errorf("BecomeValidator: make reviewer, err: %v", err)
+		return participant, nil
+	}
 
-		return nil, fmt.Errorf("make reviewer, err: %v", err)
+	// only the rejected application can be submitted again
+	if validator.ApplicationStatus != storage.ValidatorRejected {
+		return nil, ErrValidatorApplicationExists
 	}
 
-	ls.log.Infof("the new reviewer was added with tx: %s", txHash)
+	validator.EmailAddress = emailAddress
+	validator.Name = name
+	validator.Surname = surname
+	validator.ApplicationStatus = storage.ValidatorApplied
+	validator.RejectionReason = ""
+	validator.VerifiedBy = ""
+	validator.VerifiedAt = nil
+	if err := ls.storage.UpdateValidatorInfo(ctx, validator); err != nil {
+		ls.log.Errorf("BecomeValidator: error update validator, err: %v", err)
+
+		return nil, err
+	}
 
 	return participant, nil
 }
@@ -356,28 +537,32 @@ func (ls *LibrarySrv) UpdateValidator(
 
 // Publish work
 // 1. save to the storage
-// 2. publish to the IPFS
-// 3. publish fingerPrint to the Library.sol
-// returns: publish txId, error
-func (ls *LibrarySrv) PublishWork(ctx context.Context, authorAddress string, work *storage.Work) (*storage.WorkResponse, string, error) {
+// the work is pinned to the IPFS and published to the Library.sol once it's approved
+func (ls *LibrarySrv) PublishWork(ctx context.Context, authorAddress string, work *storage.Work) (*storage.WorkResponse, error) {
 	// check for the existence of the participant
 	participant, err := ls.storage.GetParticipantByAddress(authorAddress)
 	if err != nil {
 		ls.log.Errorf("PublishWork: error get participant with address %s, err: %v", authorAddress, err)
 
-		return nil, "", err
+		return nil, err
 	}
 
 	if participant.Role < storage.AuthorRole {
-		return nil, "", fmt.Errorf("the participant nether author or validator")
+		return nil, fmt.Errorf("the participant nether author or validator")
+	}
+
+	if err := ls.checkLicense(work); err != nil {
+		return nil, err
 	}
 
+	ls.setWorkScience(ctx, participant.ID, work)
+
 	// create work in Mongo and PostgreSQL databases
 	workID, err := ls.storage.CreateWork(ctx, participant.ID, work)
 	if err != nil {
 		ls.log.Errorf("PublishWork: error create work, err: %v", err)
 
-		return nil, "", fmt.Errorf("while creating a new work, err: %v", err)
+		return nil, fmt.Errorf("while creating a new work, err: %v", err)
 	}
 
 	fmt.Println("CREATED WORK ID: ", workID)
@@ -386,21 +571,10 @@ func (ls *LibrarySrv) PublishWork(ctx context.Context, authorAddress string, wor
 	if err != nil {
 		ls.log.Errorf("PublishWork: error get work by id %s, err: %v", workID, err)
 
-		return nil, "", err
-	}
-
-	txHash, err := ls.contractorSrv.PublishWork(ctx, &contractor.PublishWorkRequest{
-		Authors: []string{authorAddress},
-		Name:    work.Name,
-		Uri:     "DUMMY URI",
-		WorkId:  uuidToUint256(workResp.Work.ID),
-		Price:   faucetCount,
-	})
-	if err != nil {
-		ls.log.Errorf("PublishWork: publish work via contractor, err: %v", err)
+		return nil, err
 	}
 
-	return workResp, txHash.TxHash, nil
+	return workResp, nil
 }
 
 // PurchaseWork ...
@@ -420,6 +594,15 @@ func (ls *LibrarySrv) PurchaseWork(ctx context.Context, readerAddress, workID st
 		return fmt.Errorf("haven't got the work with id: %s", workID)
 	}
 
+	if work == nil || work.Work.Status == storage.DraftWorkStatus {
+		return fmt.Errorf("haven't got the work with id: %s", workID)
+	}
+
+	// the purchase made on the contract is recorded anyway
+	if work.Work.OpenAccess && !contract {
+		return ErrOpenAccessWork
+	}
+
 	// check if he has already purchased the work
 	if ls.storage.PurchasedWorkOrNot(participant.ID, workID) {
 		return fmt.Errorf("you have already purchased this work")
@@ -465,7 +648,6 @@ func (ls *LibrarySrv) PurchasedWorks(ctx context.Context, readerAddress string)
 
 		return nil, err
 	}
-
 	return works, nil
 }
 
@@ -489,7 +671,6 @@ func (ls *LibrarySrv) GetAllWorks(ctx context.Context, readerAddress string) ([]
 
 		return nil, err
 	}
-
 	return works, nil
 }
 
@@ -501,7 +682,6 @@ func (ls *LibrarySrv) GetWorksByKeyWords(ctx context.Context, readerAddress stri
 
 		return nil, err
 	}
-
 	return works, nil
 }
 
@@ -514,9 +694,51 @@ func (ls *LibrarySrv) GetWorkByID(ctx context.Context, authorAddress, workID str
 		return nil, err
 	}
 
+	// the draft is shown to its author only
+	if work != nil && work.Work.Status == storage.DraftWorkStatus && !isWorkAuthor(work, authorAddress) {
+		return nil, nil
+	}
+
+	if work == nil {
+		return nil, nil
+	}
+
+	if !ls.hasContentAccess(authorAddress, workID) {
+		work.Work.Content = nil
+	}
+	ls.storage.SetWorksCitations([]*storage.WorkResponse{work})
+	if err := ls.watermarkWork(authorAddress, work); err != nil {
+		return nil, err
+	}
+
 	return work, nil
 }
 
+// isWorkAuthor checks the participant is the author who has submitted the work, the addresses
+// are compared case-insensitively as the checksummed ones are mixed-case
+func isWorkAuthor(workResp *storage.WorkResponse, address string) bool {
+	return workResp.Author != nil && workResp.Author.BasicInfo != nil &&
+		strings.EqualFold(workResp.Author.BasicInfo.Web3Address, address)
+}
+
+// hasContentAccess checks the participant is allowed to read the content of the work
+func (ls *LibrarySrv) hasContentAccess(participantAddress, workID string) bool {
+	participantsWork, err := ls.storage.GetParticipantWorkByID(workID)
+	if err != nil {
+		return false
+	}
+
+	participant, err := ls.storage.GetParticipantByAddress(participantAddress)
+	if err != nil {
+		participant = nil
+	}
+
+	purchased := participant != nil && ls.storage.PurchasedWorkOrNot(participant.ID, workID)
+	_, content := participantsWork.IsShow(participant, purchased)
+
+	return content
+}
+
 func (ls *LibrarySrv) GetWorksByAuthorAddress(ctx context.Context, readerAddress, authorAddress string) ([]*storage.WorkResponse, error) {
 	// check for the existence of the participant
 	works, err := ls.storage.GetWorksByAuthorAddress(ctx, readerAddress, authorAddress)
@@ -525,38 +747,18 @@ func (ls *LibrarySrv) GetWorksByAuthorAddress(ctx context.Context, readerAddress
 
 		return nil, err
 	}
-
 	return works, nil
 }
 
-// Publish work
-// 1. save to the storage
-// 2. publish to the IPFS
-// 3. publish fingerPrint to the Library.sol
-// returns: publish txId, error
+// ApproveWork sends the pending work to the review, the work is pinned to the IPFS
+// and published to the Library.sol once the review approves it
 func (ls *LibrarySrv) ApproveWork(ctx context.Context, workID string) error {
 	// check for the existence of the participant
 	if err := ls.storage.ApproveWork(ctx, workID); err != nil {
 		return fmt.Errorf("haven't got the participant with address %s", err)
 	}
 
-	// TODO update status in the Library.sol smart-contract
-
-	// // publish to the IPFS
-	// id := pinata.PublishJson(work)
-	// fmt.Println("ID in IPFS: ", id)
-	// publish fingerPrint to the Library.sol
-	// author
-	// id
 	return nil
-
-	// // publish to the IPFS
-	// id := pinata.PublishJson(work)
-	// fmt.Println("ID in IPFS: ", id)
-	// // publish fingerPrint to the Library.sol
-	// // author
-	// // id
-	// return id, "TX_ID", nil
 }
 
 func (ls *LibrarySrv) RemoveWork(ctx context.Context, workID string) error {
@@ -672,7 +874,24 @@ func (ls *LibrarySrv) CreateOrUpdateWorkReview(ctx context.Context, validatorAdd
 		return nil, ErrValidationNotAllowed
 	}
 
-	review, updateRrr := ls.storage.UpdateOrCreateWorkReview(ctx, participant.ID, review)
+	if review.Body != nil && review.Body.Questionnaire != nil {
+		if err = ls.checkQuestionnaire(ctx, work.Work, review.Body.Questionnaire, false); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidQuestionnaire, err)
+		}
+	}
+
+	participantsReview, err := ls.storage.FindParticipantsWorkReviewByValidator(participant.ID, review.WorkID)
+	if err != nil {
+		ls.log.Errorf("CreateOrUpdateWorkReview: error find participant's review, err: %v", err)
+
+		return nil, err
+	}
+
+	if participantsReview != nil && participantsReview.Status == storage.WorkReviewReassigned {
+		return nil, ErrReviewReassigned
+	}
+
+	review, updateRrr := ls.storage.UpdateOrCreateWorkReview(ctx, participant.ID, review, ls.reviewDueAt())
 	if updateRrr != nil {
 		ls.log.Errorf("CreateOrUpdateWorkReview: error update or create work review, err: %v", err)
 
@@ -764,6 +983,21 @@ func (ls *LibrarySrv) SubmitWorkReview(ctx context.Context, validatorAddress, wo
 		}
 	}
 
+	if participantsReview == nil {
+		return ErrNoReviews
+	}
+
+	if participantsReview.Status == storage.WorkReviewReassigned {
+		return ErrReviewReassigned
+	}
+
+	// a verdict must contain the complete questionnaire
+	if status == storage.WorkReviewSubmitted || status == storage.WorkReviewRejected {
+		if err = ls.checkSubmittedQuestionnaire(ctx, participantsReview); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidQuestionnaire, err)
+		}
+	}
+
 	participantsReview.Status = status
 
 	if err = ls.storage.SubmitWorkReview(ctx, participantsReview); err != nil {
@@ -782,6 +1016,17 @@ func (ls *LibrarySrv) SubmitWorkReview(ctx context.Context, validatorAddress, wo
 				return err
 			}
 
+			// failed publication is retried by the cron
+			if err := ls.publishApprovedWork(ctx, workID); err != nil {
+				ls.log.Errorf("SubmitWorkReview: error publish approved work with id %s, err: %v", workID, err)
+			}
+			if err := ls.linkWorkNFT(ctx, workID); err != nil {
+				ls.log.Errorf("SubmitWorkReview: error link NFT of approved work with id %s, err: %v", workID, err)
+			}
+			if err := ls.identifyWork(ctx, workID); err != nil {
+				ls.log.Errorf("SubmitWorkReview: error mint PID of approved work with id %s, err: %v", workID, err)
+			}
+
 		case storage.WorkReviewRejected, storage.WorkReviewSkipped:
 			declinedErr := ls.storage.DeclineWork(ctx, workID)
 			if declinedErr != nil {
@@ -812,4 +1057,12 @@ func buildWorkResponse(inputWork storage.WorkResponse) (work storage.WorkRespons
 	return storage.WorkResponse{}
 }
 
-func (ls *LibrarySrv) Stop() {}
+func (ls *LibrarySrv) Stop() {
+	<-ls.cron.Stop().Done()
+
+	// the running extractions are cancelled and saved as failed
+	ls.stopMu.Lock()
+	ls.stop()
+	ls.stopMu.Unlock()
+	ls.extractions.Wait()
+}
@@ -0,0 +1,35 @@
+package srv
+
+import (
+	"testing"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+)
+
+func TestIsWorkAuthor(t *testing.T) {
+	work := &storage.WorkResponse{
+		Work:   &storage.Work{ID: "work"},
+		Author: &storage.AuthorResponse{BasicInfo: &storage.Participant{Web3Address: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"}},
+	}
+
+	tests := []struct {
+		address string
+		want    bool
+	}{
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", true},
+		{"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", true},
+		{"0x5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED", true},
+		{"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", false},
+		{"", false},
+	}
+
+	for _, test := range tests {
+		if got := isWorkAuthor(work, test.address); got != test.want {
+			t.Errorf("isWorkAuthor(%q) = %v, want %v", test.address, got, test.want)
+		}
+	}
+
+	if isWorkAuthor(&storage.WorkResponse{Work: work.Work}, "") {
+		t.Error("the work without the author has the author")
+	}
+}
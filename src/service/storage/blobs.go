@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+func (ss *StorageSrv) CreateBlobRecord(record *BlobRecord) error {
+	return ss.psqlDB.Create(record).Error
+}
+
+func (ss *StorageSrv) GetBlobRecord(id string) (*BlobRecord, error) {
+	record := new(BlobRecord)
+	if err := ss.psqlDB.Where("id = ?", id).First(record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBlobNotExists
+		}
+
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// BlobKeyRecorded reports whether any record refers to the blob with the key
+func (ss *StorageSrv) BlobKeyRecorded(key string) (bool, error) {
+	var count int64
+	if err := ss.psqlDB.Model(BlobRecord{}).Where("key = ?", key).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// LinkBlobsToDiploma sets the diploma of the blobs uploaded with it
+func (ss *StorageSrv) LinkBlobsToDiploma(ids []string, diplomaID string) error {
+	return ss.psqlDB.Model(BlobRecord{}).Where("id IN ?", ids).
+		Update("diploma_id", diplomaID).Error
+}
+
+func (ss *StorageSrv) GetWorkBlobRecords(workID string) (records []*BlobRecord, err error) {
+	if err := ss.psqlDB.Where("work_id = ?", workID).Order("created_at").Find(&records).Error; err != nil {
+		return nil, err
+	}
+	return
+}
@@ -37,6 +37,7 @@ func (ss *StorageSrv) GetBookmarksByParticipantID(ctx context.Context, participa
 		// other preview information of work
 		bookmarks = append(bookmarks, ss.buildWorkResponse(mWork, author, participant, ss.PurchasedWorkOrNot(participantID, mWork.ID), true))
 	}
+	ss.SetWorksCitations(bookmarks)
 
 	return bookmarks, nil
 }
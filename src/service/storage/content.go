@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/envelope"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// sealContent encrypts the content by the data key of the work, the key is
+// generated for the work which hasn't got it yet. The content isn't stored
+// in plain text, so it can't be stored without the master key.
+func (ss *StorageSrv) sealContent(work *Work) (*WorkContent, error) {
+	if work.Content == nil {
+		return nil, nil
+	}
+
+	if ss.keyring == nil {
+		if work.Content.WorkData != "" {
+			return nil, ErrNoMasterKey
+		}
+
+		return work.Content, nil
+	}
+
+	var (
+		key []byte
+		err error
+	)
+	if len(work.ContentKey) == 0 {
+		key, work.ContentKey, err = ss.keyring.NewDataKey()
+	} else {
+		key, err = ss.keyring.Unwrap(work.ContentKey)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cipher, err := envelope.Seal(key, []byte(work.Content.WorkData))
+	if err != nil {
+		return nil, err
+	}
+
+	return &WorkContent{Format: work.Content.Format, Cipher: cipher}, nil
+}
+
+// openContent decrypts the content of the work, the ciphertext is kept
+func (ss *StorageSrv) openContent(work *Work) error {
+	if work.Content == nil || len(work.Content.Cipher) == 0 {
+		return nil
+	}
+
+	key, err := ss.WorkDataKey(work)
+	if err != nil {
+		return err
+	}
+
+	data, err := envelope.Open(key, work.Content.Cipher)
+	if err != nil {
+		return err
+	}
+	work.Content.WorkData = string(data)
+
+	return nil
+}
+
+// WorkDataKey returns the data key of the encrypted content of the work
+func (ss *StorageSrv) WorkDataKey(work *Work) ([]byte, error) {
+	if len(work.ContentKey) == 0 {
+		return nil, ErrContentNotEncrypted
+	}
+
+	if ss.keyring == nil {
+		return nil, ErrNoMasterKey
+	}
+
+	return ss.keyring.Unwrap(work.ContentKey)
+}
+
+// encryptPlainWorks encrypts the content of the works stored before the master key was configured
+func (ss *StorageSrv) encryptPlainWorks(ctx context.Context) error {
+	if ss.keyring == nil {
+		return nil
+	}
+
+	collection := ss.mongoDB.Collection(collectionWorks)
+	if collection == nil {
+		panic(fmt.Errorf("works collection is nil"))
+	}
+
+	cur, err := collection.Find(ctx, bson.M{
+		"content.workdata": bson.M{"$exists": true, "$ne": ""},
+		"content.cipher":   bson.M{"$exists": false},
+	})
+	if err != nil {
+		return err
+	}
+
+	var works []*Work
+	if err := cur.All(ctx, &works); err != nil {
+		return err
+	}
+
+	// the work failed to be encrypted is left in plain text until the next start
+	encrypted := 0
+	for _, work := range works {
+		content, err := ss.sealContent(work)
+		if err != nil {
+			ss.log.Errorf("encryptPlainWorks: error encrypt the content of work %s, err: %v", work.ID, err)
+
+			continue
+		}
+
+		// the published works are published again with the encrypted content
+		if err := ss.updateWorkFields(ctx, work.ID, bson.M{
+			"content":     content,
+			"content_key": work.ContentKey,
+			"updated_at":  time.Now().UTC(),
+		}); err != nil {
+			ss.log.Errorf("encryptPlainWorks: error save the encrypted content of work %s, err: %v", work.ID, err)
+
+			continue
+		}
+		encrypted++
+	}
+
+	if len(works) > 0 {
+		ss.log.Infof("the content of %d of %d works has been encrypted", encrypted, len(works))
+	}
+
+	return nil
+}
+
+// hasWorksContent reports whether any work has the content, plain or encrypted
+func (ss *StorageSrv) hasWorksContent(ctx context.Context) (bool, error) {
+	collection := ss.mongoDB.Collection(collectionWorks)
+	if collection == nil {
+		panic(fmt.Errorf("works collection is nil"))
+	}
+
+	count, err := collection.CountDocuments(ctx, bson.M{"$or": bson.A{
+		bson.M{"content.workdata": bson.M{"$exists": true, "$ne": ""}},
+		bson.M{"content.cipher": bson.M{"$exists": true}},
+	}}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateWorkDeposits saves the pending deposits of the batch of the identified works
+func (ss *StorageSrv) CreateWorkDeposits(batchID string, persistentIDs []*PersistentID) ([]*WorkDeposit, error) {
+	deposits := make([]*WorkDeposit, 0, len(persistentIDs))
+	for _, persistentID := range persistentIDs {
+		deposits = append(deposits, &WorkDeposit{
+			ID:      uuid.New().String(),
+			BatchID: batchID,
+			WorkID:  persistentID.WorkID,
+			PID:     persistentID.PID,
+			Status:  DepositPending,
+		})
+	}
+
+	if err := ss.psqlDB.Create(&deposits).Error; err != nil {
+		return nil, err
+	}
+
+	return deposits, nil
+}
+
+// GetBatchDeposits returns the deposits of the works of the batch
+func (ss *StorageSrv) GetBatchDeposits(batchID string) (deposits []*WorkDeposit, err error) {
+	err = ss.psqlDB.Where("batch_id = ?", batchID).Order("created_at, pid").Find(&deposits).Error
+	return
+}
+
+// GetDepositBatchIDs returns the batches having the deposits in the status, the oldest first
+func (ss *StorageSrv) GetDepositBatchIDs(status DepositStatus) (batchIDs []string, err error) {
+	err = ss.psqlDB.Model(WorkDeposit{}).Where("status = ?", status).
+		Group("batch_id").Order("MIN(created_at)").Pluck("batch_id", &batchIDs).Error
+	return
+}
+
+// SetBatchDepositStatus updates the pending and the submitted deposits of the batch, the submitted
+// deposits keep the time of the submission
+func (ss *StorageSrv) SetBatchDepositStatus(batchID string, status DepositStatus, message string) error {
+	fields := map[string]interface{}{"status": status, "message": message, "updated_at": time.Now().UTC()}
+	if status == DepositSubmitted {
+		fields["submitted_at"] = time.Now().UTC()
+	}
+
+	return ss.psqlDB.Model(WorkDeposit{}).
+		Where("batch_id = ? AND status IN ?", batchID, []DepositStatus{DepositPending, DepositSubmitted}).
+		Updates(fields).Error
+}
+
+// SetWorkDepositStatus saves the result of the deposit of the identifier in the batch
+func (ss *StorageSrv) SetWorkDepositStatus(batchID, pid string, status DepositStatus, message string) error {
+	return ss.psqlDB.Model(WorkDeposit{}).Where("batch_id = ? AND pid = ?", batchID, pid).
+		Updates(map[string]interface{}{"status": status, "message": message, "updated_at": time.Now().UTC()}).Error
+}
+
+// GetWorkDeposits returns the deposits of the work and(or) in the status, the latest first,
+// the empty filters don't limit the deposits
+func (ss *StorageSrv) GetWorkDeposits(workID string, status DepositStatus) (deposits []*WorkDeposit, err error) {
+	query := ss.psqlDB.Model(WorkDeposit{})
+	if workID != "" {
+		query = query.Where("work_id = ?", workID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	err = query.Order("created_at DESC").Find(&deposits).Error
+	return
+}
+
+// GetUndepositedPersistentIDs returns the identifiers of the works which have never been deposited
+// and the ones whose last deposit failed before the time, the oldest first
+func (ss *StorageSrv) GetUndepositedPersistentIDs(limit int, failedBefore time.Time) (ids []*PersistentID, err error) {
+	lastDeposits := ss.psqlDB.Model(WorkDeposit{}).
+		Select("DISTINCT ON (work_id) work_id, status, updated_at").Order("work_id, created_at DESC")
+	deposited := ss.psqlDB.Table("(?) AS last_deposits", lastDeposits).
+		Where("status <> ? OR updated_at >= ?", DepositFailed, failedBefore).Select("work_id")
+
+	err = ss.psqlDB.Where("work_id NOT IN (?)", deposited).
+		Order("created_at").Limit(limit).Find(&ids).Error
+	return
+}
@@ -0,0 +1,151 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/envelope"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"gorm.io/gorm"
+)
+
+// CreateDraftWork creates the work which is visible to its author only until it is submitted
+func (ss *StorageSrv) CreateDraftWork(ctx context.Context, authorID string, work *Work) (string, error) {
+	work.AuthorID = authorID
+	workID, err := ss.putWork(ctx, work, DraftWorkStatus)
+	if err != nil {
+		return "", err
+	}
+
+	if err := ss.createWorkOfParticipant(authorID, workID, DraftWorkStatus, work.OpenAccess); err != nil {
+		return "", err
+	}
+
+	return workID, nil
+}
+
+// SubmitDraftWork sends the draft of the science to the review
+func (ss *StorageSrv) SubmitDraftWork(ctx context.Context, workID, science string) error {
+	if err := ss.updateWorkFields(ctx, workID, bson.M{
+		"science":    science,
+		"status":     ReviewWorkStatus,
+		"updated_at": time.Now().UTC(),
+	}); err != nil {
+		return err
+	}
+
+	return ss.updatewWorkStatus(workID, ReviewWorkStatus)
+}
+
+// LinkBlobToWork sets the work of the blob uploaded before the work was created
+func (ss *StorageSrv) LinkBlobToWork(id, workID string) error {
+	return ss.psqlDB.Model(BlobRecord{}).Where("id = ?", id).
+		Update("work_id", workID).Error
+}
+
+func (ss *StorageSrv) CreateExtraction(extraction *DocumentExtraction) error {
+	extraction.UpdatedAt = time.Now().UTC()
+	return ss.psqlDB.Create(extraction).Error
+}
+
+func (ss *StorageSrv) GetExtraction(id string) (*DocumentExtraction, error) {
+	extraction := new(DocumentExtraction)
+	if err := ss.psqlDB.Where("id = ?", id).First(extraction).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrExtractionNotExists
+		}
+
+		return nil, err
+	}
+
+	if err := ss.psqlDB.Where("extraction_id = ?", id).Order("number").
+		Find(&extraction.Pages).Error; err != nil {
+		return nil, err
+	}
+
+	return extraction, nil
+}
+
+// UpdateExtraction saves the status, the error and the created work of the extraction
+func (ss *StorageSrv) UpdateExtraction(extraction *DocumentExtraction) error {
+	extraction.UpdatedAt = time.Now().UTC()
+	return ss.psqlDB.Model(DocumentExtraction{}).Where("id = ?", extraction.ID).
+		Updates(map[string]interface{}{
+			"status":          extraction.Status,
+			"error":           extraction.Error,
+			"work_id":         extraction.WorkID,
+			"total_pages":     extraction.TotalPages,
+			"processed_pages": extraction.ProcessedPages,
+			"failed_pages":    extraction.FailedPages,
+			"updated_at":      extraction.UpdatedAt,
+		}).Error
+}
+
+// SetExtractionText saves the text recognized from the paper sealed by the data key of the draft work
+func (ss *StorageSrv) SetExtractionText(id string, work *Work, text string) error {
+	key, err := ss.WorkDataKey(work)
+	if err != nil {
+		return err
+	}
+
+	cipher, err := envelope.Seal(key, []byte(text))
+	if err != nil {
+		return err
+	}
+
+	return ss.psqlDB.Model(DocumentExtraction{}).Where("id = ?", id).
+		Update("text_cipher", cipher).Error
+}
+
+// GetExtractionText returns the text recognized from the paper the work was created from,
+// it's empty if the work hasn't been created from the uploaded paper
+func (ss *StorageSrv) GetExtractionText(work *Work) (string, error) {
+	var extractions []*DocumentExtraction
+	if err := ss.psqlDB.Where("work_id = ? AND text_cipher IS NOT NULL", work.ID).
+		Order("created_at DESC").Limit(1).Find(&extractions).Error; err != nil {
+		return "", err
+	}
+
+	if len(extractions) == 0 {
+		return "", nil
+	}
+
+	key, err := ss.WorkDataKey(work)
+	if err != nil {
+		return "", err
+	}
+
+	text, err := envelope.Open(key, extractions[0].TextCipher)
+	if err != nil {
+		return "", err
+	}
+
+	return string(text), nil
+}
+
+// CreateExtractionPages creates the pending pages of the extraction
+func (ss *StorageSrv) CreateExtractionPages(extractionID string, total int) error {
+	pages := make([]*ExtractionPage, 0, total)
+	for number := 1; number <= total; number++ {
+		pages = append(pages, &ExtractionPage{
+			ID:           uuid.New().String(),
+			ExtractionID: extractionID,
+			Number:       number,
+			Status:       ExtractionPending,
+		})
+	}
+
+	return ss.psqlDB.Create(&pages).Error
+}
+
+func (ss *StorageSrv) UpdateExtractionPage(extractionID string, page *ExtractionPage) error {
+	return ss.psqlDB.Model(ExtractionPage{}).
+		Where("extraction_id = ? AND number = ?", extractionID, page.Number).
+		Updates(map[string]interface{}{
+			"status": page.Status,
+			"source": page.Source,
+			"error":  page.Error,
+		}).Error
+}
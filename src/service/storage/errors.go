@@ -7,4 +7,16 @@ var (
 	ErrParticipantAlreadyExists = errors.New("participant already exists")
 	ErrSomethingWentWrong       = errors.New("something went wrong")
 	ErrWorkNotExists            = errors.New("work does not exist")
+	ErrTemplateNotExists        = errors.New("questionnaire template does not exist")
+	ErrReviewNotExists          = errors.New("review does not exist")
+	ErrReviewVersionNotExists   = errors.New("review version does not exist")
+	ErrDiplomaNotExists         = errors.New("diploma does not exist")
+	ErrBlobNotExists            = errors.New("blob does not exist")
+	ErrExtractionNotExists      = errors.New("extraction does not exist")
+	ErrWorkRevisionNotExists    = errors.New("work revision does not exist")
+	ErrNFTNotExists             = errors.New("NFT does not exist")
+	ErrPIDNotExists             = errors.New("persistent identifier does not exist")
+	ErrContentNotEncrypted      = errors.New("the content of the work isn't encrypted")
+	ErrNoMasterKey              = errors.New("the master key of the content isn't configured")
+	ErrNotificationNotExists    = errors.New("notification does not exist")
 )
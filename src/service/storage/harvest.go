@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gorm.io/gorm"
+)
+
+// the fields of the subjects the works are harvested by
+const (
+	ScienceField  = "science"
+	TagsField     = "tags"
+	LanguageField = "language"
+)
+
+// WorkStamp is the open work with the datestamp of its opening
+type WorkStamp struct {
+	WorkID    string
+	Datestamp time.Time
+}
+
+// HarvestFilter selects the open works by their datestamps, the zero bounds are open
+type HarvestFilter struct {
+	From time.Time
+	// Until is exclusive
+	Until time.Time
+	// WorkIDs limits the works, nil means any work
+	WorkIDs []string
+	// the list continues after the work
+	AfterStamp time.Time
+	AfterID    string
+}
+
+// the datestamp of the open work is the time of the decision on it,
+// the works opened before the decision time was saved are stamped by their creation
+const openStampColumn = "COALESCE(decided_at, created_at)"
+
+func (ss *StorageSrv) openWorkStamps(filter *HarvestFilter) *gorm.DB {
+	query := ss.psqlDB.Model(ParticipantsWork{}).Where("status = ?", OpenWorkStatus)
+	if !filter.From.IsZero() {
+		query = query.Where(openStampColumn+" >= ?", filter.From)
+	}
+	if !filter.Until.IsZero() {
+		query = query.Where(openStampColumn+" < ?", filter.Until)
+	}
+	if filter.WorkIDs != nil {
+		query = query.Where("work_id IN ?", filter.WorkIDs)
+	}
+	return query
+}
+
+// GetOpenWorkStamps returns the part of the open works ordered by the datestamps and the ids,
+// the total is the number of the works selected by the filter regardless of the part
+func (ss *StorageSrv) GetOpenWorkStamps(filter *HarvestFilter, limit int) (stamps []*WorkStamp, total int64, err error) {
+	if filter.WorkIDs != nil && len(filter.WorkIDs) == 0 {
+		return nil, 0, nil
+	}
+
+	if err = ss.openWorkStamps(filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query := ss.openWorkStamps(filter)
+	if filter.AfterID != "" {
+		query = query.Where("("+openStampColumn+", work_id) > (?, ?)", filter.AfterStamp, filter.AfterID)
+	}
+	err = query.Select("work_id, " + openStampColumn + " AS datestamp").
+		Order("datestamp, work_id").Limit(limit).Scan(&stamps).Error
+
+	return
+}
+
+// GetLatestOpenWorkStamps returns the last opened works, the latest first
+func (ss *StorageSrv) GetLatestOpenWorkStamps(filter *HarvestFilter, limit int) (stamps []*WorkStamp, err error) {
+	if filter.WorkIDs != nil && len(filter.WorkIDs) == 0 {
+		return nil, nil
+	}
+
+	err = ss.openWorkStamps(filter).Select("work_id, " + openStampColumn + " AS datestamp").
+		Order("datestamp DESC, work_id DESC").Limit(limit).Scan(&stamps).Error
+
+	return
+}
+
+// GetOpenWorkStamp returns the datestamp of the open work
+func (ss *StorageSrv) GetOpenWorkStamp(workID string) (*WorkStamp, error) {
+	var stamps []*WorkStamp
+	if err := ss.openWorkStamps(&HarvestFilter{WorkIDs: []string{workID}}).
+		Select("work_id, " + openStampColumn + " AS datestamp").Scan(&stamps).Error; err != nil {
+		return nil, err
+	}
+
+	if len(stamps) == 0 {
+		return nil, ErrWorkNotExists
+	}
+
+	return stamps[0], nil
+}
+
+// GetWorkIDsBySubject returns the works of the science, the tag or the language, the empty value selects the works having any
+func (ss *StorageSrv) GetWorkIDsBySubject(ctx context.Context, field, value string) ([]string, error) {
+	filter := bson.M{field: value}
+	if value == "" {
+		filter = bson.M{field: bson.M{"$gt": ""}}
+	}
+
+	collection := ss.mongoDB.Collection(collectionWorks)
+	if collection == nil {
+		panic(fmt.Errorf("works collection is nil"))
+	}
+
+	cur, err := collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"id": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	var works []*Work
+	if err := cur.All(ctx, &works); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(works))
+	for _, work := range works {
+		ids = append(ids, work.ID)
+	}
+
+	return ids, nil
+}
+
+// GetWorksSubjects returns the works with their science and tags only by their ids
+func (ss *StorageSrv) GetWorksSubjects(ctx context.Context, workIDs []string) (map[string]*Work, error) {
+	works := make(map[string]*Work, len(workIDs))
+	if len(workIDs) == 0 {
+		return works, nil
+	}
+
+	collection := ss.mongoDB.Collection(collectionWorks)
+	if collection == nil {
+		panic(fmt.Errorf("works collection is nil"))
+	}
+
+	cur, err := collection.Find(ctx, bson.M{"id": bson.M{"$in": workIDs}},
+		options.Find().SetProjection(bson.M{"id": 1, ScienceField: 1, TagsField: 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	var found []*Work
+	if err := cur.All(ctx, &found); err != nil {
+		return nil, err
+	}
+
+	for _, work := range found {
+		works[work.ID] = work
+	}
+
+	return works, nil
+}
+
+// GetOpenWorkSubjects returns the sorted sciences and tags of the open works
+func (ss *StorageSrv) GetOpenWorkSubjects(ctx context.Context) (sciences, tags []string, err error) {
+	var openIDs []string
+	if err := ss.psqlDB.Model(ParticipantsWork{}).Where("status = ?", OpenWorkStatus).
+		Pluck("work_id", &openIDs).Error; err != nil {
+		return nil, nil, err
+	}
+
+	if len(openIDs) == 0 {
+		return nil, nil, nil
+	}
+
+	collection := ss.mongoDB.Collection(collectionWorks)
+	if collection == nil {
+		panic(fmt.Errorf("works collection is nil"))
+	}
+
+	distinct := func(field string) ([]string, error) {
+		values, err := collection.Distinct(ctx, field, bson.M{"id": bson.M{"$in": openIDs}})
+		if err != nil {
+			return nil, err
+		}
+
+		var subjects []string
+		for _, value := range values {
+			if subject, ok := value.(string); ok && subject != "" {
+				subjects = append(subjects, subject)
+			}
+		}
+		sort.Strings(subjects)
+
+		return subjects, nil
+	}
+
+	if sciences, err = distinct(ScienceField); err != nil {
+		return nil, nil, err
+	}
+	if tags, err = distinct(TagsField); err != nil {
+		return nil, nil, err
+	}
+
+	return sciences, tags, nil
+}
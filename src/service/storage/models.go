@@ -25,8 +25,130 @@ var (
 	ReviewWorkStatus    WorkStatus = "WORK_UNDER_REVIEW"
 	OpenWorkStatus      WorkStatus = "WORK_OPEN"
 	DeclinedWorkStatus  WorkStatus = "WORK_DECLINED"
+	// the work is created from the uploaded paper and is visible to its author only
+	DraftWorkStatus WorkStatus = "WORK_DRAFT"
 )
 
+// ReviewMode is the visibility of the reviews of the work
+type ReviewMode string
+
+const (
+	// the reviews and the reviewers are known to the author and the validators only, it's the default one
+	BlindReviewMode ReviewMode = "blind"
+	// the reviews are published with the open work, the reviewers are named
+	OpenReviewMode ReviewMode = "open"
+)
+
+// ValidReviewMode checks the review mode, the empty one is blind
+func ValidReviewMode(mode ReviewMode) bool {
+	return mode == "" || mode == BlindReviewMode || mode == OpenReviewMode
+}
+
+// LicenseID is the license the authors grant to the readers of the work
+type LicenseID string
+
+const (
+	CCBYLicense   LicenseID = "CC-BY-4.0"
+	CCBYSALicense LicenseID = "CC-BY-SA-4.0"
+	CCBYNCLicense LicenseID = "CC-BY-NC-4.0"
+	// the readers may only read the work, it's the default one
+	AllRightsReservedLicense LicenseID = "all-rights-reserved"
+	// the terms are written by the authors
+	CustomLicense LicenseID = "custom"
+)
+
+// the max length of the terms of the custom license
+const maxLicenseText = 4000
+
+// the works under the licenses allowing the commercial reuse must be open access,
+// anyone may sell their copies, the NonCommercial license keeps the sale to the authors
+var licenses = map[LicenseID]struct {
+	name, url  string
+	mustBeOpen bool
+}{
+	CCBYLicense:              {"Creative Commons Attribution 4.0 International", "https://creativecommons.org/licenses/by/4.0/", true},
+	CCBYSALicense:            {"Creative Commons Attribution-ShareAlike 4.0 International", "https://creativecommons.org/licenses/by-sa/4.0/", true},
+	CCBYNCLicense:            {"Creative Commons Attribution-NonCommercial 4.0 International", "https://creativecommons.org/licenses/by-nc/4.0/", false},
+	AllRightsReservedLicense: {"All rights reserved by the authors", "", false},
+	CustomLicense:            {"Custom license", "", false},
+}
+
+// WorkLicense is the license of the work, the text is the terms of the custom license.
+// The name and the URL are filled in when the work is returned.
+type WorkLicense struct {
+	ID   LicenseID `bson:"id" json:"id" example:"CC-BY-4.0"`
+	Text string    `bson:"text,omitempty" json:"text,omitempty"`
+	Name string    `bson:"-" json:"name,omitempty"`
+	URL  string    `bson:"-" json:"url,omitempty"`
+}
+
+// ValidLicense checks the license, the empty one is all rights reserved. Only the custom
+// license has the text.
+func ValidLicense(license *WorkLicense) bool {
+	if license == nil {
+		return true
+	}
+	if _, ok := licenses[license.ID]; !ok {
+		return false
+	}
+	if license.ID == CustomLicense {
+		text := strings.TrimSpace(license.Text)
+		return text != "" && len(text) <= maxLicenseText
+	}
+	return license.Text == ""
+}
+
+// LicenseOf returns the license of the work with its name and URL
+func LicenseOf(work *Work) *WorkLicense {
+	license := &WorkLicense{ID: AllRightsReservedLicense}
+	if work.License != nil && work.License.ID != "" {
+		license.ID, license.Text = work.License.ID, strings.TrimSpace(work.License.Text)
+	}
+	license.Name, license.URL = licenses[license.ID].name, licenses[license.ID].url
+
+	return license
+}
+
+// LicenseByURL returns the standard license referred by the URL of its deed or its legal code
+func LicenseByURL(rawURL string) (LicenseID, bool) {
+	key := licenseKey(rawURL)
+	for id, license := range licenses {
+		if license.url != "" && licenseKey(license.url) == key {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+func licenseKey(rawURL string) string {
+	key := strings.ToLower(strings.TrimSpace(rawURL))
+	key = strings.TrimPrefix(strings.TrimPrefix(key, "https://"), "http://")
+	key = strings.TrimSuffix(strings.TrimSuffix(key, "/"), "legalcode")
+	return strings.TrimSuffix(key, "/")
+}
+
+// Open is true for the Creative Commons licenses, the readers may share the work
+func (l *WorkLicense) Open() bool {
+	return l != nil && (l.ID == CCBYLicense || l.ID == CCBYSALicense || l.ID == CCBYNCLicense)
+}
+
+// MustBeOpen is true for the licenses the work under which can't be sold
+func (l *WorkLicense) MustBeOpen() bool {
+	return l != nil && licenses[l.ID].mustBeOpen
+}
+
+// Notice returns the license as it's printed on the work
+func (l *WorkLicense) Notice() string {
+	switch {
+	case l.ID == CustomLicense:
+		return l.Text
+	case l.URL != "":
+		return l.Name + " (" + l.URL + ")"
+	default:
+		return l.Name
+	}
+}
+
 type Participant struct {
 	ID          string          `json:"-"`
 	NickName    string          `gorm:"type:TEXT;uniqueIndex" json:"nickname"`
@@ -42,17 +164,26 @@ type ParticipantsWork struct {
 	WorkID        string     `gorm:"type:TEXT" json:"-"`
 	Tags          string     `gorm:"type:TEXT"`
 	NFTAddress    string     `gorm:"type:TEXT" json:"nft_address"`
+	NFTTokenID    string     `gorm:"type:TEXT;index" json:"nft_token_id,omitempty"`
+	MintNFT       bool       `json:"mint_nft"`    // the author has opted in to mint the work as NFT
+	OpenAccess    bool       `json:"open_access"` // the content is free to read once the work is open
 	Status        WorkStatus `json:"status,omitempty"`
 	CreatedAt     time.Time  `json:"created_date,omitempty"`
+	DecidedAt     *time.Time `gorm:"type:TIMESTAMP WITH TIME ZONE" json:"decided_date,omitempty"`
 }
 
 func (w *ParticipantsWork) IsShow(participant *Participant, purchased bool) (work, content bool) {
+	if w.Status == DraftWorkStatus {
+		work = participant != nil && w.ParticipantID == participant.ID
+		return work, work
+	}
+
 	work = w.Status == OpenWorkStatus
 	if participant != nil {
 		work = work || w.ParticipantID == participant.ID || participant.Role >= ValidatorRole
 		content = w.ParticipantID == participant.ID || participant.Role >= ValidatorRole
 	}
-	content = content || purchased
+	content = content || purchased || w.OpenAccess && w.Status == OpenWorkStatus
 
 	return
 }
@@ -76,18 +207,242 @@ type ParticipantsWorkReview struct {
 	ParticipantID string           `gorm:"type:TEXT" json:"-"`
 	WorkID        string           `gorm:"type:TEXT" json:"-"`
 	Status        WorkReviewStatus `json:"status"`
+	DueAt         time.Time        `gorm:"type:TIMESTAMP WITH TIME ZONE" json:"due_date,omitempty"`
+	RemindedAt    *time.Time       `gorm:"type:TIMESTAMP WITH TIME ZONE" json:"reminded_date,omitempty"`
+	OverdueAt     *time.Time       `gorm:"type:TIMESTAMP WITH TIME ZONE" json:"overdue_date,omitempty"`
 	CreatedAt     time.Time        `gorm:"type:TIMESTAMP WITH TIME ZONE;default:now()" json:"created_date,omitempty,"`
 	UpdatedAt     time.Time        `gorm:"type:TIMESTAMP WITH TIME ZONE;default:now()" json:"updated_date,omitempty"`
 }
 
+// ReviewNotification is the notice of the review deadline kept for the validator, the validator
+// fetches the unread ones and marks them read
+type ReviewNotification struct {
+	ID            string     `json:"id"`
+	ParticipantID string     `gorm:"type:TEXT;index" json:"-"`
+	ReviewID      string     `gorm:"type:TEXT" json:"review_id"`
+	WorkID        string     `gorm:"type:TEXT" json:"work_id"`
+	Topic         string     `gorm:"type:TEXT" json:"topic" example:"review:reminder"`
+	DueAt         time.Time  `gorm:"type:TIMESTAMP WITH TIME ZONE" json:"due_date"`
+	ReadAt        *time.Time `gorm:"type:TIMESTAMP WITH TIME ZONE" json:"read_date,omitempty"`
+	CreatedAt     time.Time  `gorm:"type:TIMESTAMP WITH TIME ZONE;default:now()" json:"created_date"`
+}
+
+// ValidatorReviewStats is the validator's review history
+type ValidatorReviewStats struct {
+	Total      int64 `json:"total"`
+	InProgress int64 `json:"in_progress"`
+	Finished   int64 `json:"finished"`
+	Overdue    int64 `json:"overdue"` // currently overdue
+	Late       int64 `json:"late"`    // have ever been overdue
+	Reassigned int64 `json:"reassigned"`
+}
+
+// ReviewRating is the helpfulness rating(1-5) of the review given by the work's author or an admin
+type ReviewRating struct {
+	ID            string    `json:"-"`
+	ReviewID      string    `gorm:"type:TEXT;uniqueIndex:idx_review_rater" json:"review_id"`
+	ParticipantID string    `gorm:"type:TEXT;uniqueIndex:idx_review_rater" json:"-"`
+	Rating        int64     `json:"rating"`
+	CreatedAt     time.Time `gorm:"type:TIMESTAMP WITH TIME ZONE;default:now()" json:"created_date,omitempty"`
+}
+
+// ValidatorReputation is the reputation score(0-100) of the validator with its components(0-1)
+type ValidatorReputation struct {
+	ParticipantID string    `gorm:"type:TEXT;primaryKey" json:"-"`
+	Score         float64   `json:"score"`
+	Timeliness    float64   `json:"timeliness"`
+	Agreement     float64   `json:"agreement"`
+	Depth         float64   `json:"depth"`
+	Helpfulness   float64   `json:"helpfulness"`
+	History       float64   `json:"history"`
+	Reviews       int64     `json:"reviews"`
+	UpdatedAt     time.Time `gorm:"type:TIMESTAMP WITH TIME ZONE" json:"updated_date"`
+}
+
+type RewardStatus string
+
+const (
+	RewardPending RewardStatus = "REWARD_PENDING"
+	RewardPaid    RewardStatus = "REWARD_PAID"
+	RewardFailed  RewardStatus = "REWARD_FAILED"
+	// the transfer has been requested, but its result hasn't been saved yet
+	RewardPaying RewardStatus = "REWARD_PAYING"
+)
+
+// RewardLedger is the reward of the validator for the review, the amount is in wei
+type RewardLedger struct {
+	ID            string       `json:"id"`
+	ParticipantID string       `gorm:"type:TEXT;index" json:"-"`
+	ReviewID      string       `gorm:"type:TEXT;uniqueIndex" json:"review_id"`
+	WorkID        string       `gorm:"type:TEXT" json:"work_id"`
+	Policy        string       `json:"policy"`
+	Amount        string       `json:"amount"`
+	Status        RewardStatus `json:"status"`
+	TxHash        string       `json:"tx_hash,omitempty"`
+	PayoutID      string       `gorm:"type:TEXT;index" json:"-"` // the idempotency key of the transfer
+	Attempts      int64        `json:"-"`
+	Error         string       `json:"-"`
+	CreatedAt     time.Time    `gorm:"type:TIMESTAMP WITH TIME ZONE;default:now()" json:"created_date"`
+	PaidAt        *time.Time   `gorm:"type:TIMESTAMP WITH TIME ZONE" json:"paid_date,omitempty"`
+}
+
+// RewardsHistory is the validator's rewards with the totals in wei
+type RewardsHistory struct {
+	Rewards []*RewardLedger `json:"rewards"`
+	Paid    string          `json:"paid"`
+	Pending string          `json:"pending"`
+}
+
+type BlobKind string
+
+const (
+	WorkDocumentBlob BlobKind = "BLOB_WORK_DOCUMENT"
+	DiplomaBlob      BlobKind = "BLOB_DIPLOMA"
+)
+
+// BlobRecord links the blob in the blob store(by its SHA-256 key) to the participant, the work or the diploma
+type BlobRecord struct {
+	ID            string    `json:"id"`
+	Key           string    `gorm:"type:TEXT;index" json:"key"`
+	Kind          BlobKind  `json:"kind"`
+	Name          string    `json:"name"`
+	ContentType   string    `json:"content_type"`
+	Size          int64     `json:"size"`
+	ParticipantID string    `gorm:"type:TEXT;index" json:"-"`
+	WorkID        string    `gorm:"type:TEXT;index" json:"work_id,omitempty"`
+	DiplomaID     string    `gorm:"type:TEXT;index" json:"diploma_id,omitempty"`
+	CreatedAt     time.Time `gorm:"type:TIMESTAMP WITH TIME ZONE;default:now()" json:"created_date"`
+}
+
+// WorkRevision is the fingerprint of the approved work, a new revision is added
+// when the approved work is published with the changed content
+type WorkRevision struct {
+	ID          string `json:"-"`
+	WorkID      string `gorm:"type:TEXT;uniqueIndex:idx_work_revision" json:"work_id"`
+	Revision    int    `gorm:"uniqueIndex:idx_work_revision" json:"revision"`
+	Fingerprint string `json:"fingerprint"`
+	CID         string `json:"cid,omitempty"`
+	TxHash      string `json:"tx_hash,omitempty"`
+	// the license of the work at the revision
+	License     LicenseID `gorm:"type:TEXT" json:"license,omitempty"`
+	LicenseText string    `gorm:"type:TEXT" json:"license_text,omitempty"`
+	CreatedAt   time.Time `gorm:"type:TIMESTAMP WITH TIME ZONE;default:now()" json:"created_at"`
+}
+
+// PersistentID is the persistent identifier minted for the open work, it's kept across
+// the revisions of the work. The sequence number is counted within the year of minting.
+type PersistentID struct {
+	ID           string     `json:"-"`
+	PID          string     `gorm:"column:pid;type:TEXT;uniqueIndex" json:"pid"`
+	WorkID       string     `gorm:"type:TEXT;uniqueIndex" json:"work_id"`
+	Year         int        `gorm:"uniqueIndex:idx_pid_sequence" json:"-"`
+	Sequence     int        `gorm:"uniqueIndex:idx_pid_sequence" json:"-"`
+	URL          string     `gorm:"type:TEXT" json:"url,omitempty"` // the URL the identifier is registered to
+	RegisteredAt *time.Time `gorm:"type:TIMESTAMP WITH TIME ZONE" json:"registered_at,omitempty"`
+	CreatedAt    time.Time  `gorm:"type:TIMESTAMP WITH TIME ZONE;default:now()" json:"created_at"`
+}
+
+type DepositStatus string
+
+const (
+	// the deposit is generated, it's submitted again if the submission has failed
+	DepositPending DepositStatus = "DEPOSIT_PENDING"
+	// the deposit is accepted by the registration agency and waits for the processing
+	DepositSubmitted DepositStatus = "DEPOSIT_SUBMITTED"
+	DepositSucceeded DepositStatus = "DEPOSIT_SUCCEEDED"
+	DepositFailed    DepositStatus = "DEPOSIT_FAILED"
+)
+
+// WorkDeposit is the deposit of the metadata of the identified work to the DOI registration
+// agency, the works are deposited in batches and the status of the deposit is tracked per work
+type WorkDeposit struct {
+	ID          string        `json:"-"`
+	BatchID     string        `gorm:"type:TEXT;index" json:"batch_id"`
+	WorkID      string        `gorm:"type:TEXT;index" json:"work_id"`
+	PID         string        `gorm:"column:pid;type:TEXT" json:"pid"`
+	Status      DepositStatus `gorm:"type:TEXT;index" json:"status"`
+	Message     string        `gorm:"type:TEXT" json:"message,omitempty"`
+	SubmittedAt *time.Time    `gorm:"type:TIMESTAMP WITH TIME ZONE" json:"submitted_at,omitempty"`
+	CreatedAt   time.Time     `gorm:"type:TIMESTAMP WITH TIME ZONE;default:now()" json:"created_at"`
+	UpdatedAt   time.Time     `gorm:"type:TIMESTAMP WITH TIME ZONE;default:now()" json:"updated_at"`
+}
+
+// WorkReference is the entry of the reference list of the work, it refers either to
+// the work of the library or to the external work by its DOI, URL or the free text
+type WorkReference struct {
+	ID          string    `json:"-"`
+	WorkID      string    `gorm:"type:TEXT;index" json:"-"`
+	Position    int       `json:"position"`
+	CitedWorkID string    `gorm:"type:TEXT;index" json:"cited_work_id,omitempty"`
+	DOI         string    `gorm:"column:doi;type:TEXT" json:"doi,omitempty"`
+	URL         string    `gorm:"column:url;type:TEXT" json:"url,omitempty"`
+	Text        string    `gorm:"type:TEXT" json:"text,omitempty"`
+	CreatedAt   time.Time `gorm:"type:TIMESTAMP WITH TIME ZONE;default:now()" json:"-"`
+}
+
+// WorkRendering is the cached output of the work content rendered from its source format,
+// the content hash identifies the revision of the content. The body is stored encrypted
+// by the data key of the work as its content is.
+type WorkRendering struct {
+	ID          string    `json:"-"`
+	WorkID      string    `gorm:"type:TEXT;uniqueIndex:idx_work_rendering" json:"work_id"`
+	ContentHash string    `gorm:"type:TEXT;uniqueIndex:idx_work_rendering" json:"content_hash"`
+	Output      string    `gorm:"type:TEXT;uniqueIndex:idx_work_rendering" json:"output"`
+	Body        string    `gorm:"-" json:"body"`
+	Cipher      []byte    `gorm:"type:BYTEA" json:"-"`
+	CreatedAt   time.Time `gorm:"type:TIMESTAMP WITH TIME ZONE;default:now()" json:"created_at"`
+}
+
+type ExtractionStatus string
+
+const (
+	ExtractionPending ExtractionStatus = "EXTRACTION_PENDING"
+	ExtractionRunning ExtractionStatus = "EXTRACTION_RUNNING"
+	ExtractionDone    ExtractionStatus = "EXTRACTION_DONE"
+	ExtractionFailed  ExtractionStatus = "EXTRACTION_FAILED"
+)
+
+// DocumentExtraction tracks the recognition of the uploaded paper into the draft work
+type DocumentExtraction struct {
+	ID            string           `json:"id"`
+	ParticipantID string           `gorm:"type:TEXT;index" json:"-"`
+	BlobID        string           `gorm:"type:TEXT" json:"blob_id"`
+	WorkID        string           `gorm:"type:TEXT" json:"work_id,omitempty"`
+	Status        ExtractionStatus `gorm:"type:TEXT" json:"status"`
+	Error         string           `gorm:"type:TEXT" json:"error,omitempty"`
+	// the progress of the PDF processed page by page
+	TotalPages     int               `json:"total_pages,omitempty"`
+	ProcessedPages int               `json:"processed_pages,omitempty"`
+	FailedPages    int               `json:"failed_pages,omitempty"`
+	Pages          []*ExtractionPage `gorm:"-" json:"pages,omitempty"`
+	// the text recognized from the paper sealed by the data key of the draft work,
+	// it's kept to parse the bibliography after the author has edited the draft
+	TextCipher []byte    `gorm:"type:BYTEA" json:"-"`
+	CreatedAt  time.Time `gorm:"type:TIMESTAMP WITH TIME ZONE;default:now()" json:"created_date"`
+	UpdatedAt  time.Time `gorm:"type:TIMESTAMP WITH TIME ZONE" json:"updated_date"`
+}
+
+// ExtractionPage is the status of the single page of the extracted PDF
+type ExtractionPage struct {
+	ID           string           `json:"-"`
+	ExtractionID string           `gorm:"type:TEXT;index" json:"-"`
+	Number       int              `json:"number"`
+	Status       ExtractionStatus `gorm:"type:TEXT" json:"status"`
+	// PAGE_TEXT if the text is embedded into the PDF, PAGE_OCR if it's recognized from the scan
+	Source string `gorm:"type:TEXT" json:"source,omitempty"`
+	Error  string `gorm:"type:TEXT" json:"error,omitempty"`
+}
+
 type AuthorResponse struct {
 	BasicInfo  *Participant `json:"basic_info"`
 	AuthorInfo *Author      `json:"author_info"`
 }
 
 type ValidatorResponse struct {
-	BasicInfo     *Participant `json:"basic_info"`
-	ValidatorInfo *Validator   `json:"validator_info"`
+	BasicInfo     *Participant          `json:"basic_info"`
+	ValidatorInfo *Validator            `json:"validator_info"`
+	ReviewStats   *ValidatorReviewStats `json:"review_stats,omitempty"`
+	Reputation    *ValidatorReputation  `json:"reputation,omitempty"`
 }
 
 // --- Mongo ---
@@ -106,8 +461,27 @@ type Work struct {
 	Sources    string     `json:"sources,omitempty"`
 	Language   string     `json:"language,omitempty"`
 	Status     WorkStatus `bson:"status" json:"status,omitempty"`
-	// BODY INFORMATION
-	Content *WorkContent `json:"content"`
+	Science    string     `bson:"science" json:"science,omitempty"`
+	ReviewMode ReviewMode `bson:"review_mode,omitempty" json:"review_mode,omitempty" example:"blind"`
+	// License is chosen by the author, the open access work is free to read
+	License    *WorkLicense `bson:"license,omitempty" json:"license,omitempty"`
+	OpenAccess bool         `bson:"open_access" json:"open_access"`
+	// CoAuthors are the authors of the work besides the participant who has submitted it
+	CoAuthors []*Author `bson:"co_authors,omitempty" json:"co_authors,omitempty"`
+	// PUBLICATION of the approved work: the IPFS CID of its canonical JSON,
+	// the content fingerprint and the tx of the on-chain publication, the work updated
+	// after the publication is published again if its content has changed
+	CID           string    `bson:"cid" json:"cid,omitempty"`
+	Fingerprint   string    `bson:"fingerprint" json:"fingerprint,omitempty"`
+	PublishTxHash string    `bson:"publish_tx_hash" json:"publish_tx_hash,omitempty"`
+	Published     bool      `bson:"published" json:"-"`
+	PublishedAt   time.Time `bson:"published_at,omitempty" json:"-"`
+	// PID is the persistent identifier of the open work
+	PID string `bson:"pid" json:"pid,omitempty"`
+	// BODY INFORMATION, the content is encrypted by the data key of the work
+	// wrapped by the master key
+	Content    *WorkContent `json:"content"`
+	ContentKey []byte       `bson:"content_key,omitempty" json:"-"`
 }
 
 type WorkReviewStatus string
@@ -117,6 +491,10 @@ var (
 	WorkReviewInProgress WorkReviewStatus = "WORK_REVIEW_IN_PROGRESS"
 	WorkReviewRejected   WorkReviewStatus = "WORK_REVIEW_DECLINED"
 	WorkReviewSubmitted  WorkReviewStatus = "WORK_REVIEW_SUBMITTED"
+	// the deadline has passed, but the review can still be submitted
+	WorkReviewOverdue WorkReviewStatus = "WORK_REVIEW_OVERDUE"
+	// the review was given to another validator after the grace period
+	WorkReviewReassigned WorkReviewStatus = "WORK_REVIEW_REASSIGNED"
 	// after the admin's decision has been made.
 	WorkReviewAccepted WorkReviewStatus = "WORK_REVIEW_ACCEPTED"
 )
@@ -146,18 +524,102 @@ type WorkReview struct {
 	UpdatedAt time.Time        `bson:"updated_at" json:"updated_date"`
 	Language  string           `bson:"language" json:"language"`
 	Status    WorkReviewStatus `bson:"status" json:"status"`
+	DueAt     time.Time        `bson:"due_at" json:"due_date"`
 	// BODY REVIEW
 	Body *WorkReviewBody `json:"body"`
 }
 
+// WorkReviewVersion is the immutable snapshot of the review written on every save
+type WorkReviewVersion struct {
+	ID        string           `json:"id"`
+	ReviewID  string           `bson:"review_id" json:"review_id"`
+	WorkID    string           `bson:"work_id" json:"work_id"`
+	Version   int64            `bson:"version" json:"version"`
+	Status    WorkReviewStatus `bson:"status" json:"status"`
+	Body      *WorkReviewBody  `bson:"body" json:"body"`
+	BodyHash  string           `bson:"body_hash" json:"body_hash"`
+	CreatedAt time.Time        `bson:"created_at" json:"created_date"`
+}
+
+// WorkReviewHistory is all versions of the review and the one the work was decided with
+type WorkReviewHistory struct {
+	ReviewID        string               `json:"review_id"`
+	WorkID          string               `json:"work_id"`
+	DecidedAt       *time.Time           `json:"decided_date,omitempty"`
+	DecisionVersion *WorkReviewVersion   `json:"decision_version,omitempty"`
+	Versions        []*WorkReviewVersion `json:"versions"`
+}
+
+// WorkReviewDiff is the difference between two versions of the review
+type WorkReviewDiff struct {
+	ReviewID      string                `json:"review_id"`
+	From          *WorkReviewVersion    `json:"from"`
+	To            *WorkReviewVersion    `json:"to"`
+	StatusChanged bool                  `json:"status_changed"`
+	Review        []*DiffLine           `json:"review"`
+	Questionnaire []*QuestionAnswerDiff `json:"questionnaire"`
+}
+
+// DiffLine is the line of the review text, the operation is one of "=", "-", "+"
+type DiffLine struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// QuestionAnswerDiff is the changed answer of the questionnaire, nil means no answer
+type QuestionAnswerDiff struct {
+	QuestionID string `json:"question_id"`
+	From       *int64 `json:"from"`
+	To         *int64 `json:"to"`
+}
+
 type WorkReviewBody struct {
 	Questionnaire *WorkReviewQuestionnaire `bson:"questionnaire" json:"questionnaire"`
 	Review        string                   `json:"review"`
 }
 type WorkReviewQuestionnaire struct {
-	Questions map[string]int64 `json:"questions"` // 0 - не согласен, 4 - согласен
+	TemplateID      string           `bson:"template_id" json:"template_id"`
+	TemplateVersion int64            `bson:"template_version" json:"template_version"`
+	Questions       map[string]int64 `json:"questions"` // question id -> score within the template scale
+}
+
+// QuestionnaireTemplate is an admin-managed set of review questions for a science area.
+// Templates are immutable: every change is stored as a new version under the same ID.
+type QuestionnaireTemplate struct {
+	ID        string                   `json:"id"`
+	Version   int64                    `json:"version"`
+	Science   string                   `json:"science"`
+	ScaleMin  int64                    `bson:"scale_min" json:"scale_min"` // 0 - не согласен
+	ScaleMax  int64                    `bson:"scale_max" json:"scale_max"` // 4 - согласен
+	Questions []*QuestionnaireQuestion `json:"questions"`
+	CreatedAt time.Time                `bson:"created_at" json:"created_at"`
 }
 
+type QuestionnaireQuestion struct {
+	ID       string            `json:"id"`
+	Text     map[string]string `json:"text"` // 'ru', 'en'
+	Required bool              `json:"required"`
+	Weight   float64           `json:"weight"`
+}
+
+// WorkScore is the aggregated questionnaire score of the finished reviews of a work
+type WorkScore struct {
+	WorkID    string             `json:"work_id"`
+	Reviews   int                `json:"reviews"`
+	Score     float64            `json:"score"`     // weighted, normalized to [0, 1]
+	Questions map[string]float64 `json:"questions"` // question id -> average raw score
+}
+
+type ValidatorApplicationStatus string
+
+const (
+	ValidatorApplied            ValidatorApplicationStatus = "VALIDATOR_APPLIED"
+	ValidatorDocumentsSubmitted ValidatorApplicationStatus = "VALIDATOR_DOCUMENTS_SUBMITTED"
+	ValidatorUnderVerification  ValidatorApplicationStatus = "VALIDATOR_UNDER_VERIFICATION"
+	ValidatorApproved           ValidatorApplicationStatus = "VALIDATOR_APPROVED"
+	ValidatorRejected           ValidatorApplicationStatus = "VALIDATOR_REJECTED"
+)
+
 type Validator struct {
 	ID           string    `json:"-"` // postgresSQL id
 	Name         string    `json:"name"`
@@ -170,6 +632,11 @@ type Validator struct {
 	DiplomaID    string    `bson:"diploma_id" json:"diploma_id,omitempty"` // referrenceKey
 	CreatedAt    time.Time `bson:"created_at" json:"-"`
 	UpdatedAt    time.Time `bson:"updated_at" json:"-"`
+	// the application, empty status means the validator was approved before the verification existed
+	ApplicationStatus ValidatorApplicationStatus `bson:"application_status" json:"application_status,omitempty"`
+	RejectionReason   string                     `bson:"rejection_reason" json:"rejection_reason,omitempty"`
+	VerifiedBy        string                     `bson:"verified_by" json:"-"`
+	VerifiedAt        *time.Time                 `bson:"verified_at" json:"verified_date,omitempty"`
 }
 
 type DocumentType int
@@ -184,21 +651,50 @@ type Document struct {
 	Content interface{}  `json:"content"` // Diploma
 }
 
+// Diploma is the validator's diploma recognized by OCR, with the uploaded scans
 type Diploma struct {
-	ID            string `json:"-"` // postgresSQL id
-	Degree        string
-	Topics        []string
-	DiplomaNumber int64
-	DefenseNumber int64
-	DefenseDate   time.Time
-	OrderNumber   int64
-	OrderDate     time.Time
-	CreatedAt     time.Time `bson:"created_at" json:"-"`
-	UpdatedAt     time.Time `bson:"updated_at" json:"-"`
+	ID          string   `json:"id"`
+	ValidatorID string   `bson:"validator_id" json:"-"`
+	Degree      string   `json:"degree,omitempty"`
+	Topics      []string `json:"topics,omitempty"`
+	// the forward side
+	Number uint64    `json:"number"`
+	Date   time.Time `json:"date"`
+	// the backward side
+	DiplomaNumber       string         `bson:"diploma_number" json:"diploma_number"`
+	DiplomaSerialNumber string         `bson:"diploma_serial_number" json:"diploma_serial_number"`
+	DiplomaDate         time.Time      `bson:"diploma_date" json:"diploma_date"`
+	ForwardImage        *DocumentImage `bson:"forward_image" json:"forward_image,omitempty"`
+	BackwardImage       *DocumentImage `bson:"backward_image" json:"backward_image,omitempty"`
+	// OCR error if the text wasn't recognized
+	OCRError  string    `bson:"ocr_error" json:"ocr_error,omitempty"`
+	CreatedAt time.Time `bson:"created_at" json:"created_date"`
+	UpdatedAt time.Time `bson:"updated_at" json:"-"`
+}
+
+// DocumentImage is the uploaded scan of the document kept in the blob store
+type DocumentImage struct {
+	Name        string `json:"name"`
+	ContentType string `bson:"content_type" json:"content_type"`
+	Size        int64  `json:"size"`
+	BlobID      string `bson:"blob_id" json:"blob_id,omitempty"`
+	// the scans uploaded before the blob store existed are kept in the document
+	Data []byte `bson:"data,omitempty" json:"-"`
+}
+
+// ValidatorApplication is the validator's application with the uploaded diplomas
+type ValidatorApplication struct {
+	BasicInfo     *Participant `json:"basic_info"`
+	ValidatorInfo *Validator   `json:"validator_info"`
+	Diplomas      []*Diploma   `json:"diplomas"`
 }
 
 type WorkContent struct {
 	WorkData string `json:"work_data"`
+	// the source format of the work data: plain, markdown or latex, the empty one is plain
+	Format string `bson:"format,omitempty" json:"format,omitempty" example:"markdown"`
+	// the encrypted work data, the plain text isn't stored
+	Cipher []byte `bson:"cipher,omitempty" json:"-"`
 }
 
 type Author struct {
@@ -220,4 +716,6 @@ type WorkResponse struct {
 	Work       *Work           `json:"work"`
 	Author     *AuthorResponse `json:"author_info"`
 	Bookmarked bool            `json:"bookmarked"`
+	// Citations is the number of the open works citing the work
+	Citations int `json:"citations"`
 }
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidLicense(t *testing.T) {
+	tests := []struct {
+		name    string
+		license *WorkLicense
+		want    bool
+	}{
+		{"null", nil, true},
+		{"Creative Commons", &WorkLicense{ID: CCBYLicense}, true},
+		{"all rights reserved", &WorkLicense{ID: AllRightsReservedLicense}, true},
+		{"unknown", &WorkLicense{ID: "GPL-3.0"}, false},
+		{"standard with the text", &WorkLicense{ID: CCBYSALicense, Text: "the terms"}, false},
+		{"custom", &WorkLicense{ID: CustomLicense, Text: "The work may be read only."}, true},
+		{"custom without the text", &WorkLicense{ID: CustomLicense, Text: " \n"}, false},
+		{"custom with the long text", &WorkLicense{ID: CustomLicense, Text: strings.Repeat("a", maxLicenseText+1)}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := ValidLicense(test.license); got != test.want {
+				t.Errorf("ValidLicense() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestLicenseByURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want LicenseID
+		ok   bool
+	}{
+		{"https://creativecommons.org/licenses/by/4.0/", CCBYLicense, true},
+		{"http://creativecommons.org/licenses/by-sa/4.0", CCBYSALicense, true},
+		{" https://CreativeCommons.org/licenses/by-nc/4.0/legalcode ", CCBYNCLicense, true},
+		{"https://creativecommons.org/licenses/by-nd/4.0/", "", false},
+		{"", "", false},
+	}
+
+	for _, test := range tests {
+		got, ok := LicenseByURL(test.url)
+		if got != test.want || ok != test.ok {
+			t.Errorf("LicenseByURL(%q) = %q, %v, want %q, %v", test.url, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestLicenseMustBeOpen(t *testing.T) {
+	want := map[LicenseID]bool{
+		CCBYLicense:              true,
+		CCBYSALicense:            true,
+		CCBYNCLicense:            false,
+		AllRightsReservedLicense: false,
+		CustomLicense:            false,
+	}
+
+	for id, mustBeOpen := range want {
+		if got := (&WorkLicense{ID: id}).MustBeOpen(); got != mustBeOpen {
+			t.Errorf("MustBeOpen() of %s = %v, want %v", id, got, mustBeOpen)
+		}
+	}
+}
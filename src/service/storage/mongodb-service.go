@@ -17,7 +17,13 @@ const (
 
 	collectionValidators = "validators"
 
+	collectionDiplomas = "diplomas"
+
 	collectionWorkReviews = "work_reviews"
+
+	collectionWorkReviewVersions = "work_review_versions"
+
+	collectionQuestionnaireTemplates = "questionnaire_templates"
 )
 
 func addIndexOnWorks(works *mongo.Collection) {
@@ -87,7 +93,7 @@ func (ss *StorageSrv) RemoveWork(ctx context.Context, workID string) error {
 
 	// remove from Bookmarks
 	if err := ss.removeWorkFromBookmarks(workID); err != nil {
-		ss.log.Error("while removing the work from PostgreSQL(bookmarks), err: %v", err)
+		ss.log.Errorf("while removing the work from PostgreSQL(bookmarks), err: %v", err)
 
 		return fmt.Errorf("something went wrong")
 	}
@@ -141,11 +147,49 @@ func (ss *StorageSrv) DeclineWork(ctx context.Context, workID string) error {
 	return nil
 }
 
-// TODO
-func (ss *StorageSrv) UpdateWork(work *Work) error {
+// UpdateWork updates the descriptive fields, the license and the content of the work
+func (ss *StorageSrv) UpdateWork(ctx context.Context, work *Work) error {
+	content, err := ss.sealContent(work)
+	if err != nil {
+		return err
+	}
+
 	work.UpdatedAt = time.Now().UTC()
-	// if _, err := ss.worksCollection.UpdateOne(context.Background(), work); err != nil {
-	// 	return err
-	// }
+	if err := ss.updateWorkFields(ctx, work.ID, bson.M{
+		"name":        work.Name,
+		"annotation":  work.Annotation,
+		"tags":        work.Tags,
+		"sources":     work.Sources,
+		"language":    work.Language,
+		"science":     work.Science,
+		"review_mode": work.ReviewMode,
+		"license":     work.License,
+		"open_access": work.OpenAccess,
+		"co_authors":  work.CoAuthors,
+		"content":     content,
+		"content_key": work.ContentKey,
+		"updated_at":  work.UpdatedAt,
+	}); err != nil {
+		return err
+	}
+
+	return ss.psqlDB.Model(ParticipantsWork{}).Where("work_id = ?", work.ID).
+		Update("open_access", work.OpenAccess).Error
+}
+
+func (ss *StorageSrv) updateWorkFields(ctx context.Context, workID string, fields bson.M) error {
+	collection := ss.mongoDB.Collection(collectionWorks)
+	if collection == nil {
+		panic(fmt.Errorf("works collection is nil"))
+	}
+
+	res, err := collection.UpdateOne(ctx, bson.M{"id": workID}, bson.M{"$set": fields})
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return ErrWorkNotExists
+	}
 	return nil
 }
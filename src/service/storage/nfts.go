@@ -0,0 +1,37 @@
+package storage
+
+// SetWorkMintNFT opts the work in to be minted as NFT
+func (ss *StorageSrv) SetWorkMintNFT(workID string) error {
+	return ss.psqlDB.Model(ParticipantsWork{}).Where("work_id = ?", workID).
+		Update("mint_nft", true).Error
+}
+
+// SetWorkNFT saves the token the work is linked to
+func (ss *StorageSrv) SetWorkNFT(workID, address, tokenID string) error {
+	return ss.psqlDB.Model(ParticipantsWork{}).Where("work_id = ?", workID).
+		Updates(map[string]interface{}{
+			"nft_address":  address,
+			"nft_token_id": tokenID,
+		}).Error
+}
+
+// GetUnlinkedNFTWorkIDs returns the open works opted in to be minted which haven't been linked to their tokens yet
+func (ss *StorageSrv) GetUnlinkedNFTWorkIDs() (ids []string, err error) {
+	err = ss.psqlDB.Model(ParticipantsWork{}).
+		Where("status = ? AND mint_nft AND COALESCE(nft_token_id, '') = ''", OpenWorkStatus).
+		Pluck("work_id", &ids).Error
+	return
+}
+
+func (ss *StorageSrv) GetParticipantWorkByTokenID(tokenID string) (*ParticipantsWork, error) {
+	var works []*ParticipantsWork
+	if err := ss.psqlDB.Where("nft_token_id = ?", tokenID).Limit(1).Find(&works).Error; err != nil {
+		return nil, err
+	}
+
+	if len(works) == 0 {
+		return nil, ErrNFTNotExists
+	}
+
+	return works[0], nil
+}
@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateReviewNotification saves the notice of the review deadline for the validator
+func (ss *StorageSrv) CreateReviewNotification(notification *ReviewNotification) error {
+	notification.ID = uuid.New().String()
+	notification.CreatedAt = time.Now().UTC()
+
+	return ss.psqlDB.Create(notification).Error
+}
+
+// GetReviewNotifications returns the notices of the validator, the latest first
+func (ss *StorageSrv) GetReviewNotifications(participantID string, unread bool) (notifications []*ReviewNotification, err error) {
+	query := ss.psqlDB.Where("participant_id = ?", participantID)
+	if unread {
+		query = query.Where("read_at IS NULL")
+	}
+
+	err = query.Order("created_at DESC").Find(&notifications).Error
+	return
+}
+
+// ReadReviewNotification marks the notice of the validator read
+func (ss *StorageSrv) ReadReviewNotification(participantID, id string) error {
+	res := ss.psqlDB.Model(ReviewNotification{}).Where("id = ? AND participant_id = ?", id, participantID).
+		Update("read_at", time.Now().UTC())
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return ErrNotificationNotExists
+	}
+
+	return nil
+}
@@ -27,3 +27,14 @@ func (ss *StorageSrv) UpdateParticipantRole(id string, newRole ParticipantRole)
 	}
 	return nil
 }
+
+func (ss *StorageSrv) GetParticipantsByRole(role ParticipantRole) []*Participant {
+	var participants []*Participant
+	if err := ss.psqlDB.Where("role = ?", role).Find(&participants).Error; err != nil {
+		ss.log.Errorf("while GetParticipantsByRole, err: %v", err)
+
+		return nil
+	}
+
+	return participants
+}
@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"gorm.io/gorm"
+)
+
+// MintPersistentID mints the next identifier of the year for the work, the identifier
+// minted before is returned as is. The format builds the identifier of the sequence number.
+func (ss *StorageSrv) MintPersistentID(workID string, year int, format func(year, sequence int) string) (*PersistentID, error) {
+	persistentID := new(PersistentID)
+	err := ss.psqlDB.Transaction(func(tx *gorm.DB) error {
+		var minted []*PersistentID
+		if err := tx.Where("work_id = ?", workID).Limit(1).Find(&minted).Error; err != nil {
+			return err
+		}
+
+		if len(minted) > 0 {
+			persistentID = minted[0]
+			return nil
+		}
+
+		var last int
+		if err := tx.Model(PersistentID{}).Where("year = ?", year).
+			Select("COALESCE(MAX(sequence), 0)").Scan(&last).Error; err != nil {
+			return err
+		}
+
+		persistentID = &PersistentID{
+			ID:       uuid.New().String(),
+			PID:      format(year, last+1),
+			WorkID:   workID,
+			Year:     year,
+			Sequence: last + 1,
+		}
+
+		return tx.Create(persistentID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return persistentID, nil
+}
+
+// GetPersistentID returns the identifier by its value
+func (ss *StorageSrv) GetPersistentID(pid string) (*PersistentID, error) {
+	persistentID := new(PersistentID)
+	if err := ss.psqlDB.Where("pid = ?", pid).First(persistentID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPIDNotExists
+		}
+
+		return nil, err
+	}
+
+	return persistentID, nil
+}
+
+// GetUnregisteredPersistentIDs returns the identifiers which haven't been registered with the registrar yet
+func (ss *StorageSrv) GetUnregisteredPersistentIDs() (ids []*PersistentID, err error) {
+	err = ss.psqlDB.Where("registered_at IS NULL").Order("created_at").Find(&ids).Error
+	return
+}
+
+// SetPersistentIDRegistered marks the identifier as registered with the registrar
+func (ss *StorageSrv) SetPersistentIDRegistered(id string) error {
+	return ss.psqlDB.Model(PersistentID{}).Where("id = ?", id).
+		Update("registered_at", time.Now().UTC()).Error
+}
+
+// SetPersistentIDURL registers the identifier to resolve to the URL
+func (ss *StorageSrv) SetPersistentIDURL(pid, url string) error {
+	result := ss.psqlDB.Model(PersistentID{}).Where("pid = ?", pid).Update("url", url)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrPIDNotExists
+	}
+
+	return nil
+}
+
+// GetUnidentifiedWorkIDs returns the open works which haven't got the identifier yet
+func (ss *StorageSrv) GetUnidentifiedWorkIDs() (ids []string, err error) {
+	err = ss.psqlDB.Model(ParticipantsWork{}).
+		Where("status = ? AND work_id NOT IN (?)", OpenWorkStatus, ss.psqlDB.Model(PersistentID{}).Select("work_id")).
+		Pluck("work_id", &ids).Error
+	return
+}
+
+// SetWorkPID saves the identifier to the work
+func (ss *StorageSrv) SetWorkPID(ctx context.Context, workID, pid string) error {
+	return ss.updateWorkFields(ctx, workID, bson.M{"pid": pid})
+}
@@ -113,12 +113,13 @@ func (ss *StorageSrv) GetParticipantById(id string) (participant *Participant) {
 	return
 }
 
-func (ss *StorageSrv) createWorkOfParticipant(authorID, workID string) error {
+func (ss *StorageSrv) createWorkOfParticipant(authorID, workID string, status WorkStatus, openAccess bool) error {
 	return ss.psqlDB.Create(&ParticipantsWork{
 		ID:            uuid.New().String(),
 		ParticipantID: authorID,
 		WorkID:        workID,
-		Status:        ReviewWorkStatus,
+		OpenAccess:    openAccess,
+		Status:        status,
 		CreatedAt:     time.Now().UTC(),
 	}).Error
 }
@@ -137,8 +138,14 @@ func (ss *StorageSrv) getPendingWorks() []*ParticipantsWork {
 
 // updatewWorkStatus ...
 func (ss *StorageSrv) updatewWorkStatus(workID string, newStatus WorkStatus) error {
+	toUpdate := map[string]interface{}{"status": newStatus}
+	// the time of the final decision on the work
+	if newStatus == OpenWorkStatus || newStatus == DeclinedWorkStatus {
+		toUpdate["decided_at"] = time.Now().UTC()
+	}
+
 	return ss.psqlDB.Model(ParticipantsWork{}).Where("work_id = ?", workID).
-		Update("status", newStatus).Error
+		Updates(toUpdate).Error
 }
 
 func (ss *StorageSrv) getParticipantIDOrNil(participantAddress string) string {
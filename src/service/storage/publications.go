@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"gorm.io/gorm"
+)
+
+// SetWorkCID saves the CID and the fingerprint of the pinned revision of the work,
+// the revision isn't published on-chain yet
+func (ss *StorageSrv) SetWorkCID(ctx context.Context, workID, cid, fingerprint string) error {
+	return ss.updateWorkFields(ctx, workID, bson.M{"cid": cid, "fingerprint": fingerprint, "published": false})
+}
+
+// AddWorkRevision saves the fingerprint of the work as its next revision, the revision
+// of the same fingerprint as the latest one is the latest one with the new CID. The
+// revisions added concurrently violate the unique index of the revision numbers.
+func (ss *StorageSrv) AddWorkRevision(revision *WorkRevision) error {
+	return ss.psqlDB.Transaction(func(tx *gorm.DB) error {
+		last := new(WorkRevision)
+		err := tx.Where("work_id = ?", revision.WorkID).Order("revision DESC").First(last).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			last = nil
+		case err != nil:
+			return err
+		}
+
+		if last != nil && last.Fingerprint == revision.Fingerprint {
+			last.CID, last.License, last.LicenseText = revision.CID, revision.License, revision.LicenseText
+			*revision = *last
+
+			return tx.Model(WorkRevision{}).Where("id = ?", last.ID).Updates(map[string]interface{}{
+				"cid":          last.CID,
+				"license":      last.License,
+				"license_text": last.LicenseText,
+			}).Error
+		}
+
+		revision.ID = uuid.New().String()
+		revision.Revision = 1
+		if last != nil {
+			revision.Revision = last.Revision + 1
+		}
+
+		return tx.Create(revision).Error
+	})
+}
+
+// GetLastWorkRevision returns the latest revision of the work
+func (ss *StorageSrv) GetLastWorkRevision(workID string) (*WorkRevision, error) {
+	revision := new(WorkRevision)
+	if err := ss.psqlDB.Where("work_id = ?", workID).Order("revision DESC").
+		First(revision).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWorkRevisionNotExists
+		}
+
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+// SetWorkRevisionTx saves the tx of the on-chain publication of the revision
+func (ss *StorageSrv) SetWorkRevisionTx(id, txHash string) error {
+	return ss.psqlDB.Model(WorkRevision{}).Where("id = ?", id).
+		Update("tx_hash", txHash).Error
+}
+
+// SetWorkPublished marks the work as published on-chain, the tx hash is empty
+// if the work had been published before it was pinned
+func (ss *StorageSrv) SetWorkPublished(ctx context.Context, workID, txHash string) error {
+	return ss.updateWorkFields(ctx, workID, bson.M{
+		"published":       true,
+		"publish_tx_hash": txHash,
+		"published_at":    time.Now().UTC(),
+	})
+}
+
+// GetUnpublishedWorkIDs returns the approved works which haven't been published on-chain yet
+// or have been updated since, the works published before the time was saved are returned too
+func (ss *StorageSrv) GetUnpublishedWorkIDs(ctx context.Context) ([]string, error) {
+	var openIDs []string
+	if err := ss.psqlDB.Model(ParticipantsWork{}).Where("status = ?", OpenWorkStatus).
+		Pluck("work_id", &openIDs).Error; err != nil {
+		return nil, err
+	}
+
+	if len(openIDs) == 0 {
+		return nil, nil
+	}
+
+	collection := ss.mongoDB.Collection(collectionWorks)
+	if collection == nil {
+		panic(fmt.Errorf("works collection is nil"))
+	}
+
+	cur, err := collection.Find(ctx, bson.M{
+		"id": bson.M{"$in": openIDs},
+		"$or": bson.A{
+			bson.M{"published": bson.M{"$ne": true}},
+			bson.M{"$expr": bson.M{"$gt": bson.A{"$updated_at", "$published_at"}}},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var works []*Work
+	if err := cur.All(ctx, &works); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(works))
+	for _, work := range works {
+		ids = append(ids, work.ID)
+	}
+
+	return ids, nil
+}
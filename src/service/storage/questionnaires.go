@@ -0,0 +1,228 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	defaultScaleMin = 0
+	defaultScaleMax = 4
+	// the attempts to store the next version of the template published concurrently
+	maxTemplateVersionAttempts = 3
+)
+
+func addIndexOnQuestionnaireTemplates(templates *mongo.Collection) {
+	unique := true
+	model := mongo.IndexModel{Keys: bson.D{{Key: "id", Value: 1}, {Key: "version", Value: 1}},
+		Options: &options.IndexOptions{Unique: &unique}}
+	if _, err := templates.Indexes().CreateOne(context.Background(), model); err != nil {
+		panic(err)
+	}
+}
+
+// Validate verifies the template itself before it is stored
+func (t *QuestionnaireTemplate) Validate() error {
+	if t.Science == "" {
+		return fmt.Errorf("template science is null")
+	}
+
+	if t.ScaleMin == 0 && t.ScaleMax == 0 {
+		t.ScaleMin, t.ScaleMax = defaultScaleMin, defaultScaleMax
+	}
+
+	if t.ScaleMin >= t.ScaleMax {
+		return fmt.Errorf("wrong template scale: [%d, %d]", t.ScaleMin, t.ScaleMax)
+	}
+
+	if len(t.Questions) == 0 {
+		return fmt.Errorf("template has no questions")
+	}
+
+	ids := make(map[string]bool, len(t.Questions))
+	for _, question := range t.Questions {
+		if question == nil || question.ID == "" {
+			return fmt.Errorf("question id is null")
+		}
+
+		if ids[question.ID] {
+			return fmt.Errorf("duplicated question id: %s", question.ID)
+		}
+		ids[question.ID] = true
+
+		if len(question.Text) == 0 {
+			return fmt.Errorf("question %s has no text", question.ID)
+		}
+
+		if question.Weight < 0 {
+			return fmt.Errorf("question %s has negative weight: %v", question.ID, question.Weight)
+		}
+
+		if question.Weight == 0 {
+			question.Weight = 1
+		}
+	}
+
+	return nil
+}
+
+// Check verifies the questionnaire answers against the template.
+// complete demands all required questions to be answered(on submitting).
+func (t *QuestionnaireTemplate) Check(questionnaire *WorkReviewQuestionnaire, complete bool) error {
+	if questionnaire.TemplateID != t.ID || questionnaire.TemplateVersion != t.Version {
+		return fmt.Errorf("questionnaire references template %s(v%d), expected %s(v%d)",
+			questionnaire.TemplateID, questionnaire.TemplateVersion, t.ID, t.Version)
+	}
+
+	questions := make(map[string]*QuestionnaireQuestion, len(t.Questions))
+	for _, question := range t.Questions {
+		questions[question.ID] = question
+	}
+
+	for id, score := range questionnaire.Questions {
+		if _, ok := questions[id]; !ok {
+			return fmt.Errorf("unknown question: %s", id)
+		}
+
+		if score < t.ScaleMin || score > t.ScaleMax {
+			return fmt.Errorf("score %d of question %s is out of scale [%d, %d]", score, id, t.ScaleMin, t.ScaleMax)
+		}
+	}
+
+	if !complete {
+		return nil
+	}
+
+	for _, question := range t.Questions {
+		if _, ok := questionnaire.Questions[question.ID]; question.Required && !ok {
+			return fmt.Errorf("required question %s is not answered", question.ID)
+		}
+	}
+
+	return nil
+}
+
+// Score returns the weighted questionnaire score normalized to [0, 1],
+// ok is false when none of the questions were answered.
+func (t *QuestionnaireTemplate) Score(questionnaire *WorkReviewQuestionnaire) (score float64, ok bool) {
+	var weights float64
+	for _, question := range t.Questions {
+		value, answered := questionnaire.Questions[question.ID]
+		if !answered {
+			continue
+		}
+
+		score += question.Weight * float64(value-t.ScaleMin) / float64(t.ScaleMax-t.ScaleMin)
+		weights += question.Weight
+	}
+
+	if weights == 0 {
+		return 0, false
+	}
+
+	return score / weights, true
+}
+
+// PutQuestionnaireTemplate stores the template as the next version,
+// a new template ID is generated if it is absent. The version taken by
+// the concurrent publication is detected by the unique index and the next one is tried.
+func (ss *StorageSrv) PutQuestionnaireTemplate(ctx context.Context, template *QuestionnaireTemplate) error {
+	collection := ss.mongoDB.Collection(collectionQuestionnaireTemplates)
+	if collection == nil {
+		panic(fmt.Errorf("questionnaire_templates collection is nil"))
+	}
+
+	if template.ID == "" {
+		template.ID = uuid.New().String()
+		template.Version = 1
+		template.CreatedAt = time.Now().UTC()
+		_, err := collection.InsertOne(ctx, template)
+
+		return err
+	}
+
+	for attempt := 1; ; attempt++ {
+		latest, err := ss.GetQuestionnaireTemplate(ctx, template.ID, 0)
+		if err != nil {
+			return err
+		}
+
+		template.Version = latest.Version + 1
+		template.CreatedAt = time.Now().UTC()
+		_, err = collection.InsertOne(ctx, template)
+		if !mongo.IsDuplicateKeyError(err) || attempt == maxTemplateVersionAttempts {
+			return err
+		}
+	}
+}
+
+// GetQuestionnaireTemplate returns the certain version of the template, version 0 means the latest one
+func (ss *StorageSrv) GetQuestionnaireTemplate(ctx context.Context, id string, version int64) (template *QuestionnaireTemplate, err error) {
+	collection := ss.mongoDB.Collection(collectionQuestionnaireTemplates)
+	if collection == nil {
+		panic(fmt.Errorf("questionnaire_templates collection is nil"))
+	}
+
+	filter := bson.M{"id": id}
+	if version > 0 {
+		filter["version"] = version
+	}
+
+	opts := options.FindOne().SetSort(bson.M{"version": -1})
+	if err = collection.FindOne(ctx, filter, opts).Decode(&template); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrTemplateNotExists
+		}
+
+		ss.log.Errorf("while finding questionnaire template, err: %v", err)
+
+		return nil, err
+	}
+
+	return
+}
+
+// GetLatestQuestionnaireTemplates returns the latest version of every template,
+// filtered by science if it is not empty. The science may change between the versions,
+// so the latest versions are picked before the filter.
+func (ss *StorageSrv) GetLatestQuestionnaireTemplates(ctx context.Context, science string) (templates []*QuestionnaireTemplate, err error) {
+	collection := ss.mongoDB.Collection(collectionQuestionnaireTemplates)
+	if collection == nil {
+		panic(fmt.Errorf("questionnaire_templates collection is nil"))
+	}
+
+	cur, err := collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"version": -1}))
+	if err != nil {
+		ss.log.Errorf("while finding questionnaire templates, err: %v", err)
+
+		return nil, err
+	}
+
+	var all []*QuestionnaireTemplate
+	if err = cur.All(ctx, &all); err != nil {
+		ss.log.Errorf("while decoding questionnaire templates, err: %v", err)
+
+		return nil, err
+	}
+
+	templates = []*QuestionnaireTemplate{}
+	seen := make(map[string]bool)
+	for _, template := range all {
+		if seen[template.ID] {
+			continue
+		}
+		seen[template.ID] = true
+		if science != "" && template.Science != science {
+			continue
+		}
+		templates = append(templates, template)
+	}
+
+	return templates, nil
+}
@@ -0,0 +1,177 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gorm.io/gorm"
+)
+
+// SetWorkReferences replaces the reference list of the work, the references are numbered in the order
+func (ss *StorageSrv) SetWorkReferences(workID string, references []*WorkReference) error {
+	return ss.psqlDB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("work_id = ?", workID).Delete(WorkReference{}).Error; err != nil {
+			return err
+		}
+
+		if len(references) == 0 {
+			return nil
+		}
+
+		for i, reference := range references {
+			reference.ID = uuid.New().String()
+			reference.WorkID = workID
+			reference.Position = i + 1
+		}
+
+		return tx.Create(&references).Error
+	})
+}
+
+// GetWorkReferences returns the reference list of the work
+func (ss *StorageSrv) GetWorkReferences(workID string) (references []*WorkReference, err error) {
+	err = ss.psqlDB.Where("work_id = ?", workID).Order("position").Find(&references).Error
+	return
+}
+
+// citingWorks selects the open works citing the work
+func (ss *StorageSrv) citingWorks() *gorm.DB {
+	return ss.psqlDB.Model(WorkReference{}).
+		Joins("JOIN participants_works ON participants_works.work_id = work_references.work_id").
+		Where("participants_works.status = ?", OpenWorkStatus)
+}
+
+// GetCitingWorkIDs returns the open works citing the work
+func (ss *StorageSrv) GetCitingWorkIDs(workID string) (ids []string, err error) {
+	err = ss.citingWorks().Where("work_references.cited_work_id = ?", workID).
+		Distinct().Pluck("work_references.work_id", &ids).Error
+	return
+}
+
+// CountCitations returns the number of the open works citing each of the works
+func (ss *StorageSrv) CountCitations(workIDs ...string) (map[string]int, error) {
+	counts := make(map[string]int, len(workIDs))
+	if len(workIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		CitedWorkID string
+		Citations   int
+	}
+	if err := ss.citingWorks().Where("work_references.cited_work_id IN ?", workIDs).
+		Select("work_references.cited_work_id, COUNT(DISTINCT work_references.work_id) AS citations").
+		Group("work_references.cited_work_id").Scan(&rows).Error; err != nil {
+		return counts, err
+	}
+
+	for _, row := range rows {
+		counts[row.CitedWorkID] = row.Citations
+	}
+
+	return counts, nil
+}
+
+// SetWorksCitations sets the number of the citations of the listed works counted by one query
+func (ss *StorageSrv) SetWorksCitations(works []*WorkResponse) {
+	workIDs := make([]string, 0, len(works))
+	for _, workResp := range works {
+		workIDs = append(workIDs, workResp.Work.ID)
+	}
+
+	citations, err := ss.CountCitations(workIDs...)
+	if err != nil {
+		ss.log.Errorf("while counting the citations of the works, err: %v", err)
+	}
+
+	for _, workResp := range works {
+		workResp.Citations = citations[workResp.Work.ID]
+	}
+}
+
+// GetOpenWorkIDsOfParticipant returns the open works of the author
+func (ss *StorageSrv) GetOpenWorkIDsOfParticipant(participantID string) (ids []string, err error) {
+	err = ss.psqlDB.Model(ParticipantsWork{}).
+		Where("participant_id = ? AND status = ?", participantID, OpenWorkStatus).
+		Pluck("work_id", &ids).Error
+	return
+}
+
+// ReferenceQuery selects the works the parsed references may refer to
+type ReferenceQuery struct {
+	PIDs    []string // the identifiers of the works cited by the DOI or the URL
+	WorkIDs []string // the works cited by the URL of their pages
+	Titles  []string // the cited titles searched by the text index
+}
+
+// the max number of the works found by the text index for each cited title
+const referenceCandidates = 5
+
+// GetReferenceCandidates returns the open works without the content to match the parsed references
+// against: the works cited by the identifiers and the best matches of the cited titles
+func (ss *StorageSrv) GetReferenceCandidates(ctx context.Context, query *ReferenceQuery) ([]*Work, error) {
+	collection := ss.mongoDB.Collection(collectionWorks)
+	if collection == nil {
+		panic(fmt.Errorf("works collection is nil"))
+	}
+
+	var filters []bson.M
+	if len(query.PIDs) > 0 || len(query.WorkIDs) > 0 {
+		filters = append(filters, bson.M{"$or": bson.A{
+			bson.M{"pid": bson.M{"$in": query.PIDs}},
+			bson.M{"id": bson.M{"$in": query.WorkIDs}},
+		}})
+	}
+	for _, title := range query.Titles {
+		filters = append(filters, bson.M{"$text": bson.M{"$search": title}})
+	}
+
+	found := make(map[string]*Work)
+	for _, filter := range filters {
+		opts := options.Find().SetProjection(bson.M{"id": 1, "name": 1, "pid": 1, "created_at": 1})
+		if _, ok := filter["$text"]; ok {
+			// the best matches of the title only
+			score := bson.M{"$meta": "textScore"}
+			opts.SetProjection(bson.M{"id": 1, "name": 1, "pid": 1, "created_at": 1, "score": score}).
+				SetSort(bson.M{"score": score}).SetLimit(referenceCandidates)
+		}
+
+		cur, err := collection.Find(ctx, filter, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		var works []*Work
+		if err := cur.All(ctx, &works); err != nil {
+			return nil, err
+		}
+		for _, work := range works {
+			found[work.ID] = work
+		}
+	}
+
+	if len(found) == 0 {
+		return nil, nil
+	}
+
+	workIDs := make([]string, 0, len(found))
+	for workID := range found {
+		workIDs = append(workIDs, workID)
+	}
+
+	var openIDs []string
+	if err := ss.psqlDB.Model(ParticipantsWork{}).Where("status = ? AND work_id IN ?", OpenWorkStatus, workIDs).
+		Pluck("work_id", &openIDs).Error; err != nil {
+		return nil, err
+	}
+
+	works := make([]*Work, 0, len(openIDs))
+	for _, workID := range openIDs {
+		works = append(works, found[workID])
+	}
+
+	return works, nil
+}
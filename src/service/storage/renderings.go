@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/envelope"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"gorm.io/gorm/clause"
+)
+
+// GetWorkRendering returns the cached rendering of the content revision, nil if it hasn't been rendered yet
+func (ss *StorageSrv) GetWorkRendering(work *Work, contentHash, output string) (*WorkRendering, error) {
+	var renderings []*WorkRendering
+	if err := ss.psqlDB.Where("work_id = ? AND content_hash = ? AND output = ?", work.ID, contentHash, output).
+		Limit(1).Find(&renderings).Error; err != nil {
+		return nil, err
+	}
+
+	if len(renderings) == 0 {
+		return nil, nil
+	}
+
+	key, err := ss.WorkDataKey(work)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := envelope.Open(key, renderings[0].Cipher)
+	if err != nil {
+		return nil, err
+	}
+	renderings[0].Body = string(body)
+
+	return renderings[0], nil
+}
+
+// SaveWorkRendering caches the rendering sealed by the data key of the work,
+// the concurrent rendering of the same revision is kept
+func (ss *StorageSrv) SaveWorkRendering(work *Work, rendering *WorkRendering) error {
+	key, err := ss.WorkDataKey(work)
+	if err != nil {
+		return err
+	}
+
+	if rendering.Cipher, err = envelope.Seal(key, []byte(rendering.Body)); err != nil {
+		return err
+	}
+
+	rendering.ID = uuid.New().String()
+	return ss.psqlDB.Clauses(clause.OnConflict{DoNothing: true}).Create(rendering).Error
+}
+
+// DeleteWorkRendering drops the rendering from the cache
+func (ss *StorageSrv) DeleteWorkRendering(id string) error {
+	return ss.psqlDB.Delete(WorkRendering{}, "id = ?", id).Error
+}
+
+// sealPlainRenderings encrypts the renderings cached in plain text before they were sealed,
+// the rendering which can't be sealed is dropped from the cache
+func (ss *StorageSrv) sealPlainRenderings(ctx context.Context) error {
+	migrator := ss.psqlDB.Migrator()
+	if !migrator.HasColumn(WorkRendering{}, "body") {
+		return nil
+	}
+
+	var plain []struct {
+		ID     string
+		WorkID string
+		Body   string
+	}
+	if err := ss.psqlDB.Model(WorkRendering{}).Select("id, work_id, body").
+		Where("cipher IS NULL").Scan(&plain).Error; err != nil {
+		return err
+	}
+
+	collection := ss.mongoDB.Collection(collectionWorks)
+	if collection == nil {
+		panic(fmt.Errorf("works collection is nil"))
+	}
+
+	for _, rendering := range plain {
+		work := new(Work)
+		err := collection.FindOne(ctx, bson.M{"id": rendering.WorkID}).Decode(work)
+
+		var key, cipher []byte
+		if err == nil {
+			key, err = ss.WorkDataKey(work)
+		}
+		if err == nil {
+			cipher, err = envelope.Seal(key, []byte(rendering.Body))
+		}
+		if err != nil {
+			ss.log.Warnf("sealPlainRenderings: rendering %s of work %s is dropped, err: %v", rendering.ID, rendering.WorkID, err)
+			if err := ss.psqlDB.Delete(WorkRendering{}, "id = ?", rendering.ID).Error; err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := ss.psqlDB.Model(WorkRendering{}).Where("id = ?", rendering.ID).
+			Update("cipher", cipher).Error; err != nil {
+			return err
+		}
+	}
+
+	return migrator.DropColumn(WorkRendering{}, "body")
+}
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (ss *StorageSrv) GetParticipantsWorkReviewByID(id string) (review *ParticipantsWorkReview, err error) {
+	if err = ss.psqlDB.Where("id = ?", id).Find(&review).Error; err != nil {
+		return nil, err
+	}
+
+	if review.ID == "" {
+		return nil, ErrReviewNotExists
+	}
+
+	return
+}
+
+func (ss *StorageSrv) GetParticipantsWorkReviewsByValidator(validatorID string) (reviews []*ParticipantsWorkReview, err error) {
+	if err := ss.psqlDB.Where("participant_id = ?", validatorID).Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+	return
+}
+
+// RateReview creates or replaces the participant's rating of the review
+func (ss *StorageSrv) RateReview(reviewID, participantID string, rating int64) error {
+	return ss.psqlDB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "review_id"}, {Name: "participant_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rating"}),
+	}).Create(&ReviewRating{
+		ID:            uuid.New().String(),
+		ReviewID:      reviewID,
+		ParticipantID: participantID,
+		Rating:        rating,
+	}).Error
+}
+
+// GetReviewRatings returns all ratings of the reviews
+func (ss *StorageSrv) GetReviewRatings(reviewIDs []string) (ratings []*ReviewRating, err error) {
+	if len(reviewIDs) == 0 {
+		return nil, nil
+	}
+
+	if err := ss.psqlDB.Where("review_id IN ?", reviewIDs).Find(&ratings).Error; err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (ss *StorageSrv) SaveValidatorReputation(reputation *ValidatorReputation) error {
+	return ss.psqlDB.Save(reputation).Error
+}
+
+// GetValidatorReputation returns nil if the reputation hasn't been calculated yet
+func (ss *StorageSrv) GetValidatorReputation(participantID string) (*ValidatorReputation, error) {
+	reputation := new(ValidatorReputation)
+	if err := ss.psqlDB.Where("participant_id = ?", participantID).First(reputation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return reputation, nil
+}
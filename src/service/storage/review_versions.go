@@ -0,0 +1,169 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func addIndexOnWorkReviewVersions(versions *mongo.Collection) {
+	unique := true
+	model := mongo.IndexModel{Keys: bson.D{{Key: "review_id", Value: 1}, {Key: "version", Value: 1}},
+		Options: &options.IndexOptions{Unique: &unique}}
+	if _, err := versions.Indexes().CreateOne(context.Background(), model); err != nil {
+		panic(err)
+	}
+}
+
+// reviewBodyHash returns the hex encoded sha256 of the review body
+func reviewBodyHash(body *WorkReviewBody) (string, error) {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(raw)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// the counter of the versions kept in the review document, it's incremented by the same
+// update which saves the review, so every saved state gets its own version number
+const reviewVersionCounter = "version_seq"
+
+// saveWorkReview updates the review and takes its next version number atomically
+func (ss *StorageSrv) saveWorkReview(ctx context.Context, update bson.M, reviewID string) (int64, error) {
+	collection := ss.mongoDB.Collection(collectionWorkReviews)
+	if collection == nil {
+		panic(fmt.Errorf("work_reviews collection is nil"))
+	}
+
+	update["$inc"] = bson.M{reviewVersionCounter: 1}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After).
+		SetProjection(bson.M{reviewVersionCounter: 1})
+
+	var counter struct {
+		Version int64 `bson:"version_seq"`
+	}
+	if err := collection.FindOneAndUpdate(ctx, bson.M{"id": reviewID}, update, opts).Decode(&counter); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return 0, ErrReviewNotExists
+		}
+
+		return 0, err
+	}
+
+	return counter.Version, nil
+}
+
+// seedReviewVersionCounters sets the counters of the reviews versioned before the counter
+// was kept to their latest versions
+func (ss *StorageSrv) seedReviewVersionCounters(ctx context.Context) error {
+	versions := ss.mongoDB.Collection(collectionWorkReviewVersions)
+	reviews := ss.mongoDB.Collection(collectionWorkReviews)
+	if versions == nil || reviews == nil {
+		panic(fmt.Errorf("work_reviews collection is nil"))
+	}
+
+	cur, err := versions.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{"_id": "$review_id", "latest": bson.M{"$max": "$version"}}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	var latest []struct {
+		ReviewID string `bson:"_id"`
+		Version  int64  `bson:"latest"`
+	}
+	if err := cur.All(ctx, &latest); err != nil {
+		return err
+	}
+
+	for _, review := range latest {
+		if _, err := reviews.UpdateOne(ctx,
+			bson.M{"id": review.ReviewID, reviewVersionCounter: bson.M{"$exists": false}},
+			bson.M{"$set": bson.M{reviewVersionCounter: review.Version}}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// appendWorkReviewVersion stores the state of the review saved as the version
+func (ss *StorageSrv) appendWorkReviewVersion(ctx context.Context, review *WorkReview, number int64) error {
+	collection := ss.mongoDB.Collection(collectionWorkReviewVersions)
+	if collection == nil {
+		panic(fmt.Errorf("work_review_versions collection is nil"))
+	}
+
+	hash, err := reviewBodyHash(review.Body)
+	if err != nil {
+		return err
+	}
+
+	version := &WorkReviewVersion{
+		ID:        uuid.New().String(),
+		ReviewID:  review.ID,
+		WorkID:    review.WorkID,
+		Version:   number,
+		Status:    review.Status,
+		Body:      review.Body,
+		BodyHash:  hash,
+		CreatedAt: time.Now().UTC(),
+	}
+	if _, err := collection.InsertOne(ctx, version); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetWorkReviewVersions returns all versions of the review in the chronological order
+func (ss *StorageSrv) GetWorkReviewVersions(ctx context.Context, reviewID string) (versions []*WorkReviewVersion, err error) {
+	collection := ss.mongoDB.Collection(collectionWorkReviewVersions)
+	if collection == nil {
+		panic(fmt.Errorf("work_review_versions collection is nil"))
+	}
+
+	cur, err := collection.Find(ctx, bson.M{"review_id": reviewID},
+		options.Find().SetSort(bson.D{{Key: "version", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &versions); err != nil {
+		ss.log.Errorf("while decoding review versions, err: %v", err)
+
+		return nil, err
+	}
+
+	return
+}
+
+// GetWorkReviewVersion returns the certain version of the review
+func (ss *StorageSrv) GetWorkReviewVersion(ctx context.Context, reviewID string, version int64) (*WorkReviewVersion, error) {
+	collection := ss.mongoDB.Collection(collectionWorkReviewVersions)
+	if collection == nil {
+		panic(fmt.Errorf("work_review_versions collection is nil"))
+	}
+
+	reviewVersion := new(WorkReviewVersion)
+	if err := collection.FindOne(ctx, bson.M{"review_id": reviewID, "version": version}).Decode(reviewVersion); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrReviewVersionNotExists
+		}
+
+		return nil, err
+	}
+
+	return reviewVersion, nil
+}
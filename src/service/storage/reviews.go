@@ -10,7 +10,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func (ss *StorageSrv) CreateParticipantsWorkReview(validatorID, workID string) (review *ParticipantsWorkReview, err error) {
+func (ss *StorageSrv) CreateParticipantsWorkReview(validatorID, workID string, dueAt time.Time) (review *ParticipantsWorkReview, err error) {
 	ss.psqlDB.Where("participant_id = ? AND work_id = ?", validatorID, workID).Find(&review)
 	if review.ID != "" {
 		return review, nil
@@ -20,6 +20,7 @@ func (ss *StorageSrv) CreateParticipantsWorkReview(validatorID, workID string) (
 		ParticipantID: validatorID,
 		WorkID:        workID,
 		Status:        WorkReviewInProgress,
+		DueAt:         dueAt,
 	}
 	if err := ss.psqlDB.Create(review).Error; err != nil {
 		return nil, err
@@ -48,13 +49,13 @@ func (ss *StorageSrv) UpdateParticipantsWorkReviewStatus(id string, newStatus Wo
 		Update("status", newStatus).Error
 }
 
-func (ss *StorageSrv) UpdateOrCreateWorkReview(ctx context.Context, validatorID string, review *WorkReview) (*WorkReview, error) {
+func (ss *StorageSrv) UpdateOrCreateWorkReview(ctx context.Context, validatorID string, review *WorkReview, dueAt time.Time) (*WorkReview, error) {
 	collection := ss.mongoDB.Collection(collectionWorkReviews)
 	if collection == nil {
 		panic(fmt.Errorf("work_reviews collection is nil"))
 	}
 
-	participantsReview, err := ss.CreateParticipantsWorkReview(validatorID, review.WorkID)
+	participantsReview, err := ss.CreateParticipantsWorkReview(validatorID, review.WorkID, dueAt)
 	if err != nil {
 		return nil, nil
 	}
@@ -68,10 +69,22 @@ func (ss *StorageSrv) UpdateOrCreateWorkReview(ctx context.Context, validatorID
 	if currentReview == nil {
 		review.ID = participantsReview.ID
 		review.CreatedAt = time.Now().UTC()
+		review.DueAt = participantsReview.DueAt
 		if _, err := collection.InsertOne(ctx, review); err != nil {
 			return nil, err
 		}
 
+		number, err := ss.saveWorkReview(ctx, bson.M{}, review.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := ss.appendWorkReviewVersion(ctx, review, number); err != nil {
+			ss.log.Errorf("while appending the review version, err: %v", err)
+
+			return nil, err
+		}
+
 		return review, nil
 	}
 
@@ -84,6 +97,8 @@ func (ss *StorageSrv) UpdateOrCreateWorkReview(ctx context.Context, validatorID
 			if currentReview.Body.Questionnaire == nil {
 				currentReview.Body.Questionnaire = &WorkReviewQuestionnaire{}
 			}
+			currentReview.Body.Questionnaire.TemplateID = review.Body.Questionnaire.TemplateID
+			currentReview.Body.Questionnaire.TemplateVersion = review.Body.Questionnaire.TemplateVersion
 			currentReview.Body.Questionnaire.Questions = review.Body.Questionnaire.Questions
 		}
 	}
@@ -100,6 +115,56 @@ func (ss *StorageSrv) UpdateOrCreateWorkReview(ctx context.Context, validatorID
 	return currentReview, nil
 }
 
+// GetUnfinishedParticipantsWorkReviews returns the reviews which are in progress or overdue
+func (ss *StorageSrv) GetUnfinishedParticipantsWorkReviews() (reviews []*ParticipantsWorkReview, err error) {
+	if err := ss.psqlDB.Where("status IN ?", []WorkReviewStatus{WorkReviewInProgress, WorkReviewOverdue}).
+		Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+	return
+}
+
+// UpdateParticipantsWorkReviewDeadline updates the deadline related fields of the review
+func (ss *StorageSrv) UpdateParticipantsWorkReviewDeadline(review *ParticipantsWorkReview) error {
+	toUpdate := map[string]interface{}{
+		"status":      review.Status,
+		"due_at":      review.DueAt,
+		"reminded_at": review.RemindedAt,
+		"overdue_at":  review.OverdueAt,
+		"updated_at":  time.Now().UTC(),
+	}
+	return ss.psqlDB.Model(ParticipantsWorkReview{}).Where("id = ?", review.ID).
+		UpdateColumns(toUpdate).Error
+}
+
+// GetValidatorReviewStats counts the validator's reviews by their statuses
+func (ss *StorageSrv) GetValidatorReviewStats(validatorID string) (*ValidatorReviewStats, error) {
+	var reviews []*ParticipantsWorkReview
+	if err := ss.psqlDB.Where("participant_id = ?", validatorID).Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+
+	stats := &ValidatorReviewStats{Total: int64(len(reviews))}
+	for _, review := range reviews {
+		switch review.Status {
+		case WorkReviewInProgress:
+			stats.InProgress++
+		case WorkReviewOverdue:
+			stats.Overdue++
+		case WorkReviewReassigned:
+			stats.Reassigned++
+		default:
+			stats.Finished++
+		}
+
+		if review.OverdueAt != nil {
+			stats.Late++
+		}
+	}
+
+	return stats, nil
+}
+
 // SubmitWorkReview ...
 func (ss *StorageSrv) SubmitWorkReview(ctx context.Context, participantReview *ParticipantsWorkReview) error {
 	if err := ss.UpdateParticipantsWorkReviewStatus(participantReview.ID, participantReview.Status); err != nil {
@@ -249,15 +314,18 @@ func (ss *StorageSrv) GetReviewsByWorkId(workID string) (reviews []*Participants
 
 func (ss *StorageSrv) UpdateWorkReview(ctx context.Context, review *WorkReview) error {
 	review.UpdatedAt = time.Now().UTC()
-	filter := bson.M{"id": review.ID}
-	collection := ss.mongoDB.Collection(collectionWorkReviews)
-	if collection == nil {
-		panic(fmt.Errorf("authors collection is nil"))
+
+	// every saved state of the review is kept for the audit
+	number, err := ss.saveWorkReview(ctx, bson.M{"$set": review}, review.ID)
+	if err != nil {
+		return err
 	}
 
-	update := bson.M{
-		"$set": review,
+	if err := ss.appendWorkReviewVersion(ctx, review, number); err != nil {
+		ss.log.Errorf("while appending the review version, err: %v", err)
+
+		return err
 	}
 
-	return collection.FindOneAndUpdate(ctx, filter, update).Err()
+	return nil
 }
@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetUnrewardedReviews returns the reviews with the given statuses which have no reward yet
+func (ss *StorageSrv) GetUnrewardedReviews(statuses []WorkReviewStatus) (reviews []*ParticipantsWorkReview, err error) {
+	if err := ss.psqlDB.Where("status IN ? AND id NOT IN (?)", statuses,
+		ss.psqlDB.Model(&RewardLedger{}).Select("review_id")).Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+	return
+}
+
+// CreateReward adds the reward in the ledger, the second reward of the same review is ignored
+func (ss *StorageSrv) CreateReward(reward *RewardLedger) error {
+	return ss.psqlDB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "review_id"}},
+		DoNothing: true,
+	}).Create(reward).Error
+}
+
+// GetUnpaidRewards returns the oldest pending and failed rewards which can be paid out again
+func (ss *StorageSrv) GetUnpaidRewards(maxAttempts, limit int64) (rewards []*RewardLedger, err error) {
+	if err := ss.psqlDB.Where("status IN ? AND attempts < ?",
+		[]RewardStatus{RewardPending, RewardFailed}, maxAttempts).
+		Order("created_at").Limit(int(limit)).Find(&rewards).Error; err != nil {
+		return nil, err
+	}
+	return
+}
+
+// errPayoutTaken rolls back the payout of the rewards which are paid out by another transfer
+var errPayoutTaken = errors.New("the rewards are paid out by another transfer")
+
+// StartRewardsPayout marks the unpaid rewards paying by the transfer identified by the payout id,
+// started is false if some of them are paid out by another transfer
+func (ss *StorageSrv) StartRewardsPayout(ids []string, payoutID string) (started bool, err error) {
+	err = ss.psqlDB.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(RewardLedger{}).Where("id IN ? AND status IN ?", ids, []RewardStatus{RewardPending, RewardFailed}).
+			UpdateColumns(map[string]interface{}{
+				"status":    RewardPaying,
+				"payout_id": payoutID,
+				"error":     "",
+				"attempts":  clause.Expr{SQL: "attempts + 1"},
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected != int64(len(ids)) {
+			return errPayoutTaken
+		}
+
+		return nil
+	})
+	if errors.Is(err, errPayoutTaken) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// GetPayingRewards returns the rewards whose transfers have no saved result, e.g. the service
+// has stopped during the transfer
+func (ss *StorageSrv) GetPayingRewards() (rewards []*RewardLedger, err error) {
+	err = ss.psqlDB.Where("status = ?", RewardPaying).Order("created_at").Find(&rewards).Error
+	return
+}
+
+// UpdateRewardsPayout updates the payout result of the rewards
+func (ss *StorageSrv) UpdateRewardsPayout(ids []string, status RewardStatus, txHash, payoutErr string) error {
+	toUpdate := map[string]interface{}{
+		"status":  status,
+		"tx_hash": txHash,
+		"error":   payoutErr,
+	}
+	if status == RewardPaid {
+		toUpdate["paid_at"] = time.Now().UTC()
+	}
+
+	return ss.psqlDB.Model(RewardLedger{}).Where("id IN ?", ids).UpdateColumns(toUpdate).Error
+}
+
+func (ss *StorageSrv) GetParticipantRewards(participantID string) (rewards []*RewardLedger, err error) {
+	if err := ss.psqlDB.Where("participant_id = ?", participantID).
+		Order("created_at desc").Find(&rewards).Error; err != nil {
+		return nil, err
+	}
+	return
+}
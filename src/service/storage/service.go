@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/SeaOfWisdom/sow_library/src/config"
+	"github.com/SeaOfWisdom/sow_library/src/service/envelope"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"gorm.io/gorm"
@@ -22,6 +23,8 @@ type StorageSrv struct {
 	psqlDB *gorm.DB
 	/* MongoDB */
 	mongoDB *mongo.Database
+	/* wraps the data keys of the works content, nil if the content isn't encrypted */
+	keyring *envelope.Keyring
 
 	// collections
 	//	worksCollection *mongo.Collection
@@ -49,16 +52,50 @@ func NewStorageSrv(cfg *config.Config, log *log.Logger, postresDB *gorm.DB, mong
 		panic(fmt.Errorf("validators collection is nil"))
 	}
 
+	collection = mongoDB.Collection(collectionDiplomas)
+	if collection == nil {
+		panic(fmt.Errorf("diplomas collection is nil"))
+	}
+
 	collection = mongoDB.Collection(collectionWorkReviews)
 	if collection == nil {
 		panic(fmt.Errorf("work_reviews collection is nil"))
 	}
 
+	collection = mongoDB.Collection(collectionWorkReviewVersions)
+	if collection == nil {
+		panic(fmt.Errorf("work_review_versions collection is nil"))
+	}
+	addIndexOnWorkReviewVersions(collection)
+
+	collection = mongoDB.Collection(collectionQuestionnaireTemplates)
+	if collection == nil {
+		panic(fmt.Errorf("questionnaire_templates collection is nil"))
+	}
+	addIndexOnQuestionnaireTemplates(collection)
+
 	ss := &StorageSrv{
 		log:     log,
 		psqlDB:  postresDB,
 		mongoDB: mongoDB,
 	}
+	if cfg.ContentMasterKey != "" {
+		keyring, err := envelope.NewKeyring(cfg.ContentMasterKey)
+		if err != nil {
+			panic(err)
+		}
+		ss.keyring = keyring
+	} else {
+		// the stored content can't be read or encrypted without the key
+		hasContent, err := ss.hasWorksContent(ctx)
+		if err != nil {
+			panic(err)
+		}
+		if hasContent {
+			panic(fmt.Errorf("the content master key is required, the works have the content"))
+		}
+		log.Warnf("the content master key is null, the content of the works can't be stored")
+	}
 	// go postgreSQL migrations
 	if err := ss.psqlDB.AutoMigrate(Participant{}); err != nil {
 		panic(err)
@@ -79,6 +116,54 @@ func NewStorageSrv(cfg *config.Config, log *log.Logger, postresDB *gorm.DB, mong
 	if err := ss.psqlDB.AutoMigrate(ParticipantsWorkReview{}); err != nil {
 		panic(err)
 	}
+
+	if err := ss.psqlDB.AutoMigrate(ReviewNotification{}); err != nil {
+		panic(err)
+	}
+
+	if err := ss.psqlDB.AutoMigrate(ReviewRating{}); err != nil {
+		panic(err)
+	}
+
+	if err := ss.psqlDB.AutoMigrate(ValidatorReputation{}); err != nil {
+		panic(err)
+	}
+
+	if err := ss.psqlDB.AutoMigrate(RewardLedger{}); err != nil {
+		panic(err)
+	}
+
+	if err := ss.psqlDB.AutoMigrate(BlobRecord{}); err != nil {
+		panic(err)
+	}
+
+	if err := ss.psqlDB.AutoMigrate(DocumentExtraction{}); err != nil {
+		panic(err)
+	}
+
+	if err := ss.psqlDB.AutoMigrate(ExtractionPage{}); err != nil {
+		panic(err)
+	}
+
+	if err := ss.psqlDB.AutoMigrate(WorkRevision{}); err != nil {
+		panic(err)
+	}
+
+	if err := ss.psqlDB.AutoMigrate(WorkRendering{}); err != nil {
+		panic(err)
+	}
+
+	if err := ss.psqlDB.AutoMigrate(PersistentID{}); err != nil {
+		panic(err)
+	}
+
+	if err := ss.psqlDB.AutoMigrate(WorkReference{}); err != nil {
+		panic(err)
+	}
+
+	if err := ss.psqlDB.AutoMigrate(WorkDeposit{}); err != nil {
+		panic(err)
+	}
 	// create admins from the config if they don't exist
 	for nickName, address := range config.AdminAddresses {
 		if err := ss.createAdmin(nickName, address); err != nil {
@@ -90,6 +175,18 @@ func NewStorageSrv(cfg *config.Config, log *log.Logger, postresDB *gorm.DB, mong
 		panic(err)
 	}
 
+	if err := ss.seedReviewVersionCounters(ctx); err != nil {
+		panic(err)
+	}
+
+	if err := ss.sealPlainRenderings(context.Background()); err != nil {
+		panic(err)
+	}
+
+	if err := ss.encryptPlainWorks(context.Background()); err != nil {
+		panic(err)
+	}
+
 	return ss
 }
 
@@ -100,7 +197,7 @@ func (ss *StorageSrv) CreateWork(ctx context.Context, authorID string, work *Wor
 		return "", err
 	}
 
-	if err := ss.createWorkOfParticipant(authorID, workID); err != nil {
+	if err := ss.createWorkOfParticipant(authorID, workID, ReviewWorkStatus, work.OpenAccess); err != nil {
 		return "", err
 	}
 
@@ -121,6 +218,10 @@ func (ss *StorageSrv) buildWorkResponse(
 		work.AuthorID = author.ID
 	}
 	work.Price = defaultWei // wei
+	if work.OpenAccess {
+		work.Price = "0"
+	}
+	work.License = LicenseOf(work)
 	workResp = new(WorkResponse)
 	workResp.Work = work
 	workResp.Author = &AuthorResponse{
@@ -135,6 +236,9 @@ func (ss *StorageSrv) buildWorkResponse(
 
 	if !showContent {
 		workResp.Work.Content = nil
+	} else if err := ss.openContent(workResp.Work); err != nil {
+		ss.log.Errorf("while decrypting the content of the work %s, err: %v", work.ID, err)
+		workResp.Work.Content = nil
 	}
 
 	workResp.Bookmarked = bookmarked
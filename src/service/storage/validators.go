@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func (ss *StorageSrv) CreateValidator(ctx context.Context, validatorID, emailAddress, name, surname string) error {
@@ -21,6 +23,8 @@ func (ss *StorageSrv) CreateValidator(ctx context.Context, validatorID, emailAdd
 		Surname:      surname,
 		EmailAddress: emailAddress,
 		CreatedAt:    time.Now().UTC(),
+		// the role is granted after the verification of the documents
+		ApplicationStatus: ValidatorApplied,
 	}
 
 	if _, err := collection.InsertOne(ctx, validator); err != nil {
@@ -73,3 +77,89 @@ func (ss *StorageSrv) UpdateValidatorInfo(ctx context.Context, validator *Valida
 
 	return collection.FindOneAndUpdate(ctx, filter, update).Err()
 }
+
+// GetValidatorApplications returns the validators with the application status,
+// the applications waiting for the verification are returned if the status is empty.
+func (ss *StorageSrv) GetValidatorApplications(ctx context.Context, status ValidatorApplicationStatus) (validators []*Validator, err error) {
+	collection := ss.mongoDB.Collection(collectionValidators)
+	if collection == nil {
+		panic(fmt.Errorf("validators collection is nil"))
+	}
+
+	filter := bson.M{"application_status": status}
+	if status == "" {
+		filter = bson.M{"application_status": bson.M{
+			"$in": []ValidatorApplicationStatus{ValidatorDocumentsSubmitted, ValidatorUnderVerification},
+		}}
+	}
+
+	cur, err := collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &validators); err != nil {
+		ss.log.Errorf("while decoding validators, err: %v", err)
+
+		return nil, err
+	}
+
+	return
+}
+
+func (ss *StorageSrv) CreateDiploma(ctx context.Context, diploma *Diploma) error {
+	collection := ss.mongoDB.Collection(collectionDiplomas)
+	if collection == nil {
+		panic(fmt.Errorf("diplomas collection is nil"))
+	}
+
+	diploma.ID = uuid.New().String()
+	diploma.CreatedAt = time.Now().UTC()
+	diploma.UpdatedAt = diploma.CreatedAt
+
+	if _, err := collection.InsertOne(ctx, diploma); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ss *StorageSrv) GetDiploma(ctx context.Context, id string) (*Diploma, error) {
+	collection := ss.mongoDB.Collection(collectionDiplomas)
+	if collection == nil {
+		panic(fmt.Errorf("diplomas collection is nil"))
+	}
+
+	diploma := new(Diploma)
+	if err := collection.FindOne(ctx, bson.M{"id": id}).Decode(diploma); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrDiplomaNotExists
+		}
+
+		return nil, err
+	}
+
+	return diploma, nil
+}
+
+// GetValidatorDiplomas returns the validator's diplomas, the latest ones first
+func (ss *StorageSrv) GetValidatorDiplomas(ctx context.Context, validatorID string) (diplomas []*Diploma, err error) {
+	collection := ss.mongoDB.Collection(collectionDiplomas)
+	if collection == nil {
+		panic(fmt.Errorf("diplomas collection is nil"))
+	}
+
+	cur, err := collection.Find(ctx, bson.M{"validator_id": validatorID},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &diplomas); err != nil {
+		ss.log.Errorf("while decoding diplomas, err: %v", err)
+
+		return nil, err
+	}
+
+	return
+}
@@ -13,22 +13,45 @@ import (
 
 // PutWork returns workID(uuid) or error
 func (ss *StorageSrv) PutWork(ctx context.Context, work *Work) (string, error) {
+	return ss.putWork(ctx, work, ReviewWorkStatus)
+}
+
+func (ss *StorageSrv) putWork(ctx context.Context, work *Work, status WorkStatus) (string, error) {
 	work.CreatedAt = time.Now().UTC()
 	work.ID = uuid.New().String()
-	work.Status = ReviewWorkStatus
+	work.Status = status
 	collection := ss.mongoDB.Collection(collectionWorks)
 	if collection == nil {
 		panic(fmt.Errorf("works collection is nil"))
 	}
 
-	if _, err := collection.InsertOne(ctx, work); err != nil {
+	// the caller keeps the plain text content
+	stored := *work
+	content, err := ss.sealContent(&stored)
+	if err != nil {
+		return "", err
+	}
+	stored.Content = content
+
+	if _, err := collection.InsertOne(ctx, &stored); err != nil {
 		return "", err
 	}
+	work.ContentKey = stored.ContentKey
+
 	return work.ID, nil
 }
 
 // Returns the certain work by id
 func (ss *StorageSrv) GetWorkByID(ctx context.Context, workID string) (response *WorkResponse, err error) {
+	return ss.getWorkByID(ctx, workID, true)
+}
+
+// GetWorkInfoByID returns the work without the content, the content isn't decrypted
+func (ss *StorageSrv) GetWorkInfoByID(ctx context.Context, workID string) (*WorkResponse, error) {
+	return ss.getWorkByID(ctx, workID, false)
+}
+
+func (ss *StorageSrv) getWorkByID(ctx context.Context, workID string, showContent bool) (response *WorkResponse, err error) {
 	// postgres work
 	participantsWork, err := ss.GetParticipantWorkByID(workID)
 	if err != nil {
@@ -61,7 +84,7 @@ func (ss *StorageSrv) GetWorkByID(ctx context.Context, workID string) (response
 	participant := ss.GetParticipantById(participantsWork.ParticipantID)
 
 	// TODO
-	return ss.buildWorkResponse(mongoWork[0], author, participant, true, false), nil
+	return ss.buildWorkResponse(mongoWork[0], author, participant, showContent, false), nil
 }
 
 // Returns all works
@@ -121,6 +144,8 @@ func (ss *StorageSrv) GetAllWorks(ctx context.Context, readerAddress string) (re
 		}
 	}
 
+	ss.SetWorksCitations(response)
+
 	return response, nil
 }
 
@@ -145,7 +170,12 @@ func (ss *StorageSrv) GetWorkByKeyWords(ctx context.Context, readerAddress strin
 		return nil, err
 	}
 
+	reader := ss.GetParticipantById(readerID)
 	for _, work := range participantsWorks {
+		// the drafts are found by their authors only
+		if work.Status == DraftWorkStatus && work.ParticipantID != readerID {
+			continue
+		}
 		for _, mWork := range mongoWorks {
 			if work.WorkID == mWork.ID {
 				// get author info
@@ -156,16 +186,17 @@ func (ss *StorageSrv) GetWorkByKeyWords(ctx context.Context, readerAddress strin
 				participant := ss.GetParticipantById(work.ParticipantID)
 				// the participant status is Reader just to show the annotation and
 				// other preview information of work
-				purchased := false
-				if ss.PurchasedWorkOrNot(readerID, work.ID) || readerID == author.ID {
-					purchased = true
-				}
+				purchased := ss.PurchasedWorkOrNot(readerID, work.WorkID)
+				// the content is decrypted for the readers who have access to it only
+				_, content := work.IsShow(reader, purchased)
 				response = append(response,
-					ss.buildWorkResponse(mWork, author, participant, purchased, ss.BookmarkedWorkOrNot(readerID, mWork.ID)),
+					ss.buildWorkResponse(mWork, author, participant, content, ss.BookmarkedWorkOrNot(readerID, mWork.ID)),
 				)
 			}
 		}
 	}
+	ss.SetWorksCitations(response)
+
 	return response, nil
 }
 
@@ -203,13 +234,15 @@ func (ss *StorageSrv) GetPurchasedWorks(ctx context.Context, readerAddress strin
 		// get author info
 		author, err := ss.GetAuthorById(ctx, mWork.AuthorID)
 		if err != nil {
-			ss.log.Error("while getting the inforamationa about author with id %s, err: %v", mWork.AuthorID, err)
+			ss.log.Errorf("while getting the inforamationa about author with id %s, err: %v", mWork.AuthorID, err)
 		}
 		response = append(response,
 			ss.buildWorkResponse(mWork, author, authorBasicInfo, true, ss.BookmarkedWorkOrNot(readerID, mWork.ID)),
 		)
 	}
 
+	ss.SetWorksCitations(response)
+
 	return response, nil
 }
 
@@ -251,18 +284,22 @@ func (ss *StorageSrv) GetWorksByAuthorAddress(
 		return nil, err
 	}
 
+	reader := ss.GetParticipantById(readerID)
 	for _, partWork := range participantWorks {
+		if partWork.Status == DraftWorkStatus && partWork.ParticipantID != readerID {
+			continue
+		}
 		for _, work := range works {
 			if partWork.WorkID == work.ID {
-				purchased := false
-				if ss.PurchasedWorkOrNot(readerID, work.ID) || readerID == author.ID {
-					purchased = true
-				}
-				response = append(response, ss.buildWorkResponse(work, author, participant, purchased, ss.BookmarkedWorkOrNot(readerID, readerID)))
+				purchased := ss.PurchasedWorkOrNot(readerID, work.ID)
+				_, content := partWork.IsShow(reader, purchased)
+				response = append(response, ss.buildWorkResponse(work, author, participant, content, ss.BookmarkedWorkOrNot(readerID, readerID)))
 			}
 		}
 	}
 
+	ss.SetWorksCitations(response)
+
 	return response, nil
 }
 
@@ -305,6 +342,8 @@ func (ss *StorageSrv) GetPendingWorks(ctx context.Context) (response []*WorkResp
 			}
 		}
 	}
+	ss.SetWorksCitations(response)
+
 	return
 }
 
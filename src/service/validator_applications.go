@@ -0,0 +1,276 @@
+package srv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+	contractor "github.com/SeaOfWisdom/sow_proto/contractor-srv"
+)
+
+// sides of the diploma scans
+const (
+	ForwardDiplomaSide  = "forward"
+	BackwardDiplomaSide = "backward"
+)
+
+// getValidatorApplication returns the participant with his application, nil if he hasn't applied
+func (ls *LibrarySrv) getValidatorApplication(ctx context.Context, web3Address string) (*storage.Participant, *storage.Validator, error) {
+	participant, err := ls.storage.GetParticipantByAddress(web3Address)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	validator, err := ls.storage.GetValidatorById(ctx, participant.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if validator == nil {
+		return nil, nil, ErrNoValidatorApplication
+	}
+
+	return participant, validator, nil
+}
+
+// SubmitValidatorDiploma saves the diploma recognized by OCR and moves the application to the verification queue
+func (ls *LibrarySrv) SubmitValidatorDiploma(ctx context.Context, web3Address string, diploma *storage.Diploma) (*storage.Diploma, error) {
+	_, validator, err := ls.getValidatorApplication(ctx, web3Address)
+	if err != nil {
+		ls.log.Errorf("SubmitValidatorDiploma: error get application of %s, err: %v", web3Address, err)
+
+		return nil, err
+	}
+
+	if validator.ApplicationStatus != storage.ValidatorApplied &&
+		validator.ApplicationStatus != storage.ValidatorDocumentsSubmitted {
+		return nil, ErrWrongApplicationStatus
+	}
+
+	// the scans are moved to the blob store
+	var blobIDs []string
+	for _, image := range []*storage.DocumentImage{diploma.ForwardImage, diploma.BackwardImage} {
+		if image == nil {
+			continue
+		}
+
+		record, err := ls.saveBlob(ctx, validator.ID, storage.DiplomaBlob, image.Name, image.Data, "")
+		if err != nil {
+			ls.log.Errorf("SubmitValidatorDiploma: error save diploma scan, err: %v", err)
+
+			return nil, err
+		}
+
+		image.BlobID = record.ID
+		image.ContentType = record.ContentType
+		image.Size = record.Size
+		image.Data = nil
+		blobIDs = append(blobIDs, record.ID)
+	}
+
+	diploma.ValidatorID = validator.ID
+	if err := ls.storage.CreateDiploma(ctx, diploma); err != nil {
+		ls.log.Errorf("SubmitValidatorDiploma: error create diploma, err: %v", err)
+
+		return nil, err
+	}
+
+	if err := ls.storage.LinkBlobsToDiploma(blobIDs, diploma.ID); err != nil {
+		ls.log.Errorf("SubmitValidatorDiploma: error link scans to diploma %s, err: %v", diploma.ID, err)
+	}
+
+	validator.DiplomaID = diploma.ID
+	validator.ApplicationStatus = storage.ValidatorDocumentsSubmitted
+	if err := ls.storage.UpdateValidatorInfo(ctx, validator); err != nil {
+		ls.log.Errorf("SubmitValidatorDiploma: error update validator, err: %v", err)
+
+		return nil, err
+	}
+
+	return diploma, nil
+}
+
+// GetValidatorApplication returns the application with all uploaded diplomas
+func (ls *LibrarySrv) GetValidatorApplication(ctx context.Context, web3Address string) (*storage.ValidatorApplication, error) {
+	participant, validator, err := ls.getValidatorApplication(ctx, web3Address)
+	if err != nil {
+		ls.log.Errorf("GetValidatorApplication: error get application of %s, err: %v", web3Address, err)
+
+		return nil, err
+	}
+
+	diplomas, err := ls.storage.GetValidatorDiplomas(ctx, validator.ID)
+	if err != nil {
+		ls.log.Errorf("GetValidatorApplication: error get diplomas of %s, err: %v", web3Address, err)
+
+		return nil, err
+	}
+
+	return &storage.ValidatorApplication{
+		BasicInfo:     participant,
+		ValidatorInfo: validator,
+		Diplomas:      diplomas,
+	}, nil
+}
+
+// GetValidatorApplications returns the applications with the status, the pending ones by default
+func (ls *LibrarySrv) GetValidatorApplications(ctx context.Context, status storage.ValidatorApplicationStatus) ([]*storage.ValidatorApplication, error) {
+	validators, err := ls.storage.GetValidatorApplications(ctx, status)
+	if err != nil {
+		ls.log.Errorf("GetValidatorApplications: error get applications, err: %v", err)
+
+		return nil, err
+	}
+
+	applications := make([]*storage.ValidatorApplication, 0, len(validators))
+	for _, validator := range validators {
+		applications = append(applications, &storage.ValidatorApplication{
+			BasicInfo:     ls.storage.GetParticipantById(validator.ID),
+			ValidatorInfo: validator,
+		})
+	}
+
+	return applications, nil
+}
+
+// GetDiplomaImage returns the forward or backward scan of the applicant's diploma
+func (ls *LibrarySrv) GetDiplomaImage(ctx context.Context, web3Address, diplomaID, side string) (*storage.DocumentImage, io.ReadCloser, error) {
+	_, validator, err := ls.getValidatorApplication(ctx, web3Address)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	diploma, err := ls.storage.GetDiploma(ctx, diplomaID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if diploma.ValidatorID != validator.ID {
+		return nil, nil, storage.ErrDiplomaNotExists
+	}
+
+	var image *storage.DocumentImage
+	switch side {
+	case ForwardDiplomaSide:
+		image = diploma.ForwardImage
+	case BackwardDiplomaSide:
+		image = diploma.BackwardImage
+	default:
+		return nil, nil, fmt.Errorf("unknown diploma side %q", side)
+	}
+
+	if image == nil {
+		return nil, nil, storage.ErrDiplomaNotExists
+	}
+
+	// the scans uploaded before the blob store existed
+	if image.BlobID == "" {
+		return image, io.NopCloser(bytes.NewReader(image.Data)), nil
+	}
+
+	record, err := ls.storage.GetBlobRecord(image.BlobID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := ls.blobs.Get(ctx, record.Key)
+	if err != nil {
+		ls.log.Errorf("GetDiplomaImage: error get blob %s, err: %v", record.Key, err)
+
+		return nil, nil, err
+	}
+
+	return image, data, nil
+}
+
+// StartValidatorVerification takes the application with the submitted documents for the verification
+func (ls *LibrarySrv) StartValidatorVerification(ctx context.Context, adminAddress, web3Address string) (*storage.Validator, error) {
+	_, validator, err := ls.getValidatorApplication(ctx, web3Address)
+	if err != nil {
+		ls.log.Errorf("StartValidatorVerification: error get application of %s, err: %v", web3Address, err)
+
+		return nil, err
+	}
+
+	if validator.ApplicationStatus != storage.ValidatorDocumentsSubmitted {
+		return nil, ErrWrongApplicationStatus
+	}
+
+	validator.ApplicationStatus = storage.ValidatorUnderVerification
+	validator.VerifiedBy = adminAddress
+	if err := ls.storage.UpdateValidatorInfo(ctx, validator); err != nil {
+		ls.log.Errorf("StartValidatorVerification: error update validator, err: %v", err)
+
+		return nil, err
+	}
+
+	return validator, nil
+}
+
+// DecideValidatorApplication approves or rejects the application under the verification,
+// the approved applicant gets the validator role in the library and in SowLibrary.
+func (ls *LibrarySrv) DecideValidatorApplication(
+	ctx context.Context,
+	adminAddress,
+	web3Address string,
+	approved bool,
+	reason string,
+) (*storage.Validator, error) {
+	participant, validator, err := ls.getValidatorApplication(ctx, web3Address)
+	if err != nil {
+		ls.log.Errorf("DecideValidatorApplication: error get application of %s, err: %v", web3Address, err)
+
+		return nil, err
+	}
+
+	if validator.ApplicationStatus != storage.ValidatorUnderVerification {
+		return nil, ErrWrongApplicationStatus
+	}
+
+	now := time.Now().UTC()
+	validator.VerifiedBy = adminAddress
+	validator.VerifiedAt = &now
+
+	if !approved {
+		validator.ApplicationStatus = storage.ValidatorRejected
+		validator.RejectionReason = reason
+		if err := ls.storage.UpdateValidatorInfo(ctx, validator); err != nil {
+			ls.log.Errorf("DecideValidatorApplication: error update validator, err: %v", err)
+
+			return nil, err
+		}
+
+		return validator, nil
+	}
+
+	// let's add the new reviewer into SowLibrary
+	txHash, err := ls.contractorSrv.MakeReviewer(ctx, &contractor.AccountRequest{
+		Address: web3Address,
+	})
+	if err != nil {
+		ls.log.Errorf("DecideValidatorApplication: make reviewer, err: %v", err)
+
+		return nil, fmt.Errorf("make reviewer, err: %v", err)
+	}
+
+	ls.log.Infof("the new reviewer was added with tx: %s", txHash)
+
+	if err = ls.storage.UpdateParticipantRole(participant.ID, storage.ValidatorRole); err != nil {
+		ls.log.Errorf("DecideValidatorApplication: error update participant role, err: %v", err)
+
+		return nil, fmt.Errorf("while updating the participant's role, err: %s", err)
+	}
+
+	validator.ApplicationStatus = storage.ValidatorApproved
+	validator.RejectionReason = ""
+	if err := ls.storage.UpdateValidatorInfo(ctx, validator); err != nil {
+		ls.log.Errorf("DecideValidatorApplication: error update validator, err: %v", err)
+
+		return nil, err
+	}
+
+	return validator, nil
+}
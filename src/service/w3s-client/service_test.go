@@ -0,0 +1,109 @@
+package w3scli
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+const testToken = "test-token"
+
+// w3sStandIn accepts the uploads by the test token and returns the CID of the file named
+// in the header, the uploaded files are kept by their names
+func w3sStandIn(uploaded map[string][]byte) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/upload" {
+			http.NotFound(w, r)
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer "+testToken {
+			http.Error(w, `{"name":"HTTPError","message":"Unauthorized"}`, http.StatusUnauthorized)
+			return
+		}
+
+		name, err := url.PathUnescape(r.Header.Get("X-Name"))
+		if err != nil || name == "" {
+			http.Error(w, `{"name":"HTTPError","message":"bad name"}`, http.StatusBadRequest)
+			return
+		}
+
+		content, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		uploaded[name] = content
+
+		json.NewEncoder(w).Encode(uploadResponse{CID: "bafy-" + strings.TrimSuffix(name, ".json")})
+	})
+}
+
+func TestPublish(t *testing.T) {
+	uploaded := map[string][]byte{}
+	server := httptest.NewServer(w3sStandIn(uploaded))
+	defer server.Close()
+
+	client, err := NewClient(server.URL+"/", testToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := []byte(`{"id":"work","content":"c2VhbGVk"}`)
+	cid, err := client.Publish(context.Background(), "the work.json", content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cid != "bafy-the work" {
+		t.Errorf("CID = %q, want bafy-the work", cid)
+	}
+
+	if string(uploaded["the work.json"]) != string(content) {
+		t.Errorf("uploaded %q, want %q", uploaded["the work.json"], content)
+	}
+}
+
+func TestPublishErrors(t *testing.T) {
+	server := httptest.NewServer(w3sStandIn(map[string][]byte{}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "wrong-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Publish(context.Background(), "work.json", []byte("{}")); err == nil ||
+		!strings.Contains(err.Error(), "401") {
+		t.Errorf("Publish with the wrong token err = %v", err)
+	}
+
+	noCID := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"carCid":"bagb"}`))
+	}))
+	defer noCID.Close()
+
+	client, err = NewClient(noCID.URL, testToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Publish(context.Background(), "work.json", []byte("{}")); err == nil {
+		t.Error("Publish without the CID in the response succeeded")
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	if _, err := NewClient("https://api.web3.storage", ""); err == nil {
+		t.Error("the client without token is created")
+	}
+
+	if _, err := NewClient("api.web3.storage", testToken); err == nil {
+		t.Error("the client with the URL without scheme is created")
+	}
+}
@@ -0,0 +1,124 @@
+package watermark
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+var ErrNotStampable = errors.New("the PDF can't be stamped")
+
+// the document information key of the mark
+const pdfMarkKey = "SOWWatermark"
+
+var (
+	startXRef   = regexp.MustCompile(`startxref\s+(\d+)\s+%%EOF\s*$`)
+	trailerRoot = regexp.MustCompile(`/Root\s+(\d+\s+\d+\s+R)`)
+	trailerSize = regexp.MustCompile(`/Size\s+(\d+)`)
+	trailerID   = regexp.MustCompile(`/ID\s*(\[[^\]]*\])`)
+	pdfMark     = regexp.MustCompile(`/` + pdfMarkKey + `\s*<([0-9a-fA-F]+)>`)
+)
+
+// StampPDF appends the incremental update with the document information carrying the mark,
+// the content of the document isn't changed. The update has the cross-reference section
+// of the same kind as the last one: the classic table or the cross-reference stream.
+func (m *Marker) StampPDF(data []byte, mark *Mark) ([]byte, error) {
+	payload, err := m.payload(mark)
+	if err != nil {
+		return nil, err
+	}
+
+	// the last cross-reference section is either the classic trailer or the xref stream dictionary
+	start := startXRef.FindSubmatch(data)
+	if start == nil {
+		return nil, fmt.Errorf("%w: startxref is not found", ErrNotStampable)
+	}
+
+	prev, err := strconv.ParseInt(string(start[1]), 10, 64)
+	if err != nil || prev <= 0 || prev >= int64(len(data)) {
+		return nil, fmt.Errorf("%w: wrong startxref", ErrNotStampable)
+	}
+
+	trailer := data[prev:]
+	if bytes.Contains(trailer, []byte("/Encrypt")) {
+		return nil, fmt.Errorf("%w: the document is encrypted", ErrNotStampable)
+	}
+
+	root, size := trailerRoot.FindSubmatch(trailer), trailerSize.FindSubmatch(trailer)
+	if root == nil || size == nil {
+		return nil, fmt.Errorf("%w: the trailer has no root or size", ErrNotStampable)
+	}
+
+	infoNumber, err := strconv.Atoi(string(size[1]))
+	if err != nil {
+		return nil, fmt.Errorf("%w: wrong trailer size", ErrNotStampable)
+	}
+
+	// the identifier of the document is kept by the updates
+	var id string
+	if match := trailerID.FindSubmatch(trailer); match != nil {
+		id = " /ID " + string(match[1])
+	}
+
+	var buf bytes.Buffer
+	buf.Write(data)
+	if data[len(data)-1] != '\n' {
+		buf.WriteByte('\n')
+	}
+
+	infoOffset := buf.Len()
+	fmt.Fprintf(&buf, "%d 0 obj\n<< /Producer (Sea of Wisdom) /%s <%s> >>\nendobj\n",
+		infoNumber, pdfMarkKey, hex.EncodeToString(payload))
+
+	xrefOffset := buf.Len()
+	if !bytes.HasPrefix(bytes.TrimLeft(trailer, " \t\r\n"), []byte("xref")) {
+		writeXRefStream(&buf, infoNumber, infoOffset, xrefOffset, string(root[1]), id, prev)
+	} else {
+		fmt.Fprintf(&buf, "xref\n%d 1\n%010d 00000 n \n", infoNumber, infoOffset)
+		fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root %s /Info %d 0 R%s /Prev %d >>\n",
+			infoNumber+1, root[1], infoNumber, id, prev)
+	}
+	fmt.Fprintf(&buf, "startxref\n%d\n%%%%EOF\n", xrefOffset)
+
+	return buf.Bytes(), nil
+}
+
+// writeXRefStream writes the cross-reference stream of the information object and the stream itself,
+// the entries are the type 1 byte, the 8 bytes offset and the 2 bytes generation
+func writeXRefStream(buf *bytes.Buffer, infoNumber, infoOffset, xrefOffset int, root, id string, prev int64) {
+	var entries bytes.Buffer
+	for _, offset := range []int{infoOffset, xrefOffset} {
+		entries.WriteByte(1)
+		binary.Write(&entries, binary.BigEndian, uint64(offset))
+		entries.Write([]byte{0, 0})
+	}
+
+	fmt.Fprintf(buf, "%d 0 obj\n<< /Type /XRef /Size %d /Index [%d 2] /W [1 8 2] /Root %s /Info %d 0 R%s /Prev %d /Length %d >>\nstream\n",
+		infoNumber+1, infoNumber+2, infoNumber, root, infoNumber, id, prev, entries.Len())
+	buf.Write(entries.Bytes())
+	buf.WriteString("\nendstream\nendobj\n")
+}
+
+// ExtractPDF returns the distinct valid marks stamped to the PDF
+func (m *Marker) ExtractPDF(data []byte) []*Mark {
+	var (
+		marks []*Mark
+		seen  = make(map[Mark]bool)
+	)
+	for _, match := range pdfMark.FindAllSubmatch(data, -1) {
+		payload, err := hex.DecodeString(string(match[1]))
+		if err != nil {
+			continue
+		}
+		if mark, ok := m.parsePayload(payload); ok && !seen[*mark] {
+			seen[*mark] = true
+			marks = append(marks, mark)
+		}
+	}
+
+	return marks
+}
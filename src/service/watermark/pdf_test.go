@@ -0,0 +1,116 @@
+package watermark
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/ledongthuc/pdf"
+)
+
+// classicPDF returns the document with the classic cross-reference table
+func classicPDF(t *testing.T) []byte {
+	t.Helper()
+
+	doc := fpdf.New("P", "mm", "A4", "")
+	doc.AddPage()
+	doc.SetFont("Helvetica", "", 12)
+	doc.Cell(40, 10, "The work")
+
+	var buf bytes.Buffer
+	if err := doc.Output(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+// xrefStreamPDF returns the document with the cross-reference stream as PDF 1.5 writers make it
+func xrefStreamPDF() []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.5\n")
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 200 200] >>",
+	}
+	offsets := make([]int, 0, len(objects)+1)
+	for i, object := range objects {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	offsets = append(offsets, buf.Len())
+
+	var entries bytes.Buffer
+	entries.Write([]byte{0, 0, 0, 0, 0, 0xff, 0xff})
+	for _, offset := range offsets {
+		entries.WriteByte(1)
+		binary.Write(&entries, binary.BigEndian, uint32(offset))
+		entries.Write([]byte{0, 0})
+	}
+
+	fmt.Fprintf(&buf, "4 0 obj\n<< /Type /XRef /Size 5 /W [1 4 2] /Root 1 0 R /ID [<0102> <0102>] /Length %d >>\nstream\n", entries.Len())
+	buf.Write(entries.Bytes())
+	fmt.Fprintf(&buf, "\nendstream\nendobj\nstartxref\n%d\n%%%%EOF\n", offsets[3])
+
+	return buf.Bytes()
+}
+
+func TestStampPDF(t *testing.T) {
+	marker := testMarker(t, "the secret of the library")
+
+	for name, data := range map[string][]byte{
+		"classic":     classicPDF(t),
+		"xref stream": xrefStreamPDF(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			// the original is readable
+			if _, err := pdf.NewReader(bytes.NewReader(data), int64(len(data))); err != nil {
+				t.Fatalf("the original isn't readable: %v", err)
+			}
+
+			stamped, err := marker.StampPDF(data, testMark)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.HasPrefix(stamped, data) {
+				t.Error("the original isn't kept by the stamped PDF")
+			}
+
+			reader, err := pdf.NewReader(bytes.NewReader(stamped), int64(len(stamped)))
+			if err != nil {
+				t.Fatalf("the stamped PDF isn't readable: %v", err)
+			}
+
+			if reader.NumPage() != 1 {
+				t.Errorf("the stamped PDF has %d pages, want 1", reader.NumPage())
+			}
+
+			if reader.Trailer().Key("Info").Key(pdfMarkKey).IsNull() {
+				t.Error("the document information of the stamped PDF has no mark")
+			}
+
+			marks := marker.ExtractPDF(stamped)
+			if len(marks) != 1 || *marks[0] != *testMark {
+				t.Errorf("the marks of the stamped PDF = %v, want %v", marks, testMark)
+			}
+		})
+	}
+}
+
+func TestStampPDFErrors(t *testing.T) {
+	marker := testMarker(t, "the secret of the library")
+
+	if _, err := marker.StampPDF([]byte("not a PDF"), testMark); err == nil {
+		t.Error("the document without startxref is stamped")
+	}
+
+	encrypted := bytes.Replace(classicPDF(t), []byte("trailer\n<<"), []byte("trailer\n<< /Encrypt 9 0 R"), 1)
+	if _, err := marker.StampPDF(encrypted, testMark); err == nil {
+		t.Error("the encrypted document is stamped")
+	}
+}
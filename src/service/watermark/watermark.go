@@ -0,0 +1,201 @@
+package watermark
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrNoWatermark = errors.New("there is no watermark")
+
+// Mark identifies the reader the content was served to
+type Mark struct {
+	ParticipantID string    `json:"participant_id"`
+	ServedAt      time.Time `json:"served_at"`
+}
+
+// Marker embeds and extracts the marks authenticated by the secret of the server,
+// so the marks can't be forged by the readers
+type Marker struct {
+	secret []byte
+}
+
+// the secret is the key of HMAC-SHA256
+const minSecretSize = 16
+
+func NewMarker(secret []byte) (*Marker, error) {
+	if len(secret) < minSecretSize {
+		return nil, fmt.Errorf("the watermark secret must be at least %d bytes", minSecretSize)
+	}
+
+	return &Marker{secret: secret}, nil
+}
+
+// the payload is the participant uuid, the unix time and the truncated HMAC-SHA256 of both
+const (
+	dataSize    = 16 + 8
+	tagSize     = 16
+	payloadSize = dataSize + tagSize
+)
+
+func (m *Marker) tag(data []byte) []byte {
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write(data)
+
+	return mac.Sum(nil)[:tagSize]
+}
+
+func (m *Marker) payload(mark *Mark) ([]byte, error) {
+	id, err := uuid.Parse(mark.ParticipantID)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := make([]byte, 0, payloadSize)
+	payload = append(payload, id[:]...)
+	payload = binary.BigEndian.AppendUint64(payload, uint64(mark.ServedAt.Unix()))
+	payload = append(payload, m.tag(payload)...)
+
+	return payload, nil
+}
+
+func (m *Marker) parsePayload(payload []byte) (*Mark, bool) {
+	if len(payload) != payloadSize {
+		return nil, false
+	}
+
+	data, tag := payload[:dataSize], payload[dataSize:]
+	if !hmac.Equal(m.tag(data), tag) {
+		return nil, false
+	}
+
+	id, err := uuid.FromBytes(data[:16])
+	if err != nil {
+		return nil, false
+	}
+
+	return &Mark{
+		ParticipantID: id.String(),
+		ServedAt:      time.Unix(int64(binary.BigEndian.Uint64(data[16:])), 0).UTC(),
+	}, true
+}
+
+// the zero width characters encode two bits each, the mark is framed
+// by the invisible separators
+var symbols = [4]rune{'\u200b', '\u200c', '\u200d', '\u2060'}
+
+const (
+	markStart = '\u2062'
+	markEnd   = '\u2063'
+)
+
+func symbolValue(r rune) int {
+	for i, symbol := range symbols {
+		if r == symbol {
+			return i
+		}
+	}
+	return -1
+}
+
+func encode(payload []byte) string {
+	var sb strings.Builder
+	sb.WriteRune(markStart)
+	for _, b := range payload {
+		for shift := 6; shift >= 0; shift -= 2 {
+			sb.WriteRune(symbols[(b>>shift)&3])
+		}
+	}
+	sb.WriteRune(markEnd)
+
+	return sb.String()
+}
+
+// EmbedText hides the mark after the first word of every paragraph, so any
+// copied paragraph identifies the reader
+func (m *Marker) EmbedText(text string, mark *Mark) (string, error) {
+	payload, err := m.payload(mark)
+	if err != nil {
+		return "", err
+	}
+	encoded := encode(payload)
+
+	paragraphs := strings.Split(text, "\n\n")
+	for i, paragraph := range paragraphs {
+		if strings.TrimSpace(paragraph) == "" {
+			continue
+		}
+		if at := strings.IndexAny(strings.TrimLeft(paragraph, " \t\n"), " \t\n"); at >= 0 {
+			at += len(paragraph) - len(strings.TrimLeft(paragraph, " \t\n"))
+			paragraphs[i] = paragraph[:at] + encoded + paragraph[at:]
+		} else {
+			paragraphs[i] = paragraph + encoded
+		}
+	}
+
+	return strings.Join(paragraphs, "\n\n"), nil
+}
+
+// StripText removes the marks and the stray zero width characters from the text
+func StripText(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r == markStart || r == markEnd || symbolValue(r) >= 0 {
+			return -1
+		}
+		return r
+	}, text)
+}
+
+// ExtractText returns the distinct valid marks found in the text
+func (m *Marker) ExtractText(text string) []*Mark {
+	var (
+		marks   []*Mark
+		seen    = make(map[Mark]bool)
+		payload []byte
+		inMark  bool
+		bits    int
+		current byte
+	)
+	for _, r := range text {
+		switch {
+		case r == markStart:
+			inMark, payload, bits, current = true, payload[:0], 0, 0
+		case r == markEnd && inMark:
+			inMark = false
+			if mark, ok := m.parsePayload(payload); ok && bits == 0 && !seen[*mark] {
+				seen[*mark] = true
+				marks = append(marks, mark)
+			}
+		case inMark:
+			value := symbolValue(r)
+			if value < 0 {
+				// the mark is broken by a visible character
+				inMark = false
+				continue
+			}
+			current = current<<2 | byte(value)
+			if bits += 2; bits == 8 {
+				payload = append(payload, current)
+				bits, current = 0, 0
+			}
+		}
+	}
+
+	return marks
+}
+
+// EmbedHTML hides the mark at the start of every paragraph of the HTML rendered by the library
+func (m *Marker) EmbedHTML(html string, mark *Mark) (string, error) {
+	payload, err := m.payload(mark)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.ReplaceAll(html, "<p>", "<p>"+encode(payload)), nil
+}
@@ -0,0 +1,93 @@
+package watermark
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+var testMark = &Mark{
+	ParticipantID: "6f1c1d0e-5b7a-4c1e-9d2f-1a2b3c4d5e6f",
+	ServedAt:      time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+}
+
+func testMarker(t *testing.T, secret string) *Marker {
+	t.Helper()
+
+	marker, err := NewMarker([]byte(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return marker
+}
+
+func TestNewMarker(t *testing.T) {
+	if _, err := NewMarker([]byte("short")); err == nil {
+		t.Error("the marker with the short secret is created")
+	}
+}
+
+func TestEmbedText(t *testing.T) {
+	marker := testMarker(t, "the secret of the library")
+	text := "The first paragraph of the work.\n\nThe second one.\n\nLast"
+
+	marked, err := marker.EmbedText(text, testMark)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if StripText(marked) != text {
+		t.Errorf("the stripped text %q isn't the original", StripText(marked))
+	}
+
+	// any copied paragraph identifies the reader
+	paragraphs := strings.Split(marked, "\n\n")
+	marks := marker.ExtractText(paragraphs[1])
+	if len(marks) != 1 || *marks[0] != *testMark {
+		t.Fatalf("the marks of the paragraph = %v, want %v", marks, testMark)
+	}
+
+	if marks := marker.ExtractText(marked); len(marks) != 1 {
+		t.Errorf("the distinct marks of the text = %d, want 1", len(marks))
+	}
+}
+
+func TestEmbedHTML(t *testing.T) {
+	marker := testMarker(t, "the secret of the library")
+
+	marked, err := marker.EmbedHTML("<h1>Work</h1><p>First</p><p>Second</p>", testMark)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if marks := marker.ExtractText(marked); len(marks) != 1 || *marks[0] != *testMark {
+		t.Errorf("the marks of the HTML = %v, want %v", marks, testMark)
+	}
+}
+
+func TestForgedMark(t *testing.T) {
+	marker := testMarker(t, "the secret of the library")
+	forger := testMarker(t, "the guess of the forger")
+
+	// the reader can't mark the content as served to the other reader
+	forged, err := forger.EmbedText("The leaked paragraph.", testMark)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if marks := marker.ExtractText(forged); len(marks) != 0 {
+		t.Errorf("the forged mark is extracted: %v", marks)
+	}
+
+	payload, err := marker.payload(testMark)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the participant of the genuine mark can't be changed
+	payload[0] ^= 1
+	if _, ok := marker.parsePayload(payload); ok {
+		t.Error("the tampered payload is parsed")
+	}
+}
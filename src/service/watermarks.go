@@ -0,0 +1,135 @@
+package srv
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/SeaOfWisdom/sow_library/src/config"
+	"github.com/SeaOfWisdom/sow_library/src/service/ingest"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+	"github.com/SeaOfWisdom/sow_library/src/service/watermark"
+)
+
+// WatermarkTrace is the account the leaked copy was served to
+type WatermarkTrace struct {
+	*watermark.Mark
+	Participant *storage.Participant `json:"participant,omitempty"`
+}
+
+// the label of the watermark secret derived from the content master key
+const watermarkSecretLabel = "sow-library watermark"
+
+// watermarkSecret returns the configured secret of the watermarks or derives it from
+// the content master key, nil if neither is configured
+func watermarkSecret(cfg *config.Config) []byte {
+	if cfg.WatermarkSecret != "" {
+		return []byte(cfg.WatermarkSecret)
+	}
+
+	if cfg.ContentMasterKey == "" {
+		return nil
+	}
+
+	mac := hmac.New(sha256.New, []byte(cfg.ContentMasterKey))
+	mac.Write([]byte(watermarkSecretLabel))
+
+	return mac.Sum(nil)
+}
+
+// readerMark returns the mark of the reader the content is served to
+func (ls *LibrarySrv) readerMark(readerAddress string) (*watermark.Mark, error) {
+	reader, err := ls.storage.GetParticipantByAddress(readerAddress)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWatermarkFailed, err)
+	}
+
+	return &watermark.Mark{ParticipantID: reader.ID, ServedAt: time.Now().UTC()}, nil
+}
+
+// watermarkWork hides the mark of the reader in the content of the work served by the content
+// endpoint, the lists aren't marked. The authors get their own works unmarked, the content
+// which can't be marked isn't served.
+func (ls *LibrarySrv) watermarkWork(readerAddress string, work *storage.WorkResponse) error {
+	if ls.marker == nil || work == nil || work.Work.Content == nil || work.Work.Content.WorkData == "" {
+		return nil
+	}
+
+	if isWorkAuthor(work, readerAddress) {
+		return nil
+	}
+
+	mark, err := ls.readerMark(readerAddress)
+	if err != nil {
+		return err
+	}
+
+	marked, err := ls.marker.EmbedText(work.Work.Content.WorkData, mark)
+	if err != nil {
+		ls.log.Errorf("watermarkWork: error mark work %s, err: %v", work.Work.ID, err)
+
+		return fmt.Errorf("%w: %v", ErrWatermarkFailed, err)
+	}
+	// the stored content isn't changed
+	work.Work.Content = &storage.WorkContent{WorkData: marked, Format: work.Work.Content.Format}
+
+	return nil
+}
+
+// watermarkBlob stamps the PDF of the work served to the reader, the PDF
+// which can't be stamped isn't served
+func (ls *LibrarySrv) watermarkBlob(reader *storage.Participant, record *storage.BlobRecord, data io.ReadCloser) (*storage.BlobRecord, io.ReadCloser, error) {
+	if ls.marker == nil || record.ContentType != ingest.PDFContentType || record.ParticipantID == reader.ID {
+		return record, data, nil
+	}
+	defer data.Close()
+
+	original, err := io.ReadAll(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stamped, err := ls.marker.StampPDF(original, &watermark.Mark{ParticipantID: reader.ID, ServedAt: time.Now().UTC()})
+	if err != nil {
+		ls.log.Errorf("watermarkBlob: error stamp blob %s, err: %v", record.ID, err)
+
+		return nil, nil, fmt.Errorf("%w: %v", ErrWatermarkFailed, err)
+	}
+
+	marked := *record
+	marked.Size = int64(len(stamped))
+
+	return &marked, io.NopCloser(bytes.NewReader(stamped)), nil
+}
+
+// TraceWatermark extracts the marks from the leaked text or PDF and identifies the accounts it was served to
+func (ls *LibrarySrv) TraceWatermark(ctx context.Context, contentType string, data []byte) ([]*WatermarkTrace, error) {
+	if ls.marker == nil {
+		return nil, watermark.ErrNoWatermark
+	}
+
+	var marks []*watermark.Mark
+	if contentType == ingest.PDFContentType {
+		marks = ls.marker.ExtractPDF(data)
+	} else {
+		marks = ls.marker.ExtractText(string(data))
+	}
+
+	if len(marks) == 0 {
+		return nil, watermark.ErrNoWatermark
+	}
+
+	traces := make([]*WatermarkTrace, 0, len(marks))
+	for _, mark := range marks {
+		traces = append(traces, &WatermarkTrace{
+			Mark:        mark,
+			Participant: ls.storage.GetParticipantById(mark.ParticipantID),
+		})
+	}
+
+	return traces, nil
+}
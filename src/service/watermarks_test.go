@@ -0,0 +1,39 @@
+package srv
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/SeaOfWisdom/sow_library/src/log"
+	"github.com/SeaOfWisdom/sow_library/src/service/ingest"
+	"github.com/SeaOfWisdom/sow_library/src/service/storage"
+	"github.com/SeaOfWisdom/sow_library/src/service/watermark"
+)
+
+// TestWatermarkBlobRefused checks the PDF which can't be stamped isn't served unmarked
+func TestWatermarkBlobRefused(t *testing.T) {
+	marker, err := watermark.NewMarker([]byte("test-watermark-secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ls := &LibrarySrv{log: log.NewLogger(), marker: marker}
+
+	reader := &storage.Participant{ID: "reader"}
+	record := &storage.BlobRecord{ID: "blob", ParticipantID: "author", ContentType: ingest.PDFContentType}
+
+	marked, data, err := ls.watermarkBlob(reader, record, io.NopCloser(strings.NewReader("not a PDF")))
+	if !errors.Is(err, ErrWatermarkFailed) {
+		t.Fatalf("err = %v, want %v", err, ErrWatermarkFailed)
+	}
+	if marked != nil || data != nil {
+		t.Error("the unstamped PDF is served")
+	}
+
+	// the owner gets the document as is
+	record.ParticipantID = reader.ID
+	if _, data, err := ls.watermarkBlob(reader, record, io.NopCloser(strings.NewReader("not a PDF"))); err != nil || data == nil {
+		t.Errorf("the owner's document isn't served, err: %v", err)
+	}
+}